@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
@@ -30,8 +31,19 @@ var (
 		Short: "Start stdio server",
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if path := viper.GetString("export_tool_schemas"); path != "" {
+				return exportToolSchemas(path)
+			}
+
+			fixturesMode, err := ghmcp.ParseFixtureMode(viper.GetString("fixtures_mode"))
+			if err != nil {
+				return err
+			}
+
+			// Replaying fixtures never calls GitHub, so no token is needed
 			token := viper.GetString("personal_access_token")
-			if token == "" {
+			replaying := viper.GetString("fixtures_dir") != "" && fixturesMode == ghmcp.FixtureModeReplay
+			if token == "" && !replaying {
 				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
 			}
 
@@ -44,16 +56,63 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			toolTimeouts, err := toolTimeouts()
+			if err != nil {
+				return err
+			}
+
+			toolsetRateLimits, err := toolsetRateLimits()
+			if err != nil {
+				return err
+			}
+
+			var allowedMCPMethods []string
+			if err := viper.UnmarshalKey("allowed_mcp_methods", &allowedMCPMethods); err != nil {
+				return fmt.Errorf("failed to unmarshal allowed MCP methods: %w", err)
+			}
+
+			secretScanner, err := secretScanner()
+			if err != nil {
+				return err
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
+				Version:                 version,
+				Host:                    viper.GetString("host"),
+				Token:                   token,
+				EnabledToolsets:         enabledToolsets,
+				DynamicToolsets:         viper.GetBool("dynamic_toolsets"),
+				ReadOnly:                viper.GetBool("read-only"),
+				ExportTranslations:      viper.GetBool("export-translations"),
+				EnableCommandLogging:    viper.GetBool("enable-command-logging"),
+				LogFilePath:             viper.GetString("log-file"),
+				LogDedupWindow:          viper.GetDuration("log_dedup_window"),
+				OutputFormat:            viper.GetString("output_format"),
+				CircuitBreakerThreshold: viper.GetInt("circuit_breaker_threshold"),
+				CircuitBreakerCooldown:  viper.GetDuration("circuit_breaker_cooldown"),
+				ExitOnUpstreamFailures:  viper.GetInt("exit_on_upstream_failures"),
+				UpstreamFailureWindow:   viper.GetDuration("exit_on_upstream_failures_window"),
+				ETagCacheSize:           viper.GetInt("etag_cache_size"),
+				ToolTimeouts:            toolTimeouts,
+				ToolsetRateLimits:       toolsetRateLimits,
+				SecretScanner:           secretScanner,
+				PaginationConcurrency:   viper.GetInt("pagination_concurrency"),
+				StreamResults:           viper.GetBool("stream_results"),
+				MaxBodyChars:            viper.GetInt("max_body_chars"),
+				AllowedMCPMethods:       allowedMCPMethods,
+				ToolPolicyFile:          viper.GetString("tool_policy"),
+				MaxArgumentChars:        viper.GetInt("max_argument_chars"),
+				ToolPrefix:              viper.GetString("tool_prefix"),
+				SoftErrors:              viper.GetBool("soft_errors"),
+				StartupSelfTest:         viper.GetBool("startup_selftest"),
+				TokenValidationFailOpen: viper.GetBool("token_validation_failopen"),
+				FixturesDir:             viper.GetString("fixtures_dir"),
+				FixturesMode:            fixturesMode,
+				MaxSessionConcurrency:   viper.GetInt("max_session_concurrency"),
+				SessionConcurrencyWait:  viper.GetDuration("session_concurrency_wait"),
+				MaxSessionAPICalls:      viper.GetInt("max_session_api_calls"),
+				AuditWebhookURL:         viper.GetString("audit_webhook_url"),
+				AuthzURL:                viper.GetString("authz_url"),
 			}
 
 			return ghmcp.RunStdioServer(stdioServerConfig)
@@ -65,6 +124,15 @@ var (
 		Short: "Start SSE server with optional authentication support",
 		Long:  `Start a server that communicates via Server-Sent Events over HTTP with optional authentication from gateway headers.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if path := viper.GetString("export_tool_schemas"); path != "" {
+				return exportToolSchemas(path)
+			}
+
+			fixturesMode, err := ghmcp.ParseFixtureMode(viper.GetString("fixtures_mode"))
+			if err != nil {
+				return err
+			}
+
 			token := viper.GetString("personal_access_token")
 			if token == "" {
 				// Check if authentication will be required
@@ -79,6 +147,61 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			var allowedHosts []string
+			if err := viper.UnmarshalKey("allowed_hosts", &allowedHosts); err != nil {
+				return fmt.Errorf("failed to unmarshal allowed hosts: %w", err)
+			}
+
+			var trustedProxies []string
+			if err := viper.UnmarshalKey("trusted_proxies", &trustedProxies); err != nil {
+				return fmt.Errorf("failed to unmarshal trusted proxies: %w", err)
+			}
+
+			var corsAllowedHeaders []string
+			if err := viper.UnmarshalKey("cors_allowed_headers", &corsAllowedHeaders); err != nil {
+				return fmt.Errorf("failed to unmarshal CORS allowed headers: %w", err)
+			}
+
+			var corsAllowedMethods []string
+			if err := viper.UnmarshalKey("cors_allowed_methods", &corsAllowedMethods); err != nil {
+				return fmt.Errorf("failed to unmarshal CORS allowed methods: %w", err)
+			}
+
+			var logContextHeaders []string
+			if err := viper.UnmarshalKey("log_context_headers", &logContextHeaders); err != nil {
+				return fmt.Errorf("failed to unmarshal log context headers: %w", err)
+			}
+
+			var requiredHeaders []string
+			if err := viper.UnmarshalKey("require_headers", &requiredHeaders); err != nil {
+				return fmt.Errorf("failed to unmarshal required headers: %w", err)
+			}
+
+			logSampleRate := viper.GetFloat64("log_sample_rate")
+			if logSampleRate < 0 || logSampleRate > 1 {
+				return fmt.Errorf("invalid log sample rate %v, expected a fraction between 0 and 1", logSampleRate)
+			}
+
+			toolTimeouts, err := toolTimeouts()
+			if err != nil {
+				return err
+			}
+
+			toolsetRateLimits, err := toolsetRateLimits()
+			if err != nil {
+				return err
+			}
+
+			var allowedMCPMethods []string
+			if err := viper.UnmarshalKey("allowed_mcp_methods", &allowedMCPMethods); err != nil {
+				return fmt.Errorf("failed to unmarshal allowed MCP methods: %w", err)
+			}
+
+			secretScanner, err := secretScanner()
+			if err != nil {
+				return err
+			}
+
 			port := os.Getenv("PORT")
 			if port == "" {
 				port = "8080"
@@ -86,20 +209,67 @@ var (
 
 			// Use the existing SSEServerConfig structure
 			sseServerConfig := ghmcp.SSEServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ListenAddr:           ":" + port,
-				BaseURL:              viper.GetString("base-url"),
-				BasePath:             "",
-				KeepAlive:            true,
-				KeepAliveInterval:    30 * time.Second,
+				Version:                 version,
+				Host:                    viper.GetString("host"),
+				Token:                   token,
+				EnabledToolsets:         enabledToolsets,
+				DynamicToolsets:         viper.GetBool("dynamic_toolsets"),
+				ReadOnly:                viper.GetBool("read-only"),
+				ExportTranslations:      viper.GetBool("export-translations"),
+				EnableCommandLogging:    viper.GetBool("enable-command-logging"),
+				LogFilePath:             viper.GetString("log-file"),
+				LogDedupWindow:          viper.GetDuration("log_dedup_window"),
+				OutputFormat:            viper.GetString("output_format"),
+				CircuitBreakerThreshold: viper.GetInt("circuit_breaker_threshold"),
+				CircuitBreakerCooldown:  viper.GetDuration("circuit_breaker_cooldown"),
+				ExitOnUpstreamFailures:  viper.GetInt("exit_on_upstream_failures"),
+				UpstreamFailureWindow:   viper.GetDuration("exit_on_upstream_failures_window"),
+				ETagCacheSize:           viper.GetInt("etag_cache_size"),
+				ToolTimeouts:            toolTimeouts,
+				ToolsetRateLimits:       toolsetRateLimits,
+				SecretScanner:           secretScanner,
+				PaginationConcurrency:   viper.GetInt("pagination_concurrency"),
+				StreamResults:           viper.GetBool("stream_results"),
+				MaxBodyChars:            viper.GetInt("max_body_chars"),
+				AllowedMCPMethods:       allowedMCPMethods,
+				ToolPolicyFile:          viper.GetString("tool_policy"),
+				MaxArgumentChars:        viper.GetInt("max_argument_chars"),
+				ToolPrefix:              viper.GetString("tool_prefix"),
+				SoftErrors:              viper.GetBool("soft_errors"),
+				StartupSelfTest:         viper.GetBool("startup_selftest"),
+				TokenValidationFailOpen: viper.GetBool("token_validation_failopen"),
+				FixturesDir:             viper.GetString("fixtures_dir"),
+				FixturesMode:            fixturesMode,
+				MaxSessionConcurrency:   viper.GetInt("max_session_concurrency"),
+				SessionConcurrencyWait:  viper.GetDuration("session_concurrency_wait"),
+				MaxSessionAPICalls:      viper.GetInt("max_session_api_calls"),
+				AuditWebhookURL:         viper.GetString("audit_webhook_url"),
+				AuthzURL:                viper.GetString("authz_url"),
+				TokenExchangeURL:        viper.GetString("token_exchange_url"),
+				TokenExchangeTTL:        viper.GetDuration("token_exchange_ttl"),
+				LogContextHeaders:       logContextHeaders,
+				LogSampleRate:           logSampleRate,
+				RequiredHeaders:         requiredHeaders,
+				RequestIDHeader:         viper.GetString("request_id_header"),
+				AllowedHosts:            allowedHosts,
+				TrustedProxies:          trustedProxies,
+				CORSAllowedHeaders:      corsAllowedHeaders,
+				CORSAllowedMethods:      corsAllowedMethods,
+				StatusRequiresAuth:      viper.GetBool("status_requires_auth"),
+				DisableStatus:           viper.GetBool("disable_status"),
+				MaxSSEConnections:       viper.GetInt("max_sse_connections"),
+				SSERetryBase:            viper.GetDuration("sse_retry_base"),
+				SSERetryMax:             viper.GetDuration("sse_retry_max"),
+				AdminToken:              viper.GetString("admin_token"),
+				MaintenanceRetryAfter:   viper.GetDuration("maintenance_retry_after"),
+				ShutdownGracePeriod:     viper.GetDuration("shutdown_grace_period"),
+				ShutdownReconnectDelay:  viper.GetDuration("shutdown_reconnect_delay"),
+				ListenAddr:              ":" + port,
+				BaseURL:                 viper.GetString("base-url"),
+				BasePath:                "",
+				KeepAlive:               true,
+				KeepAliveInterval:       30 * time.Second,
+				KeepAliveComment:        viper.GetString("sse_keepalive_comment"),
 			}
 
 			// Use the new authentication-aware SSE server instead of the original
@@ -119,35 +289,275 @@ func init() {
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
+	rootCmd.PersistentFlags().Duration("log-dedup-window", 0, "Collapse identical consecutive log lines written within this window into one with a repeat count, 0 disables it")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
+	rootCmd.PersistentFlags().String("export-tool-schemas", "", "Write the schemas of the enabled tools to a JSON file at this path and exit")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().String("output-format", "compact", "Format of JSON tool results, either compact or pretty")
+	rootCmd.PersistentFlags().Int("circuit-breaker-threshold", 0, "Number of consecutive GitHub API failures that opens the circuit breaker, 0 disables it")
+	rootCmd.PersistentFlags().Duration("circuit-breaker-cooldown", 30*time.Second, "How long the circuit breaker stays open before probing the GitHub API again")
+	rootCmd.PersistentFlags().Int("exit-on-upstream-failures", 0, "Exit with a non-zero code after this many consecutive GitHub API failures within --exit-on-upstream-failures-window, for a supervisor to restart the server, 0 disables it")
+	rootCmd.PersistentFlags().Duration("exit-on-upstream-failures-window", 5*time.Minute, "Time within which the consecutive GitHub API failures counted by --exit-on-upstream-failures have to happen")
+	rootCmd.PersistentFlags().Int("etag-cache-size", 0, "Number of GitHub API responses kept and revalidated with their ETag, served from the cache when unchanged without using rate limit, 0 disables the cache")
+	rootCmd.PersistentFlags().Duration("tool-call-timeout", 0, "Maximum duration of a tool call, 0 disables the timeout")
+	rootCmd.PersistentFlags().Bool("secret-scan", false, "Refuse to write file content that looks like it contains secrets, unless the tool call sets allow_secrets")
+	rootCmd.PersistentFlags().String("secret-patterns-file", "", "File of name=regex secret patterns, one per line, replacing the built-in patterns")
+	rootCmd.PersistentFlags().Int("pagination-concurrency", 4, "Maximum number of pages fetched at once by tools that read a whole list, 1 fetches pages one at a time")
+	rootCmd.PersistentFlags().Bool("stream-results", false, "Send each page tools reading a whole list fetch to the client as a partial result notification before the final result")
+	rootCmd.PersistentFlags().Int("max-body-chars", 0, "Truncate body fields of tool results, such as issue and comment bodies, longer than this many characters, 0 disables truncation")
+	rootCmd.PersistentFlags().StringSlice("allowed-mcp-methods", nil, "Comma separated list of MCP method families to accept, among tools, resources, prompts and logging, requests of other families are rejected. Defaults to all of them")
+	rootCmd.PersistentFlags().String("tool-policy", "", "YAML file enabling or disabling individual tools and making toolsets read-only, applied on top of --toolsets")
+	rootCmd.PersistentFlags().Int("max-argument-chars", ghmcp.DefaultMaxArgumentChars, "Maximum length in characters of each string argument of a tool call, longer ones fail with argument_too_large. 0 disables the limit, the tool policy may override it per tool")
+	rootCmd.PersistentFlags().String("tool-prefix", "", "Prefix prepended to the name of every tool, such as gh_, to tell them apart from the tools of other MCP servers")
+	rootCmd.PersistentFlags().Bool("startup-selftest", false, "Probe the permissions of the token for each enabled toolset at startup, failing only if the token cannot be used at all")
+	rootCmd.PersistentFlags().Bool("token-validation-failopen", false, "Start anyway when the startup self-test cannot validate the token because GitHub is unreachable or failing. A token rejected with 401 always stops the server. Requires --startup-selftest")
+	rootCmd.PersistentFlags().String("fixtures-dir", "", "Directory of recorded tool results, keyed by tool and arguments, served instead of calling GitHub")
+	rootCmd.PersistentFlags().String("fixtures-mode", "replay", "Either replay, serving tool results from --fixtures-dir, or record, writing live tool results into it")
+	rootCmd.PersistentFlags().Int("max-session-concurrency", 0, "Maximum number of tool calls a client session may have in flight, 0 means no limit")
+	rootCmd.PersistentFlags().Duration("session-concurrency-wait", 0, "How long a tool call over --max-session-concurrency waits for a slot before it fails with too_many_concurrent_calls, 0 fails it at once")
+	rootCmd.PersistentFlags().Int("max-session-api-calls", 0, "Maximum number of GitHub API calls a client session may make, after which its tool calls fail with session_api_budget_exhausted, 0 means no limit")
+	rootCmd.PersistentFlags().String("audit-webhook-url", "", "URL to POST an audit event for every tool call to as JSON, retried and dropped when the queue is full")
+	rootCmd.PersistentFlags().String("authz-url", "", "URL asked for an allow or deny decision before every tool call, calls it denies or does not answer for fail with access_denied")
+	rootCmd.PersistentFlags().Bool("soft-errors", false, "Return not found, validation and rate limit errors from GitHub as tool results with an error field instead of failing the tool call")
+	rootCmd.PersistentFlags().StringSlice("tool-category-timeouts", nil, "Comma separated list of category=duration timeouts overriding --tool-call-timeout, categories are read, write and search")
+	rootCmd.PersistentFlags().StringSlice("toolset-rate-limits", nil, "Comma separated list of toolset=calls/unit rate limits, e.g. actions=60/m, applied per authenticated user. The read, write and search tool categories may be named instead of a toolset")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
+	_ = viper.BindPFlag("log_dedup_window", rootCmd.PersistentFlags().Lookup("log-dedup-window"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
+	_ = viper.BindPFlag("export_tool_schemas", rootCmd.PersistentFlags().Lookup("export-tool-schemas"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("output_format", rootCmd.PersistentFlags().Lookup("output-format"))
+	_ = viper.BindPFlag("circuit_breaker_threshold", rootCmd.PersistentFlags().Lookup("circuit-breaker-threshold"))
+	_ = viper.BindPFlag("circuit_breaker_cooldown", rootCmd.PersistentFlags().Lookup("circuit-breaker-cooldown"))
+	_ = viper.BindPFlag("exit_on_upstream_failures", rootCmd.PersistentFlags().Lookup("exit-on-upstream-failures"))
+	_ = viper.BindPFlag("exit_on_upstream_failures_window", rootCmd.PersistentFlags().Lookup("exit-on-upstream-failures-window"))
+	_ = viper.BindPFlag("etag_cache_size", rootCmd.PersistentFlags().Lookup("etag-cache-size"))
+	_ = viper.BindPFlag("tool_call_timeout", rootCmd.PersistentFlags().Lookup("tool-call-timeout"))
+	_ = viper.BindPFlag("tool_category_timeouts", rootCmd.PersistentFlags().Lookup("tool-category-timeouts"))
+	_ = viper.BindPFlag("toolset_rate_limits", rootCmd.PersistentFlags().Lookup("toolset-rate-limits"))
+	_ = viper.BindPFlag("secret_scan", rootCmd.PersistentFlags().Lookup("secret-scan"))
+	_ = viper.BindPFlag("secret_patterns_file", rootCmd.PersistentFlags().Lookup("secret-patterns-file"))
+	_ = viper.BindPFlag("pagination_concurrency", rootCmd.PersistentFlags().Lookup("pagination-concurrency"))
+	_ = viper.BindPFlag("stream_results", rootCmd.PersistentFlags().Lookup("stream-results"))
+	_ = viper.BindPFlag("max_body_chars", rootCmd.PersistentFlags().Lookup("max-body-chars"))
+	_ = viper.BindPFlag("allowed_mcp_methods", rootCmd.PersistentFlags().Lookup("allowed-mcp-methods"))
+	_ = viper.BindPFlag("tool_policy", rootCmd.PersistentFlags().Lookup("tool-policy"))
+	_ = viper.BindPFlag("max_argument_chars", rootCmd.PersistentFlags().Lookup("max-argument-chars"))
+	_ = viper.BindPFlag("tool_prefix", rootCmd.PersistentFlags().Lookup("tool-prefix"))
+	_ = viper.BindPFlag("soft_errors", rootCmd.PersistentFlags().Lookup("soft-errors"))
+	_ = viper.BindPFlag("startup_selftest", rootCmd.PersistentFlags().Lookup("startup-selftest"))
+	_ = viper.BindPFlag("token_validation_failopen", rootCmd.PersistentFlags().Lookup("token-validation-failopen"))
+	_ = viper.BindPFlag("fixtures_dir", rootCmd.PersistentFlags().Lookup("fixtures-dir"))
+	_ = viper.BindPFlag("fixtures_mode", rootCmd.PersistentFlags().Lookup("fixtures-mode"))
+	_ = viper.BindPFlag("max_session_concurrency", rootCmd.PersistentFlags().Lookup("max-session-concurrency"))
+	_ = viper.BindPFlag("session_concurrency_wait", rootCmd.PersistentFlags().Lookup("session-concurrency-wait"))
+	_ = viper.BindPFlag("max_session_api_calls", rootCmd.PersistentFlags().Lookup("max-session-api-calls"))
+	_ = viper.BindPFlag("audit_webhook_url", rootCmd.PersistentFlags().Lookup("audit-webhook-url"))
+	_ = viper.BindPFlag("authz_url", rootCmd.PersistentFlags().Lookup("authz-url"))
 
 	// Add SSE-specific flags
 	sseCmd.Flags().String("base-url", "", "Base URL for the SSE server")
 	sseCmd.Flags().Bool("allow-unauthenticated", false, "Allow unauthenticated requests (for testing)")
+	sseCmd.Flags().StringSlice("log-context-headers", nil, "Comma separated list of request headers to include on every log line for a request")
+	sseCmd.Flags().Float64("log-sample-rate", 1, "Fraction of successfully authenticated requests logged at info level, chosen by request ID. Failed authentications are always logged")
+	sseCmd.Flags().StringSlice("require-headers", nil, "Comma separated list of request headers every request to the MCP endpoints must carry, requests missing one are rejected with 400")
+	sseCmd.Flags().String("request-id-header", "", "Header carrying the X-Gateway-Request-ID of a request on the GitHub API requests its tool calls make, empty disables it")
+	sseCmd.Flags().StringSlice("allowed-hosts", nil, "Comma separated list of additional GitHub hosts a request may select with the X-GitHub-Host header, each as host=TOKEN_ENV_VAR naming the environment variable holding its token")
+	sseCmd.Flags().StringSlice("trusted-proxies", nil, "Comma separated list of proxy CIDRs whose X-Forwarded-For and X-Real-IP headers are trusted for the client IP")
+	sseCmd.Flags().StringSlice("cors-allowed-headers", nil, "Comma separated list of request headers browsers may send, in addition to the default ones")
+	sseCmd.Flags().StringSlice("cors-allowed-methods", nil, "Comma separated list of methods browsers may use, replacing the default GET, POST, PUT, DELETE and OPTIONS")
+	sseCmd.Flags().Bool("status-requires-auth", false, "Require authentication for the /status endpoint, /health stays open")
+	sseCmd.Flags().Bool("disable-status", false, "Disable the /status endpoint")
+	sseCmd.Flags().Int("max-sse-connections", 0, "Maximum number of open SSE connections, 0 means no limit")
+	sseCmd.Flags().Duration("sse-retry-base", time.Second, "Reconnect delay suggested to the first client shed over the SSE connection limit")
+	sseCmd.Flags().Duration("sse-retry-max", time.Minute, "Upper bound of the reconnect delay, which doubles for every client shed in a row")
+	sseCmd.Flags().String("admin-token", "", "Bearer token guarding the /admin endpoints, which are disabled when empty")
+	sseCmd.Flags().Duration("maintenance-retry-after", time.Minute, "Retry-After sent by the MCP endpoints while in maintenance mode")
+	sseCmd.Flags().Duration("shutdown-grace-period", 0, "How long open SSE streams may finish after clients are notified of shutdown, new streams are refused meanwhile, 0 shuts down without notifying")
+	sseCmd.Flags().Duration("shutdown-reconnect-delay", 5*time.Second, "Reconnect delay suggested to clients while the server shuts down")
+	sseCmd.Flags().String("token-exchange-url", "", "URL of a token service asked for the GitHub token of each authenticated user, whose calls use the configured token when it is not set")
+	sseCmd.Flags().Duration("token-exchange-ttl", 5*time.Minute, "How long the GitHub token of a user is cached before it is exchanged again")
+	sseCmd.Flags().String("sse-keepalive-comment", ghmcp.DefaultSSEKeepAliveComment, "SSE comment written to idle streams every 30s to keep proxies from dropping them, must begin with ':', empty disables it")
 
 	_ = viper.BindPFlag("base-url", sseCmd.Flags().Lookup("base-url"))
 	_ = viper.BindPFlag("allow_unauthenticated", sseCmd.Flags().Lookup("allow-unauthenticated"))
+	_ = viper.BindPFlag("log_context_headers", sseCmd.Flags().Lookup("log-context-headers"))
+	_ = viper.BindPFlag("log_sample_rate", sseCmd.Flags().Lookup("log-sample-rate"))
+	_ = viper.BindPFlag("require_headers", sseCmd.Flags().Lookup("require-headers"))
+	_ = viper.BindPFlag("request_id_header", sseCmd.Flags().Lookup("request-id-header"))
+	_ = viper.BindPFlag("allowed_hosts", sseCmd.Flags().Lookup("allowed-hosts"))
+	_ = viper.BindPFlag("trusted_proxies", sseCmd.Flags().Lookup("trusted-proxies"))
+	_ = viper.BindPFlag("cors_allowed_headers", sseCmd.Flags().Lookup("cors-allowed-headers"))
+	_ = viper.BindPFlag("cors_allowed_methods", sseCmd.Flags().Lookup("cors-allowed-methods"))
+	_ = viper.BindPFlag("status_requires_auth", sseCmd.Flags().Lookup("status-requires-auth"))
+	_ = viper.BindPFlag("disable_status", sseCmd.Flags().Lookup("disable-status"))
+	_ = viper.BindPFlag("max_sse_connections", sseCmd.Flags().Lookup("max-sse-connections"))
+	_ = viper.BindPFlag("sse_retry_base", sseCmd.Flags().Lookup("sse-retry-base"))
+	_ = viper.BindPFlag("sse_retry_max", sseCmd.Flags().Lookup("sse-retry-max"))
+	_ = viper.BindPFlag("admin_token", sseCmd.Flags().Lookup("admin-token"))
+	_ = viper.BindPFlag("maintenance_retry_after", sseCmd.Flags().Lookup("maintenance-retry-after"))
+	_ = viper.BindPFlag("shutdown_grace_period", sseCmd.Flags().Lookup("shutdown-grace-period"))
+	_ = viper.BindPFlag("shutdown_reconnect_delay", sseCmd.Flags().Lookup("shutdown-reconnect-delay"))
+	_ = viper.BindPFlag("token_exchange_url", sseCmd.Flags().Lookup("token-exchange-url"))
+	_ = viper.BindPFlag("token_exchange_ttl", sseCmd.Flags().Lookup("token-exchange-ttl"))
+	_ = viper.BindPFlag("sse_keepalive_comment", sseCmd.Flags().Lookup("sse-keepalive-comment"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
 	rootCmd.AddCommand(sseCmd)
 }
 
+// toolTimeouts reads the global and per tool category timeouts
+func toolTimeouts() (ghmcp.ToolTimeouts, error) {
+	var categoryTimeouts []string
+	if err := viper.UnmarshalKey("tool_category_timeouts", &categoryTimeouts); err != nil {
+		return ghmcp.ToolTimeouts{}, fmt.Errorf("failed to unmarshal tool category timeouts: %w", err)
+	}
+
+	perCategory, err := ghmcp.ParseToolCategoryTimeouts(categoryTimeouts)
+	if err != nil {
+		return ghmcp.ToolTimeouts{}, err
+	}
+
+	return ghmcp.ToolTimeouts{
+		Default:     viper.GetDuration("tool_call_timeout"),
+		PerCategory: perCategory,
+	}, nil
+}
+
+// toolsetRateLimits reads the rate limits of toolsets and tool categories
+func toolsetRateLimits() (map[string]ghmcp.ToolsetRateLimit, error) {
+	var entries []string
+	if err := viper.UnmarshalKey("toolset_rate_limits", &entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal toolset rate limits: %w", err)
+	}
+	return ghmcp.ParseToolsetRateLimits(entries)
+}
+
+// secretScanner builds the scanner guarding the file tools, or returns nil when secret scanning is disabled
+func secretScanner() (*github.SecretScanner, error) {
+	if !viper.GetBool("secret_scan") {
+		return nil, nil
+	}
+
+	path := viper.GetString("secret_patterns_file")
+	if path == "" {
+		return github.NewSecretScanner(nil), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open secret patterns file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	patterns, err := github.ParseSecretPatterns(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secret patterns file: %w", err)
+	}
+	return github.NewSecretScanner(patterns), nil
+}
+
+// exportToolSchemas writes the schemas of the tools selected by the toolsets and read-only flags to path
+func exportToolSchemas(path string) error {
+	var enabledToolsets []string
+	if err := viper.UnmarshalKey("toolsets", &enabledToolsets); err != nil {
+		return fmt.Errorf("failed to unmarshal toolsets: %w", err)
+	}
+
+	return ghmcp.ExportToolSchemas(ghmcp.ExportToolSchemasConfig{
+		Path:            path,
+		EnabledToolsets: enabledToolsets,
+		ReadOnly:        viper.GetBool("read-only"),
+	})
+}
+
+// envKeys are the only configuration keys read from the environment, so that unrelated GITHUB_*
+// variables in a shared environment never change the configuration. A flag bound to viper is only
+// configurable from the environment once its key is listed here, see envVarName for the variable name.
+var envKeys = []string{
+	"personal_access_token",
+	"host",
+	"toolsets",
+	"dynamic_toolsets",
+	"read-only",
+	"log-file",
+	"log_dedup_window",
+	"enable-command-logging",
+	"export-translations",
+	"export_tool_schemas",
+	"output_format",
+	"circuit_breaker_threshold",
+	"circuit_breaker_cooldown",
+	"exit_on_upstream_failures",
+	"exit_on_upstream_failures_window",
+	"etag_cache_size",
+	"tool_call_timeout",
+	"tool_category_timeouts",
+	"toolset_rate_limits",
+	"secret_scan",
+	"secret_patterns_file",
+	"pagination_concurrency",
+	"stream_results",
+	"max_body_chars",
+	"allowed_mcp_methods",
+	"tool_policy",
+	"max_argument_chars",
+	"tool_prefix",
+	"soft_errors",
+	"startup_selftest",
+	"token_validation_failopen",
+	"fixtures_dir",
+	"fixtures_mode",
+	"max_session_concurrency",
+	"session_concurrency_wait",
+	"max_session_api_calls",
+	"audit_webhook_url",
+	"authz_url",
+
+	// sse only
+	"base-url",
+	"allow_unauthenticated",
+	"log_context_headers",
+	"log_sample_rate",
+	"require_headers",
+	"request_id_header",
+	"allowed_hosts",
+	"trusted_proxies",
+	"cors_allowed_headers",
+	"cors_allowed_methods",
+	"status_requires_auth",
+	"disable_status",
+	"max_sse_connections",
+	"sse_retry_base",
+	"sse_retry_max",
+	"admin_token",
+	"maintenance_retry_after",
+	"shutdown_grace_period",
+	"shutdown_reconnect_delay",
+	"token_exchange_url",
+	"token_exchange_ttl",
+	"sse_keepalive_comment",
+}
+
+// envVarName returns the environment variable a configuration key is read from, GITHUB_ followed by the
+// key in upper case with dashes replaced by underscores, e.g. GITHUB_READ_ONLY for read-only
+func envVarName(key string) string {
+	return "GITHUB_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
 func initConfig() {
 	// Initialize Viper configuration
-	viper.SetEnvPrefix("github")
-	viper.AutomaticEnv()
+	for _, key := range envKeys {
+		_ = viper.BindEnv(key, envVarName(key))
+	}
 }
 
 func main() {
@@ -0,0 +1,100 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrorCodeArgumentTooLarge is returned for tool calls with a string argument over the length limit of the tool
+const ErrorCodeArgumentTooLarge = "argument_too_large"
+
+// DefaultMaxArgumentChars is the default length limit of string arguments, generous enough for any file or
+// comment body an agent should be writing, while bounding what a single call can make the server hold
+const DefaultMaxArgumentChars = 1_000_000
+
+// argumentLimits are the length limits in characters of the string arguments of tool calls. 0 means no limit.
+type argumentLimits struct {
+	// Default applies to the tools without a limit of their own
+	Default int
+
+	// PerTool overrides Default for individual tools, keyed by tool name
+	PerTool map[string]int
+}
+
+// limit returns the limit for the tool called name
+func (l argumentLimits) limit(name string) int {
+	if limit, ok := l.PerTool[name]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// oversizedArgument returns the path of the first string in value longer than limit characters, such as
+// files[1].content, and its length. Strings nested in arrays and objects are checked too.
+func oversizedArgument(path string, value any, limit int) (string, int, bool) {
+	switch v := value.(type) {
+	case string:
+		// A string cannot have more characters than bytes, so only long ones need counting
+		if len(v) <= limit {
+			return "", 0, false
+		}
+		if length := utf8.RuneCountInString(v); length > limit {
+			return path, length, true
+		}
+	case []any:
+		for i, item := range v {
+			if p, length, ok := oversizedArgument(fmt.Sprintf("%s[%d]", path, i), item, limit); ok {
+				return p, length, true
+			}
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if p, length, ok := oversizedArgument(path+"."+key, v[key], limit); ok {
+				return p, length, true
+			}
+		}
+	}
+	return "", 0, false
+}
+
+// argumentLimitMiddleware rejects tool calls with a string argument over the limit of their tool with an
+// argument_too_large tool error, before the tool makes any GitHub API call. limits is read at call time, so
+// that the per-tool limits of the tool policy can be filled in once the policy is loaded.
+func argumentLimitMiddleware(limits *argumentLimits) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			limit := limits.limit(request.Params.Name)
+			if limit <= 0 {
+				return next(ctx, request)
+			}
+
+			args := request.GetArguments()
+			for _, name := range slices.Sorted(maps.Keys(args)) {
+				argument, length, ok := oversizedArgument(name, args[name], limit)
+				if !ok {
+					continue
+				}
+
+				r, err := json.Marshal(map[string]any{
+					"error":     ErrorCodeArgumentTooLarge,
+					"message":   fmt.Sprintf("%s is %d characters long, over the limit of %d for %s", argument, length, limit, request.Params.Name),
+					"argument":  argument,
+					"length":    length,
+					"max_chars": limit,
+				})
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("%s: %s", ErrorCodeArgumentTooLarge, argument)), nil
+				}
+				return mcp.NewToolResultError(string(r)), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ArgumentLimitMiddleware(t *testing.T) {
+	limits := &argumentLimits{Default: 10, PerTool: map[string]int{"create_or_update_file": 20, "get_file_contents": 0}}
+
+	called := false
+	handler := argumentLimitMiddleware(limits)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return mcp.NewToolResultText("ok"), nil
+	})
+	call := func(tool string, args map[string]any) *mcp.CallToolResult {
+		called = false
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool
+		request.Params.Arguments = args
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Characters are counted rather than bytes
+	result := call("add_issue_comment", map[string]any{"body": strings.Repeat("é", 10), "issue_number": float64(1)})
+	assert.False(t, result.IsError)
+	assert.True(t, called)
+
+	result = call("add_issue_comment", map[string]any{"body": strings.Repeat("a", 11)})
+	require.True(t, result.IsError)
+	assert.False(t, called)
+	var rejection map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &rejection))
+	assert.Equal(t, map[string]any{
+		"error":     "argument_too_large",
+		"message":   "body is 11 characters long, over the limit of 10 for add_issue_comment",
+		"argument":  "body",
+		"length":    float64(11),
+		"max_chars": float64(10),
+	}, rejection)
+
+	// The tool policy overrides the default
+	result = call("create_or_update_file", map[string]any{"content": strings.Repeat("a", 20)})
+	assert.False(t, result.IsError)
+	result = call("get_file_contents", map[string]any{"path": strings.Repeat("a", 1000)})
+	assert.False(t, result.IsError)
+
+	// Nested strings are checked too
+	result = call("push_files", map[string]any{
+		"files": []any{
+			map[string]any{"path": "a.txt", "content": "short"},
+			map[string]any{"path": "b.txt", "content": strings.Repeat("a", 11)},
+		},
+	})
+	require.True(t, result.IsError)
+	assert.False(t, called)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, `"argument":"files[1].content"`)
+}
@@ -0,0 +1,219 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// auditWebhookQueueSize bounds the audit events waiting to be sent, events over it are dropped
+	auditWebhookQueueSize = 1000
+
+	// auditWebhookAttempts is how many times an event is sent before it is given up on
+	auditWebhookAttempts = 3
+
+	// auditWebhookTimeout bounds each attempt to send an event
+	auditWebhookTimeout = 10 * time.Second
+)
+
+// Audit event statuses
+const (
+	AuditStatusSuccess = "success"
+	AuditStatusError   = "error"
+	AuditStatusFailed  = "failed"
+)
+
+// AuditEvent records a tool call. Arguments are left out, they may hold file contents or secrets.
+type AuditEvent struct {
+	Time       time.Time `json:"time"`
+	Tool       string    `json:"tool"`
+	Status     string    `json:"status"`
+	DurationMS int64     `json:"duration_ms"`
+	UserID     string    `json:"user_id,omitempty"`
+	UserEmail  string    `json:"user_email,omitempty"`
+	UserName   string    `json:"user_name,omitempty"`
+	SessionID  string    `json:"session_id,omitempty"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditWebhookStatus counts what happened to the audit events, as reported by /status
+type AuditWebhookStatus struct {
+	Queued  int   `json:"queued"`
+	Sent    int64 `json:"sent"`
+	Failed  int64 `json:"failed"`
+	Dropped int64 `json:"dropped"`
+}
+
+// AuditWebhook POSTs audit events as JSON to a URL, such as the collector of a SIEM. Events are queued
+// and sent one at a time by Run, so that a slow or failing endpoint never holds up tool calls. Events
+// that find the queue full are dropped, and events that fail every attempt are given up on, both are
+// counted and logged.
+type AuditWebhook struct {
+	url          string
+	client       *http.Client
+	queue        chan AuditEvent
+	retryBackoff time.Duration
+
+	sent    atomic.Int64
+	failed  atomic.Int64
+	dropped atomic.Int64
+}
+
+// NewAuditWebhook checks rawURL and returns a webhook sending to it. Nothing is sent until Run is called.
+func NewAuditWebhook(rawURL string) (*AuditWebhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid audit webhook URL %q, expected an http or https URL", rawURL)
+	}
+	return &AuditWebhook{
+		url:          rawURL,
+		client:       &http.Client{Timeout: auditWebhookTimeout},
+		queue:        make(chan AuditEvent, auditWebhookQueueSize),
+		retryBackoff: time.Second,
+	}, nil
+}
+
+// startAuditWebhook returns a running webhook for rawURL, or nil when rawURL is empty. It stops with ctx.
+func startAuditWebhook(ctx context.Context, rawURL string) (*AuditWebhook, error) {
+	if rawURL == "" {
+		return nil, nil
+	}
+	webhook, err := NewAuditWebhook(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	go webhook.Run(ctx)
+	logrus.WithField("audit_webhook", webhook.url).Info("Sending audit events to webhook")
+	return webhook, nil
+}
+
+// Record queues event without blocking, dropping it when the queue is full
+func (w *AuditWebhook) Record(event AuditEvent) {
+	select {
+	case w.queue <- event:
+	default:
+		dropped := w.dropped.Add(1)
+		logrus.WithFields(logrus.Fields{
+			"tool":    event.Tool,
+			"dropped": dropped,
+		}).Warn("Audit webhook queue is full, dropped audit event")
+	}
+}
+
+// Run sends the queued events until ctx ends. Events still queued then are not sent.
+func (w *AuditWebhook) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-w.queue:
+			w.send(ctx, event)
+		}
+	}
+}
+
+// send POSTs event, retrying failed attempts with exponential backoff
+func (w *AuditWebhook) send(ctx context.Context, event AuditEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		w.failed.Add(1)
+		logrus.WithError(err).Warn("Failed to encode audit event")
+		return
+	}
+
+	backoff := w.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err = w.post(ctx, body)
+		if err == nil {
+			w.sent.Add(1)
+			return
+		}
+		if attempt == auditWebhookAttempts || ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	failed := w.failed.Add(1)
+	logrus.WithError(err).WithFields(logrus.Fields{
+		"tool":   event.Tool,
+		"failed": failed,
+	}).Warn("Failed to send audit event to webhook")
+}
+
+func (w *AuditWebhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("audit webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// Status returns the counters of the webhook
+func (w *AuditWebhook) Status() AuditWebhookStatus {
+	return AuditWebhookStatus{
+		Queued:  len(w.queue),
+		Sent:    w.sent.Load(),
+		Failed:  w.failed.Load(),
+		Dropped: w.dropped.Load(),
+	}
+}
+
+// auditMiddleware records an audit event for every tool call, with the gateway user making it if any
+func auditMiddleware(webhook *AuditWebhook) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+
+			event := AuditEvent{
+				Time:       start.UTC(),
+				Tool:       request.Params.Name,
+				Status:     AuditStatusSuccess,
+				DurationMS: time.Since(start).Milliseconds(),
+			}
+			switch {
+			case err != nil:
+				event.Status = AuditStatusFailed
+				event.Error = err.Error()
+			case result != nil && result.IsError:
+				event.Status = AuditStatusError
+			}
+			if userCtx, ok := GetUserContext(ctx); ok {
+				event.UserID = userCtx.UserID
+				event.UserEmail = userCtx.Email
+				event.UserName = userCtx.Name
+				event.SessionID = userCtx.SessionID
+				event.RequestID = userCtx.RequestID
+			}
+			webhook.Record(event)
+
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewAuditWebhook(t *testing.T) {
+	_, err := NewAuditWebhook("https://siem.example.com/events")
+	require.NoError(t, err)
+
+	for _, rawURL := range []string{"siem.example.com/events", "ftp://siem.example.com", "https://"} {
+		_, err := NewAuditWebhook(rawURL)
+		assert.Error(t, err, rawURL)
+	}
+
+	webhook, err := startAuditWebhook(context.Background(), "")
+	require.NoError(t, err)
+	assert.Nil(t, webhook)
+}
+
+func Test_AuditMiddleware(t *testing.T) {
+	events := make(chan AuditEvent, 10)
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt fails, so the event is only received on the retry
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var event AuditEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events <- event
+	}))
+	defer srv.Close()
+
+	webhook, err := NewAuditWebhook(srv.URL)
+	require.NoError(t, err)
+	webhook.retryBackoff = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go webhook.Run(ctx)
+
+	handler := auditMiddleware(webhook)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("not found"), nil
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_issue"
+	callCtx := WithUserContext(context.Background(), &UserContext{UserID: "u1", Email: "octocat@example.com", SessionID: "s1"})
+	_, err = handler(callCtx, request)
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "get_issue", event.Tool)
+		assert.Equal(t, AuditStatusError, event.Status)
+		assert.Equal(t, "u1", event.UserID)
+		assert.Equal(t, "octocat@example.com", event.UserEmail)
+		assert.Equal(t, "s1", event.SessionID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("audit event was not sent")
+	}
+	assert.Eventually(t, func() bool { return webhook.Status().Sent == 1 }, 5*time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func Test_AuditWebhook_DropsWhenFull(t *testing.T) {
+	webhook, err := NewAuditWebhook("http://127.0.0.1:1/events")
+	require.NoError(t, err)
+
+	// Nothing runs the webhook, so the queue fills up and further events are dropped
+	for range auditWebhookQueueSize + 2 {
+		webhook.Record(AuditEvent{Tool: "get_me"})
+	}
+	status := webhook.Status()
+	assert.Equal(t, auditWebhookQueueSize, status.Queued)
+	assert.Equal(t, int64(2), status.Dropped)
+}
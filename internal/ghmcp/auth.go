@@ -3,10 +3,14 @@ package ghmcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"net/http"
 	"strings"
 
+	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,6 +22,8 @@ type UserContext struct {
 	SessionID string
 	Token     string
 	RequestID string
+	// Locale is the raw X-Locale or Accept-Language preference of the client, see requestLocale
+	Locale string
 }
 
 // contextKey is a type for context keys to avoid collisions
@@ -59,6 +65,7 @@ func extractUserContext(r *http.Request) (*UserContext, error) {
 		SessionID: sessionID,
 		Token:     token,
 		RequestID: requestID,
+		Locale:    requestLocale(r),
 	}, nil
 }
 
@@ -73,78 +80,200 @@ func WithUserContext(ctx context.Context, userCtx *UserContext) context.Context
 	return context.WithValue(ctx, userContextKey, userCtx)
 }
 
-// AuthenticationMiddleware extracts user context from headers and adds to request context
-func AuthenticationMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Log incoming request headers for debugging
-		logrus.WithFields(logrus.Fields{
-			"method": r.Method,
-			"path":   r.URL.Path,
-			"remote": r.RemoteAddr,
-		}).Debug("Incoming request")
-
-		// Extract user context from headers
-		userCtx, err := extractUserContext(r)
-		if err != nil {
-			// Log the error with request details
-			logrus.WithFields(logrus.Fields{
-				"error":      err.Error(),
-				"path":       r.URL.Path,
-				"user_agent": r.Header.Get("User-Agent"),
-			}).Warn("Authentication extraction failed")
-
-			// Return 401 Unauthorized for missing or invalid authentication
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			w.Write([]byte(`{"error":"authentication required","message":"` + err.Error() + `"}`))
-			return
+// AuthOptions configures the authentication middlewares
+type AuthOptions struct {
+	// LogContextHeaders lists request headers whose values are attached to every log line
+	// written while handling the request. Headers that are not present are omitted.
+	LogContextHeaders []string
+	// LogSampleRate is the fraction of successfully authenticated requests that are logged at info level,
+	// the others are logged at debug level. Failed authentications are always logged. 0, the zero value,
+	// and 1 log every request.
+	LogSampleRate float64
+	// RequiredHeaders lists request headers every request must carry, such as those the gateway guarantees.
+	// Requests missing any of them are rejected with 400 before authentication, whether it is optional or not.
+	RequiredHeaders []string
+}
+
+// parseRequiredHeaders validates the names of the headers every request must carry
+func parseRequiredHeaders(headers []string) ([]string, error) {
+	required := make([]string, 0, len(headers))
+	for _, header := range headers {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !isHTTPToken(header) {
+			return nil, fmt.Errorf("invalid required header name %q", header)
 		}
+		required = append(required, header)
+	}
+	return required, nil
+}
 
-		// Log successful authentication
-		logrus.WithFields(logrus.Fields{
-			"user_id":    userCtx.UserID,
-			"user_email": userCtx.Email,
-			"session_id": userCtx.SessionID,
-			"request_id": userCtx.RequestID,
-		}).Info("Authenticated request")
+// rejectMissingHeaders answers 400 to requests missing any of the required headers and reports whether it did
+func (o AuthOptions) rejectMissingHeaders(w http.ResponseWriter, r *http.Request, logger *logrus.Entry) bool {
+	var missing []string
+	for _, header := range o.RequiredHeaders {
+		if r.Header.Get(header) == "" {
+			missing = append(missing, header)
+		}
+	}
+	if len(missing) == 0 {
+		return false
+	}
 
-		// Add user context to request context
-		ctx := WithUserContext(r.Context(), userCtx)
-		r = r.WithContext(ctx)
+	logger.WithFields(logrus.Fields{
+		"missing_headers": missing,
+		"path":            r.URL.Path,
+		"user_agent":      r.Header.Get("User-Agent"),
+	}).Warn("Request is missing required headers")
 
-		// Continue to next handler
-		next.ServeHTTP(w, r)
+	message, _ := json.Marshal(map[string]string{
+		"error":   "missing required headers",
+		"message": "missing required headers: " + strings.Join(missing, ", "),
 	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write(message)
+	return true
+}
+
+// logSampled reports whether the successful authentication of a request is logged. Sampling hashes the
+// gateway request ID, so every instance and every retry makes the same choice for a request. Requests
+// without an ID are always logged.
+func (o AuthOptions) logSampled(requestID string) bool {
+	if o.LogSampleRate <= 0 || o.LogSampleRate >= 1 || requestID == "" {
+		return true
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(requestID))
+	return float64(h.Sum64()) < o.LogSampleRate*math.MaxUint64
+}
+
+// requestLogger builds the request scoped log entry carrying the configured context headers
+func (o AuthOptions) requestLogger(r *http.Request) *logrus.Entry {
+	fields := logrus.Fields{}
+	if ip, ok := ClientIPFromContext(r.Context()); ok {
+		fields["client_ip"] = ip
+	}
+	for _, header := range o.LogContextHeaders {
+		if value := r.Header.Get(header); value != "" {
+			fields[logFieldName(header)] = value
+		}
+	}
+	return logrus.WithFields(fields)
+}
+
+// logFieldName converts a header name such as X-Tenant-ID into a log field name such as x_tenant_id
+func logFieldName(header string) string {
+	return strings.ReplaceAll(strings.ToLower(header), "-", "_")
+}
+
+// AuthenticationMiddleware extracts user context from headers and adds to request context
+func AuthenticationMiddleware(next http.Handler) http.Handler {
+	return NewAuthenticationMiddleware(AuthOptions{})(next)
+}
+
+// NewAuthenticationMiddleware returns a middleware that extracts user context from headers and adds to request context,
+// rejecting requests without it
+func NewAuthenticationMiddleware(opts AuthOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := opts.requestLogger(r)
+
+			// Log incoming request headers for debugging
+			logger.WithFields(logrus.Fields{
+				"method": r.Method,
+				"path":   r.URL.Path,
+				"remote": r.RemoteAddr,
+			}).Debug("Incoming request")
+
+			if opts.rejectMissingHeaders(w, r, logger) {
+				return
+			}
+
+			// Extract user context from headers
+			userCtx, err := extractUserContext(r)
+			if err != nil {
+				// Log the error with request details
+				logger.WithFields(logrus.Fields{
+					"error":      err.Error(),
+					"path":       r.URL.Path,
+					"user_agent": r.Header.Get("User-Agent"),
+				}).Warn("Authentication extraction failed")
+
+				// Return 401 Unauthorized for missing or invalid authentication
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":"authentication required","message":"` + err.Error() + `"}`))
+				return
+			}
+
+			// Log successful authentication, sampled to keep busy deployments from flooding the logs
+			level := logrus.InfoLevel
+			if !opts.logSampled(userCtx.RequestID) {
+				level = logrus.DebugLevel
+			}
+			logger.WithFields(logrus.Fields{
+				"user_id":    userCtx.UserID,
+				"user_email": userCtx.Email,
+				"session_id": userCtx.SessionID,
+				"request_id": userCtx.RequestID,
+			}).Log(level, "Authenticated request")
+
+			// Add user context and the request scoped logger to request context
+			ctx := WithUserContext(r.Context(), userCtx)
+			ctx = mcplog.WithLogger(ctx, logger)
+			r = r.WithContext(ctx)
+
+			// Continue to next handler
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 // OptionalAuthenticationMiddleware extracts user context but allows unauthenticated requests
 func OptionalAuthenticationMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Extract user context from headers
-		userCtx, err := extractUserContext(r)
-		if err != nil {
-			// Log the warning but continue without user context
-			logrus.WithFields(logrus.Fields{
-				"error": err.Error(),
-				"path":  r.URL.Path,
-			}).Debug("No authentication context, continuing without user context")
-
-			// Continue without user context
-			next.ServeHTTP(w, r)
-			return
-		}
+	return NewOptionalAuthenticationMiddleware(AuthOptions{})(next)
+}
 
-		// Log successful authentication
-		logrus.WithFields(logrus.Fields{
-			"user_id":    userCtx.UserID,
-			"user_email": userCtx.Email,
-		}).Debug("Authenticated request")
+// NewOptionalAuthenticationMiddleware returns a middleware that extracts user context but allows unauthenticated requests
+func NewOptionalAuthenticationMiddleware(opts AuthOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := opts.requestLogger(r)
+			r = r.WithContext(mcplog.WithLogger(r.Context(), logger))
 
-		// Add user context to request context
-		ctx := WithUserContext(r.Context(), userCtx)
-		r = r.WithContext(ctx)
+			if opts.rejectMissingHeaders(w, r, logger) {
+				return
+			}
 
-		// Continue to next handler
-		next.ServeHTTP(w, r)
-	})
+			// Extract user context from headers
+			userCtx, err := extractUserContext(r)
+			if err != nil {
+				// Log the warning but continue without user context
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"path":  r.URL.Path,
+				}).Debug("No authentication context, continuing without user context")
+
+				// Continue without user context
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Log successful authentication
+			logger.WithFields(logrus.Fields{
+				"user_id":    userCtx.UserID,
+				"user_email": userCtx.Email,
+			}).Debug("Authenticated request")
+
+			// Add user context to request context
+			ctx := WithUserContext(r.Context(), userCtx)
+			r = r.WithContext(ctx)
+
+			// Continue to next handler
+			next.ServeHTTP(w, r)
+		})
+	}
 }
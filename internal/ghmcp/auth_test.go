@@ -0,0 +1,132 @@
+package ghmcp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthenticatedRequest() *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/message", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set("X-User-ID", "user-1")
+	r.Header.Set("X-User-Email", "user@example.com")
+	return r
+}
+
+func TestNewAuthenticationMiddleware(t *testing.T) {
+	opts := AuthOptions{LogContextHeaders: []string{"X-Tenant-ID", "X-Trace-ID"}}
+
+	t.Run("attaches log context headers and user context", func(t *testing.T) {
+		var logger *logrus.Entry
+		var userCtx *UserContext
+		handler := NewAuthenticationMiddleware(opts)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			logger = mcplog.FromContext(r.Context())
+			userCtx, _ = GetUserContext(r.Context())
+		}))
+
+		r := newAuthenticatedRequest()
+		r.Header.Set("X-Tenant-ID", "tenant-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.NotNil(t, userCtx)
+		assert.Equal(t, "user-1", userCtx.UserID)
+		require.NotNil(t, logger)
+		assert.Equal(t, logrus.Fields{"x_tenant_id": "tenant-1"}, logger.Data)
+	})
+
+	t.Run("rejects requests without user context", func(t *testing.T) {
+		handler := NewAuthenticationMiddleware(opts)(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+			t.Fatal("handler should not be called")
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/message", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthOptions_RequiredHeaders(t *testing.T) {
+	opts := AuthOptions{RequiredHeaders: []string{"X-Gateway-Request-ID", "X-Tenant-ID"}}
+
+	for name, middleware := range map[string]func(AuthOptions) func(http.Handler) http.Handler{
+		"required": NewAuthenticationMiddleware,
+		"optional": NewOptionalAuthenticationMiddleware,
+	} {
+		t.Run(name, func(t *testing.T) {
+			called := false
+			handler := middleware(opts)(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+				called = true
+			}))
+
+			r := newAuthenticatedRequest()
+			r.Header.Set("X-Tenant-ID", "tenant-1")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"error":"missing required headers","message":"missing required headers: X-Gateway-Request-ID"}`, w.Body.String())
+			assert.False(t, called)
+
+			r.Header.Set("X-Gateway-Request-ID", "request-1")
+			w = httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.True(t, called)
+		})
+	}
+}
+
+func TestParseRequiredHeaders(t *testing.T) {
+	headers, err := parseRequiredHeaders([]string{" X-Gateway-Request-ID ", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"X-Gateway-Request-ID"}, headers)
+
+	_, err = parseRequiredHeaders([]string{"X Gateway"})
+	assert.ErrorContains(t, err, "invalid required header name")
+}
+
+func TestNewOptionalAuthenticationMiddleware(t *testing.T) {
+	var logger *logrus.Entry
+	handler := NewOptionalAuthenticationMiddleware(AuthOptions{LogContextHeaders: []string{"X-Trace-ID"}})(
+		http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			logger = mcplog.FromContext(r.Context())
+		}),
+	)
+
+	r := httptest.NewRequest(http.MethodPost, "/message", nil)
+	r.Header.Set("X-Trace-ID", "trace-1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.NotNil(t, logger)
+	assert.Equal(t, logrus.Fields{"x_trace_id": "trace-1"}, logger.Data)
+}
+
+func TestAuthOptions_logSampled(t *testing.T) {
+	all := AuthOptions{}
+	assert.True(t, all.logSampled("request-1"))
+
+	opts := AuthOptions{LogSampleRate: 0.25}
+	assert.True(t, opts.logSampled(""), "requests without an ID are always logged")
+
+	sampled := 0
+	for i := range 4000 {
+		requestID := fmt.Sprintf("request-%d", i)
+		if opts.logSampled(requestID) {
+			sampled++
+		}
+		// The same request ID always gets the same answer
+		assert.Equal(t, opts.logSampled(requestID), opts.logSampled(requestID))
+	}
+	assert.InDelta(t, 1000, sampled, 150)
+}
@@ -0,0 +1,157 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrorCodeAccessDenied is returned for tool calls the authorizer denies
+const ErrorCodeAccessDenied = "access_denied"
+
+// authzTimeout bounds each decision request of the HTTP authorizer
+const authzTimeout = 5 * time.Second
+
+// AuthzUser is the gateway user making a tool call. The token is never passed on.
+type AuthzUser struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Name      string `json:"name,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// AuthzRequest describes a tool call to be authorized. User is nil for calls without a gateway user, such as
+// those over stdio or of unauthenticated sessions.
+type AuthzRequest struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments"`
+	User      *AuthzUser     `json:"user"`
+}
+
+// AuthzDecision is the answer of an Authorizer. Reason is passed on to the client of denied calls.
+type AuthzDecision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Authorizer decides whether a tool call may go ahead, for policies that cannot be expressed by the
+// toolsets, read-only mode or the tool policy file. An error denies the call.
+type Authorizer interface {
+	Authorize(ctx context.Context, request AuthzRequest) (AuthzDecision, error)
+}
+
+// NoopAuthorizer allows every tool call
+type NoopAuthorizer struct{}
+
+// Authorize allows the call
+func (NoopAuthorizer) Authorize(context.Context, AuthzRequest) (AuthzDecision, error) {
+	return AuthzDecision{Allow: true}, nil
+}
+
+// HTTPAuthorizer POSTs each AuthzRequest as JSON to a URL, such as a policy engine, which answers with an
+// AuthzDecision. Calls are denied when the URL does not answer 200 with a decision in time.
+type HTTPAuthorizer struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPAuthorizer checks rawURL and returns an authorizer asking it for decisions
+func NewHTTPAuthorizer(rawURL string) (*HTTPAuthorizer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid authorization URL %q, expected an http or https URL", rawURL)
+	}
+	return &HTTPAuthorizer{
+		url:    rawURL,
+		client: &http.Client{Timeout: authzTimeout},
+	}, nil
+}
+
+// Authorize asks the URL for a decision
+func (a *HTTPAuthorizer) Authorize(ctx context.Context, request AuthzRequest) (AuthzDecision, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return AuthzDecision{}, fmt.Errorf("failed to encode authorization request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return AuthzDecision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return AuthzDecision{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return AuthzDecision{}, fmt.Errorf("authorization service responded with %s", resp.Status)
+	}
+
+	var decision AuthzDecision
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return AuthzDecision{}, fmt.Errorf("failed to decode authorization decision: %w", err)
+	}
+	return decision, nil
+}
+
+// newAuthorizer returns the HTTP authorizer for rawURL, or the NoopAuthorizer when rawURL is empty
+func newAuthorizer(rawURL string) (Authorizer, error) {
+	if rawURL == "" {
+		return NoopAuthorizer{}, nil
+	}
+	authorizer, err := NewHTTPAuthorizer(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("authz_url", rawURL).Info("Authorizing tool calls with authorization service")
+	return authorizer, nil
+}
+
+// authzMiddleware consults the authorizer before every tool call and fails the calls it denies with
+// access_denied. Calls the authorizer cannot decide on are denied too. The arguments in sensitive, keyed by
+// tool name, are redacted from the request sent to the authorizer.
+func authzMiddleware(authorizer Authorizer, sensitive map[string][]string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			authzRequest := AuthzRequest{
+				Tool:      request.Params.Name,
+				Arguments: github.RedactArguments(request.GetArguments(), sensitive[request.Params.Name]),
+			}
+			if userCtx, ok := GetUserContext(ctx); ok {
+				authzRequest.User = &AuthzUser{
+					UserID:    userCtx.UserID,
+					Email:     userCtx.Email,
+					Name:      userCtx.Name,
+					SessionID: userCtx.SessionID,
+					RequestID: userCtx.RequestID,
+				}
+			}
+
+			decision, err := authorizer.Authorize(ctx, authzRequest)
+			if err != nil {
+				mcplog.FromContext(ctx).WithError(err).WithField("tool", request.Params.Name).Warn("Failed to authorize tool call, denying it")
+				return mcp.NewToolResultError(fmt.Sprintf("%s: the call could not be authorized", ErrorCodeAccessDenied)), nil
+			}
+			if !decision.Allow {
+				reason := decision.Reason
+				if reason == "" {
+					reason = "the call is not allowed"
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("%s: %s", ErrorCodeAccessDenied, reason)), nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
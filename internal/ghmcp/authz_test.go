@@ -0,0 +1,109 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewAuthorizer(t *testing.T) {
+	authorizer, err := newAuthorizer("")
+	require.NoError(t, err)
+	assert.Equal(t, NoopAuthorizer{}, authorizer)
+
+	authorizer, err = newAuthorizer("https://policy.example.com/decide")
+	require.NoError(t, err)
+	assert.IsType(t, &HTTPAuthorizer{}, authorizer)
+
+	for _, rawURL := range []string{"policy.example.com/decide", "ftp://policy.example.com", "https://"} {
+		_, err := NewHTTPAuthorizer(rawURL)
+		assert.Error(t, err, rawURL)
+	}
+}
+
+func Test_AuthzMiddleware(t *testing.T) {
+	var received AuthzRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		received = AuthzRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		switch received.Tool {
+		case "get_issue", "set_org_secret":
+			_ = json.NewEncoder(w).Encode(AuthzDecision{Allow: true})
+		case "merge_pull_request":
+			_ = json.NewEncoder(w).Encode(AuthzDecision{Allow: false, Reason: "merges need a release manager"})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	authorizer, err := NewHTTPAuthorizer(srv.URL)
+	require.NoError(t, err)
+
+	called := false
+	var passedOn map[string]any
+	sensitive := map[string][]string{"set_org_secret": {"value"}}
+	handler := authzMiddleware(authorizer, sensitive)(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		passedOn = request.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	})
+	call := func(ctx context.Context, tool string) *mcp.CallToolResult {
+		called = false
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool
+		request.Params.Arguments = map[string]any{"owner": "octo-org", "repo": "app"}
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+	userCtx := WithUserContext(context.Background(), &UserContext{UserID: "u1", Email: "octocat@example.com", Token: "secret", RequestID: "req-1"})
+
+	t.Run("allowed", func(t *testing.T) {
+		result := call(userCtx, "get_issue")
+		assert.True(t, called)
+		assert.False(t, result.IsError)
+		assert.Equal(t, AuthzRequest{
+			Tool:      "get_issue",
+			Arguments: map[string]any{"owner": "octo-org", "repo": "app"},
+			User:      &AuthzUser{UserID: "u1", Email: "octocat@example.com", RequestID: "req-1"},
+		}, received)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		result := call(userCtx, "merge_pull_request")
+		assert.False(t, called)
+		require.True(t, result.IsError)
+		assert.Equal(t, "access_denied: merges need a release manager", result.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("authorization service failing", func(t *testing.T) {
+		result := call(context.Background(), "delete_file")
+		assert.False(t, called)
+		require.True(t, result.IsError)
+		assert.Equal(t, "access_denied: the call could not be authorized", result.Content[0].(mcp.TextContent).Text)
+		assert.Nil(t, received.User)
+	})
+
+	t.Run("sensitive arguments are redacted", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "set_org_secret"
+		request.Params.Arguments = map[string]any{"org": "octo-org", "name": "DEPLOY_KEY", "value": "hunter2"}
+		result, err := handler(userCtx, request)
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		assert.Equal(t, map[string]any{"org": "octo-org", "name": "DEPLOY_KEY", "value": "[REDACTED]"}, received.Arguments)
+		// The tool itself still gets the value
+		assert.Equal(t, "hunter2", passedOn["value"])
+	})
+}
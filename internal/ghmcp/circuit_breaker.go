@@ -0,0 +1,171 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrUpstreamUnavailable is returned instead of calling the GitHub API while the circuit breaker is open.
+var ErrUpstreamUnavailable = errors.New("upstream_unavailable: GitHub API is failing, requests are short-circuited until it recovers")
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fails every request fast until the cooldown has elapsed.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe request through to check whether the upstream has recovered.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreaker tracks consecutive upstream failures and short-circuits requests once a
+// threshold is reached. It is shared by the REST and GraphQL clients so that an outage seen
+// by one also protects the other.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// CircuitBreakerStatus is a point in time snapshot of a CircuitBreaker.
+type CircuitBreakerStatus struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	Threshold           int          `json:"threshold"`
+	Cooldown            string       `json:"cooldown"`
+}
+
+// NewCircuitBreaker creates a circuit breaker that opens after threshold consecutive failures
+// and half-opens after cooldown. A threshold of zero or less disables the breaker.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     CircuitClosed,
+	}
+}
+
+// Status returns a snapshot of the breaker state.
+func (cb *CircuitBreaker) Status() CircuitBreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	state := cb.state
+	if state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.cooldown {
+		state = CircuitHalfOpen
+	}
+
+	return CircuitBreakerStatus{
+		State:               state,
+		ConsecutiveFailures: cb.failures,
+		Threshold:           cb.threshold,
+		Cooldown:            cb.cooldown.String(),
+	}
+}
+
+// allow reports whether a request may be sent upstream.
+func (cb *CircuitBreaker) allow() bool {
+	if cb.threshold <= 0 {
+		return true
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.cooldown {
+			return false
+		}
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		return true
+	case CircuitHalfOpen:
+		// Only one probe at a time, everything else keeps failing fast
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of a request that was allowed through.
+func (cb *CircuitBreaker) record(success bool) {
+	if cb.threshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probing = false
+
+	if success {
+		if cb.state != CircuitClosed {
+			logrus.Info("GitHub API recovered, closing circuit breaker")
+		}
+		cb.state = CircuitClosed
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.state == CircuitHalfOpen || cb.failures >= cb.threshold {
+		if cb.state != CircuitOpen {
+			logrus.WithField("consecutive_failures", cb.failures).Warn("GitHub API is failing, opening circuit breaker")
+		}
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
+
+// release frees the probe slot without recording an outcome.
+func (cb *CircuitBreaker) release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probing = false
+}
+
+// circuitBreakerTransport short-circuits requests while the breaker is open and reports
+// transport errors and 5xx responses as failures.
+type circuitBreakerTransport struct {
+	transport http.RoundTripper
+	breaker   *CircuitBreaker
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow() {
+		return nil, ErrUpstreamUnavailable
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		// The caller gave up, that says nothing about the health of the upstream
+		t.breaker.release()
+	case err != nil:
+		t.breaker.record(false)
+	default:
+		t.breaker.record(resp.StatusCode < http.StatusInternalServerError)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,85 @@
+package ghmcp
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_CircuitBreakerTransport(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	calls := 0
+	status := http.StatusServiceUnavailable
+	transport := &circuitBreakerTransport{
+		breaker: breaker,
+		transport: roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+		}),
+	}
+
+	do := func() error {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		return err
+	}
+
+	// Failures below the threshold keep the circuit closed
+	require.NoError(t, do())
+	assert.Equal(t, CircuitClosed, breaker.Status().State)
+
+	// Reaching the threshold opens it
+	require.NoError(t, do())
+	assert.Equal(t, CircuitOpen, breaker.Status().State)
+
+	// While open, calls fail fast without reaching the upstream
+	err := do()
+	assert.True(t, errors.Is(err, ErrUpstreamUnavailable))
+	assert.Equal(t, 2, calls)
+
+	// After the cooldown a failing probe opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.Status().State)
+	require.NoError(t, do())
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, CircuitOpen, breaker.Status().State)
+
+	// A successful probe closes it
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	require.NoError(t, do())
+	assert.Equal(t, CircuitClosed, breaker.Status().State)
+	assert.Equal(t, 0, breaker.Status().ConsecutiveFailures)
+}
+
+func Test_CircuitBreakerDisabled(t *testing.T) {
+	breaker := NewCircuitBreaker(0, time.Minute)
+	transport := &circuitBreakerTransport{
+		breaker: breaker,
+		transport: roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}),
+	}
+
+	for i := 0; i < 10; i++ {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		assert.False(t, errors.Is(err, ErrUpstreamUnavailable))
+	}
+	assert.Equal(t, CircuitClosed, breaker.Status().State)
+}
@@ -0,0 +1,98 @@
+package ghmcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseTrustedProxies(t *testing.T) {
+	proxies, err := parseTrustedProxies([]string{"10.0.0.0/8", " 192.168.1.5 ", "", "fd00::/8"})
+	require.NoError(t, err)
+	require.Len(t, proxies, 3)
+	assert.Equal(t, "192.168.1.5/32", proxies[1].String())
+
+	_, err = parseTrustedProxies([]string{"not-an-ip"})
+	require.Error(t, err)
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0/99"})
+	require.Error(t, err)
+}
+
+func Test_ClientIPMiddleware(t *testing.T) {
+	proxies, err := parseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		expectedIP string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:51234",
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "forwarded headers from an untrusted peer are ignored",
+			remoteAddr: "203.0.113.7:51234",
+			headers: map[string]string{
+				"X-Forwarded-For": "198.51.100.1",
+				"X-Real-IP":       "198.51.100.2",
+			},
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "forwarded for from a trusted proxy",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1"},
+			expectedIP: "198.51.100.1",
+		},
+		{
+			name:       "spoofed leftmost entry is skipped",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.1, 10.0.0.3"},
+			expectedIP: "198.51.100.1",
+		},
+		{
+			name:       "real ip from a trusted proxy",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.2"},
+			expectedIP: "198.51.100.2",
+		},
+		{
+			name:       "trusted proxy without forwarded headers",
+			remoteAddr: "10.0.0.2:443",
+			expectedIP: "10.0.0.2",
+		},
+		{
+			name:       "garbage forwarded entry stops at the last valid hop",
+			remoteAddr: "10.0.0.2:443",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.1, garbage"},
+			expectedIP: "10.0.0.2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotIP string
+			handler := clientIPMiddleware(proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotIP, _ = ClientIPFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/sse", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for name, value := range tc.headers {
+				req.Header.Set(name, value)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.expectedIP, gotIP)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package ghmcp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// corsDefaultHeaders are the request headers browsers may always send to the server
+var corsDefaultHeaders = []string{
+	"Authorization", "Content-Type", "X-User-ID", "X-User-Email", "X-User-Name", "X-Session-ID",
+	"X-Gateway-Request-ID", "X-Output-Format", "X-GitHub-Host", "X-Locale",
+}
+
+// corsDefaultMethods are the methods browsers may use when none are configured
+var corsDefaultMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+
+// corsAllowList builds the Access-Control-Allow-Headers and Access-Control-Allow-Methods values.
+// Extra headers are added to the default ones, while methods replace the defaults when any are given.
+func corsAllowList(extraHeaders, methods []string) (string, string, error) {
+	headers := append([]string{}, corsDefaultHeaders...)
+	seen := make(map[string]bool, len(headers)+len(extraHeaders))
+	for _, header := range headers {
+		seen[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, header := range extraHeaders {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !isHTTPToken(header) {
+			return "", "", fmt.Errorf("invalid CORS header name %q", header)
+		}
+		if seen[http.CanonicalHeaderKey(header)] {
+			continue
+		}
+		seen[http.CanonicalHeaderKey(header)] = true
+		headers = append(headers, header)
+	}
+
+	allowedMethods := make([]string, 0, len(methods))
+	for _, method := range methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			continue
+		}
+		if !isHTTPToken(method) {
+			return "", "", fmt.Errorf("invalid CORS method %q", method)
+		}
+		allowedMethods = append(allowedMethods, method)
+	}
+	if len(allowedMethods) == 0 {
+		allowedMethods = corsDefaultMethods
+	}
+
+	return strings.Join(headers, ", "), strings.Join(allowedMethods, ", "), nil
+}
+
+// isHTTPToken reports whether s is a valid header name or method, a token in RFC 9110 terms
+func isHTTPToken(s string) bool {
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+// addSimpleCORS adds basic CORS support, allowing the given request headers and methods
+func addSimpleCORS(next http.Handler, allowedHeaders, allowedMethods string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+		w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,83 @@
+package ghmcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CORSAllowList(t *testing.T) {
+	tests := []struct {
+		name            string
+		headers         []string
+		methods         []string
+		expectedHeaders string
+		expectedMethods string
+		expectedErr     string
+	}{
+		{
+			name:            "defaults",
+			expectedHeaders: "Authorization, Content-Type, X-User-ID, X-User-Email, X-User-Name, X-Session-ID, X-Gateway-Request-ID, X-Output-Format, X-GitHub-Host, X-Locale",
+			expectedMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		},
+		{
+			name:            "extra headers are added once",
+			headers:         []string{" X-Tenant-ID ", "authorization", "", "X-Tenant-Id"},
+			methods:         []string{"get", " POST ", "OPTIONS"},
+			expectedHeaders: "Authorization, Content-Type, X-User-ID, X-User-Email, X-User-Name, X-Session-ID, X-Gateway-Request-ID, X-Output-Format, X-GitHub-Host, X-Locale, X-Tenant-ID",
+			expectedMethods: "GET, POST, OPTIONS",
+		},
+		{
+			name:        "invalid header",
+			headers:     []string{"X-Tenant ID"},
+			expectedErr: `invalid CORS header name "X-Tenant ID"`,
+		},
+		{
+			name:        "invalid method",
+			methods:     []string{"GET;"},
+			expectedErr: `invalid CORS method "GET;"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			headers, methods, err := corsAllowList(tc.headers, tc.methods)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedHeaders, headers)
+			assert.Equal(t, tc.expectedMethods, methods)
+		})
+	}
+}
+
+func Test_AddSimpleCORS(t *testing.T) {
+	headers, methods, err := corsAllowList([]string{"X-Tenant-ID"}, []string{"GET", "POST", "OPTIONS"})
+	require.NoError(t, err)
+
+	called := false
+	handler := addSimpleCORS(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusAccepted)
+	}), headers, methods)
+
+	// Preflight requests are answered without reaching the handler
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/message", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, called)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), "X-Tenant-ID")
+	assert.Equal(t, "GET, POST, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/message", nil))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.True(t, called)
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), "X-Tenant-ID")
+}
@@ -0,0 +1,145 @@
+package ghmcp
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxETagCacheBodySize is the largest response body the ETag cache keeps, larger responses are passed through
+const maxETagCacheBodySize = 1 << 20
+
+// etagCacheEntry is a response kept by the ETag cache, along with the ETag to revalidate it with.
+type etagCacheEntry struct {
+	key    string
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// etagCache keeps the most recently used GET responses of the GitHub API that carry an ETag, keyed by URL,
+// media type and token, so that they can be revalidated with a conditional request. GitHub does not count
+// the 304 answers to those against the rate limit.
+type etagCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+func newETagCache(size int) *etagCache {
+	return &etagCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// etagCacheKey identifies the responses that can stand in for each other. The token is hashed, so that the
+// cache never holds it, and keeps the responses of one user from being served to another.
+func etagCacheKey(req *http.Request) string {
+	token := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + "\n" + req.Header.Get("Accept") + "\n" + hex.EncodeToString(token[:])
+}
+
+func (c *etagCache) get(key string) *etagCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*etagCacheEntry)
+}
+
+func (c *etagCache) put(entry *etagCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*etagCacheEntry).key)
+	}
+}
+
+// etagCacheTransport sends GET requests with the ETag of the cached response as If-None-Match, and serves
+// the cached body when GitHub answers 304 Not Modified.
+type etagCacheTransport struct {
+	transport http.RoundTripper
+	cache     *etagCache
+}
+
+func (t *etagCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Conditional requests of the caller are its own business
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return t.transport.RoundTrip(req)
+	}
+
+	key := etagCacheKey(req)
+	cached := t.cache.get(key)
+	if cached != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		_ = resp.Body.Close()
+
+		// The 304 carries the current rate limit and ETag, the rest comes from the cached response
+		header := cached.header.Clone()
+		for name, values := range resp.Header {
+			header[name] = values
+		}
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK"
+		resp.Header = header
+		resp.ContentLength = int64(len(cached.body))
+		resp.Body = io.NopCloser(bytes.NewReader(cached.body))
+		return resp, nil
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxETagCacheBodySize+1))
+		if err != nil {
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		if len(body) > maxETagCacheBodySize {
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+
+		t.cache.put(&etagCacheEntry{
+			key:    key,
+			etag:   resp.Header.Get("ETag"),
+			header: resp.Header.Clone(),
+			body:   body,
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	default:
+		return resp, nil
+	}
+}
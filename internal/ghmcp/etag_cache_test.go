@@ -0,0 +1,84 @@
+package ghmcp
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ETagCacheTransport(t *testing.T) {
+	var sent []*http.Request
+	body := `{"login":"octocat"}`
+	transport := &etagCacheTransport{
+		cache: newETagCache(1),
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			sent = append(sent, req)
+			header := http.Header{"X-Ratelimit-Remaining": []string{"4999"}}
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				return &http.Response{StatusCode: http.StatusNotModified, Header: header, Body: http.NoBody}, nil
+			}
+			header.Set("ETag", `"v1"`)
+			header.Set("Content-Type", "application/json")
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}),
+	}
+
+	get := func(url, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		return resp
+	}
+	readBody := func(resp *http.Response) string {
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	// The first request is sent as is and its response kept
+	resp := get("https://api.github.com/user", "token-a")
+	assert.Equal(t, body, readBody(resp))
+	assert.Empty(t, sent[0].Header.Get("If-None-Match"))
+
+	// The next one is revalidated, and the cached body served on 304
+	resp = get("https://api.github.com/user", "token-a")
+	assert.Equal(t, `"v1"`, sent[1].Header.Get("If-None-Match"))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "4999", resp.Header.Get("X-Ratelimit-Remaining"))
+	assert.Equal(t, body, readBody(resp))
+
+	// Responses are not shared between tokens
+	get("https://api.github.com/user", "token-b")
+	assert.Empty(t, sent[2].Header.Get("If-None-Match"))
+
+	// The least recently used response is evicted once the cache is full
+	get("https://api.github.com/user", "token-a")
+	assert.Empty(t, sent[3].Header.Get("If-None-Match"))
+}
+
+func Test_ETagCacheTransportSkipsWrites(t *testing.T) {
+	calls := 0
+	transport := &etagCacheTransport{
+		cache: newETagCache(10),
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			assert.Empty(t, req.Header.Get("If-None-Match"))
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Etag": []string{`"v1"`}}, Body: http.NoBody}, nil
+		}),
+	}
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodPatch, "https://api.github.com/repos/owner/repo", strings.NewReader("{}"))
+		require.NoError(t, err)
+		_, err = transport.RoundTrip(req)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+}
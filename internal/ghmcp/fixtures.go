@@ -0,0 +1,150 @@
+package ghmcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// FixtureMode selects whether tool results are read from or written to the fixtures directory
+type FixtureMode string
+
+const (
+	// FixtureModeReplay serves tool results from the fixtures directory without calling GitHub
+	FixtureModeReplay FixtureMode = "replay"
+	// FixtureModeRecord calls GitHub and writes every tool result to the fixtures directory
+	FixtureModeRecord FixtureMode = "record"
+)
+
+// ParseFixtureMode parses the --fixtures-mode flag, an empty mode is replay
+func ParseFixtureMode(mode string) (FixtureMode, error) {
+	switch FixtureMode(mode) {
+	case "", FixtureModeReplay:
+		return FixtureModeReplay, nil
+	case FixtureModeRecord:
+		return FixtureModeRecord, nil
+	default:
+		return "", fmt.Errorf("invalid fixtures mode %q, expected replay or record", mode)
+	}
+}
+
+// toolFixture is the file recorded for a tool call. The tool and arguments are only kept so that the
+// fixture can be read and edited by hand, files are looked up by fixturePath.
+type toolFixture struct {
+	Tool      string          `json:"tool"`
+	Arguments map[string]any  `json:"arguments"`
+	Result    json.RawMessage `json:"result"`
+}
+
+// fixturePath returns the file of the fixture for a call of tool with args. Arguments are hashed from
+// their JSON encoding, which sorts object keys, so the same arguments always map to the same file.
+func fixturePath(dir, tool string, args map[string]any) (string, error) {
+	if args == nil {
+		args = map[string]any{}
+	}
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode arguments of %s: %w", tool, err)
+	}
+	sum := sha256.Sum256(encoded)
+	return filepath.Join(dir, fmt.Sprintf("%s-%s.json", tool, hex.EncodeToString(sum[:6]))), nil
+}
+
+// readFixture returns the result recorded at path
+func readFixture(path string) (*mcp.CallToolResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixture toolFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	result, err := mcp.ParseCallToolResult(&fixture.Result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse result of fixture %s: %w", path, err)
+	}
+	return result, nil
+}
+
+// writeFixture records result at path. The fixture is written to a temporary file first so that a
+// server replaying the same directory never reads a partial file.
+func writeFixture(path, tool string, args map[string]any, result *mcp.CallToolResult) error {
+	encodedResult, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	data, err := json.MarshalIndent(toolFixture{
+		Tool:      tool,
+		Arguments: args,
+		Result:    encodedResult,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".fixture-*")
+	if err != nil {
+		return fmt.Errorf("failed to create fixture: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// fixturesMiddleware serves tool results from the fixtures in dir in replay mode, and records the
+// result of every call that did not fail into dir in record mode. It is the innermost middleware, so
+// that replayed results go through the same formatting and error handling as live ones. The arguments in
+// sensitive, keyed by tool name, are redacted before the fixture is looked up, so their values appear
+// neither in the fixture nor in its file name.
+func fixturesMiddleware(dir string, mode FixtureMode, sensitive map[string][]string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			tool := request.Params.Name
+			args := github.RedactArguments(request.GetArguments(), sensitive[tool])
+			path, err := fixturePath(dir, tool, args)
+			if err != nil {
+				return nil, err
+			}
+
+			if mode == FixtureModeReplay {
+				result, err := readFixture(path)
+				if errors.Is(err, os.ErrNotExist) {
+					return mcp.NewToolResultError(fmt.Sprintf("no fixture recorded for this call of %s, expected %s. Run the server with --fixtures-mode record to record it", tool, path)), nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				return result, nil
+			}
+
+			result, err := next(ctx, request)
+			if err != nil || result == nil {
+				return result, err
+			}
+			if err := writeFixture(path, tool, args, result); err != nil {
+				logrus.WithField("tool", tool).WithError(err).Warn("Failed to record fixture")
+			}
+			return result, nil
+		}
+	}
+}
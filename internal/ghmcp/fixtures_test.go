@@ -0,0 +1,116 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseFixtureMode(t *testing.T) {
+	mode, err := ParseFixtureMode("")
+	require.NoError(t, err)
+	assert.Equal(t, FixtureModeReplay, mode)
+
+	mode, err = ParseFixtureMode("record")
+	require.NoError(t, err)
+	assert.Equal(t, FixtureModeRecord, mode)
+
+	_, err = ParseFixtureMode("rewind")
+	require.EqualError(t, err, `invalid fixtures mode "rewind", expected replay or record`)
+}
+
+func Test_FixturePath(t *testing.T) {
+	first, err := fixturePath("fixtures", "get_issue", map[string]any{"owner": "octo", "repo": "hello", "issue_number": float64(1)})
+	require.NoError(t, err)
+	second, err := fixturePath("fixtures", "get_issue", map[string]any{"issue_number": float64(1), "repo": "hello", "owner": "octo"})
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	other, err := fixturePath("fixtures", "get_issue", map[string]any{"owner": "octo", "repo": "hello", "issue_number": float64(2)})
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+}
+
+func Test_FixturesMiddleware(t *testing.T) {
+	dir := t.TempDir()
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get_me"
+	request.Params.Arguments = map[string]any{"reason": "test"}
+
+	calls := 0
+	live := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultText(`{"login":"octocat"}`), nil
+	}
+	replay := fixturesMiddleware(dir, FixtureModeReplay, nil)(live)
+
+	// Nothing is recorded yet
+	result, err := replay(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "no fixture recorded for this call of get_me")
+	assert.Equal(t, 0, calls)
+
+	// Failed calls are not recorded
+	failing := fixturesMiddleware(dir, FixtureModeRecord, nil)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return nil, errors.New("boom")
+	})
+	_, err = failing(context.Background(), request)
+	require.Error(t, err)
+	result, err = replay(context.Background(), request)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+
+	record := fixturesMiddleware(dir, FixtureModeRecord, nil)(live)
+	result, err = record(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, `{"login":"octocat"}`, result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, 1, calls)
+
+	result, err = replay(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, `{"login":"octocat"}`, result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, 1, calls)
+}
+
+func Test_FixturesMiddlewareRedactsSensitiveArguments(t *testing.T) {
+	dir := t.TempDir()
+	sensitive := map[string][]string{"set_org_secret": {"value"}}
+	call := func(mode FixtureMode, value string) (*mcp.CallToolResult, string) {
+		var passedOn string
+		handler := fixturesMiddleware(dir, mode, sensitive)(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			passedOn, _ = request.GetArguments()["value"].(string)
+			return mcp.NewToolResultText(`{"name":"DEPLOY_KEY","created":true}`), nil
+		})
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "set_org_secret"
+		request.Params.Arguments = map[string]any{"org": "octo-org", "name": "DEPLOY_KEY", "value": value}
+		result, err := handler(context.Background(), request)
+		require.NoError(t, err)
+		return result, passedOn
+	}
+
+	_, passedOn := call(FixtureModeRecord, "hunter2")
+	assert.Equal(t, "hunter2", passedOn)
+
+	files, err := filepath.Glob(filepath.Join(dir, "set_org_secret-*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.Contains(t, string(data), `"value": "[REDACTED]"`)
+
+	// The fixture is found whatever the value, since it is not part of the file name either
+	result, _ := call(FixtureModeReplay, "another value")
+	require.False(t, result.IsError)
+	assert.Equal(t, `{"name":"DEPLOY_KEY","created":true}`, result.Content[0].(mcp.TextContent).Text)
+}
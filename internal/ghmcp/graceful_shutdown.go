@@ -0,0 +1,129 @@
+package ghmcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ShutdownNotificationMethod is the method of the notification sent to the connected clients when the SSE
+// server starts shutting down. Its params carry reconnect_after_ms, the suggested delay before reconnecting,
+// and grace_period_ms, how long open streams are kept before the server closes them.
+const ShutdownNotificationMethod = "notifications/server/shutting_down"
+
+// shutdownDrain lets the open SSE streams finish before the server shuts down. Once draining, new streams
+// are refused with a 503 while the open ones get a shutdown notification and up to the grace period to end.
+type shutdownDrain struct {
+	gracePeriod    time.Duration
+	reconnectDelay time.Duration
+
+	mu       sync.Mutex
+	draining bool
+	active   int
+	idle     chan struct{}
+}
+
+// newShutdownDrain creates a drain keeping open streams for up to gracePeriod, gracePeriod <= 0 disables it
+func newShutdownDrain(gracePeriod, reconnectDelay time.Duration) *shutdownDrain {
+	if reconnectDelay <= 0 {
+		reconnectDelay = 5 * time.Second
+	}
+	return &shutdownDrain{
+		gracePeriod:    gracePeriod,
+		reconnectDelay: reconnectDelay,
+		idle:           make(chan struct{}),
+	}
+}
+
+// acquire counts a new stream, it returns false once the server is draining
+func (d *shutdownDrain) acquire() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return false
+	}
+	d.active++
+	return true
+}
+
+func (d *shutdownDrain) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.active--
+	if d.draining && d.active == 0 {
+		close(d.idle)
+	}
+}
+
+// middleware refuses new SSE streams with a 503 and a Retry-After header while the server is draining
+func (d *shutdownDrain) middleware(next http.Handler) http.Handler {
+	if d.gracePeriod <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !d.acquire() {
+			seconds := int((d.reconnectDelay + time.Second - 1) / time.Second)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"error":       "shutting_down",
+				"message":     "The server is shutting down, reconnect later",
+				"retry_after": seconds,
+			})
+			return
+		}
+		defer d.release()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// drain stops accepting streams, sends the shutdown notification through notify and waits for the open
+// streams to end, or for the grace period to elapse
+func (d *shutdownDrain) drain(notify func(method string, params map[string]any)) {
+	if d.gracePeriod <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	if d.draining {
+		d.mu.Unlock()
+		return
+	}
+	d.draining = true
+	active := d.active
+	if active == 0 {
+		close(d.idle)
+	}
+	d.mu.Unlock()
+
+	if active == 0 {
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"active_streams":  active,
+		"grace_period":    d.gracePeriod.String(),
+		"reconnect_after": d.reconnectDelay.String(),
+	}).Info("Notifying SSE clients of shutdown and draining their streams")
+	notify(ShutdownNotificationMethod, map[string]any{
+		"reconnect_after_ms": d.reconnectDelay.Milliseconds(),
+		"grace_period_ms":    d.gracePeriod.Milliseconds(),
+	})
+
+	timer := time.NewTimer(d.gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-d.idle:
+	case <-timer.C:
+		logrus.Warn("Shutdown grace period elapsed with SSE streams still open, closing them")
+	}
+}
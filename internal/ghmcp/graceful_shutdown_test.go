@@ -0,0 +1,75 @@
+package ghmcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShutdownDrain(t *testing.T) {
+	drain := newShutdownDrain(time.Minute, 3*time.Second)
+	streaming := make(chan struct{})
+	finish := make(chan struct{})
+	handler := drain.middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(streaming)
+		<-finish
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// An SSE stream is open when the server starts shutting down
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/sse", nil))
+	<-streaming
+
+	var method string
+	var params map[string]any
+	drained := make(chan struct{})
+	go func() {
+		drain.drain(func(m string, p map[string]any) {
+			method, params = m, p
+			close(finish)
+		})
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain did not return once the stream ended")
+	}
+	assert.Equal(t, ShutdownNotificationMethod, method)
+	assert.Equal(t, map[string]any{"reconnect_after_ms": int64(3000), "grace_period_ms": int64(60000)}, params)
+
+	// New streams are refused while the server shuts down
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), `"error":"shutting_down"`)
+}
+
+func Test_ShutdownDrainGracePeriod(t *testing.T) {
+	drain := newShutdownDrain(50*time.Millisecond, 0)
+	require.True(t, drain.acquire())
+
+	// A stream that outlives the grace period does not hold the shutdown up
+	notified := false
+	start := time.Now()
+	drain.drain(func(string, map[string]any) { notified = true })
+	assert.True(t, notified)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func Test_ShutdownDrainDisabled(t *testing.T) {
+	drain := newShutdownDrain(0, 0)
+	drain.drain(func(string, map[string]any) { t.Fatal("clients notified with the drain disabled") })
+
+	rec := httptest.NewRecorder()
+	drain.middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,229 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	gogithub "github.com/google/go-github/v72/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// GitHubHostHeader lets a gateway route a single request to another GitHub instance
+	GitHubHostHeader = "X-GitHub-Host"
+
+	githubHostContextKey contextKey = "github_host"
+)
+
+// ContextWithGitHubHost overrides the GitHub host targeted by tool calls made with ctx
+func ContextWithGitHubHost(ctx context.Context, host string) context.Context {
+	return context.WithValue(ctx, githubHostContextKey, host)
+}
+
+// GitHubHostFromContext returns the GitHub host override carried by ctx, if any
+func GitHubHostFromContext(ctx context.Context) (string, bool) {
+	host, ok := ctx.Value(githubHostContextKey).(string)
+	return host, ok && host != ""
+}
+
+// normalizeGitHubHost turns a bare host or URL into a scheme://host[:port] URL, the form expected by parseAPIHost.
+// The port is kept, so that a GHES instance on a non-default port is called on that port.
+func normalizeGitHubHost(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", fmt.Errorf("empty host")
+	}
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("could not parse host: %w", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("host must use http or https: %s", s)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("host has no hostname: %s", s)
+	}
+
+	return u.Scheme + "://" + strings.ToLower(u.Host), nil
+}
+
+// parseAllowedHosts parses the --allowed-hosts entries, each a host and the environment variable holding its
+// token, such as ghe.example.com=GHE_TOKEN, and returns the token of every host keyed by normalized host.
+// Every host needs a token of its own, so that the token of the default host is never sent to another one.
+func parseAllowedHosts(defaultHost string, entries []string, getenv func(string) string) (map[string]string, error) {
+	if defaultHost == "" {
+		defaultHost = "https://github.com"
+	}
+	normalizedDefault, err := normalizeGitHubHost(defaultHost)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default host %q: %w", defaultHost, err)
+	}
+
+	tokens := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("allowed host %q has no token, expected host=TOKEN_ENV_VAR", entry)
+		}
+		host, envVar := strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		normalized, err := normalizeGitHubHost(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed host %q: %w", host, err)
+		}
+		if normalized == normalizedDefault {
+			return nil, fmt.Errorf("allowed host %s is the default host, which always uses the configured token", normalized)
+		}
+		if _, ok := tokens[normalized]; ok {
+			return nil, fmt.Errorf("allowed host %s is given twice", normalized)
+		}
+		if envVar == "" {
+			return nil, fmt.Errorf("allowed host %q has no token, expected host=TOKEN_ENV_VAR", entry)
+		}
+		token := getenv(envVar)
+		if token == "" {
+			return nil, fmt.Errorf("the token of allowed host %s is missing, %s is not set", normalized, envVar)
+		}
+		tokens[normalized] = token
+	}
+	return tokens, nil
+}
+
+// hostAllowList holds the hosts the X-GitHub-Host header may select
+type hostAllowList map[string]struct{}
+
+// newHostAllowList builds the allow-list from the configured hosts. The default host is always allowed.
+func newHostAllowList(defaultHost string, hosts []string) (hostAllowList, error) {
+	if defaultHost == "" {
+		defaultHost = "https://github.com"
+	}
+
+	allowed := hostAllowList{}
+	for _, host := range append([]string{defaultHost}, hosts...) {
+		normalized, err := normalizeGitHubHost(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed host %q: %w", host, err)
+		}
+		allowed[normalized] = struct{}{}
+	}
+	return allowed, nil
+}
+
+// resolve validates a header value and returns the normalized host it selects
+func (a hostAllowList) resolve(header string) (string, error) {
+	host, err := normalizeGitHubHost(header)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := a[host]; !ok {
+		return "", fmt.Errorf("host %s is not in the list of allowed hosts", host)
+	}
+	return host, nil
+}
+
+// gitHubHostMiddleware applies the X-GitHub-Host header to the request context. Hosts outside the
+// allow-list are rejected rather than ignored, so a request is never silently sent to the wrong instance.
+func gitHubHostMiddleware(allowed hostAllowList) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get(GitHubHostHeader)
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			host, err := allowed.resolve(header)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"path":  r.URL.Path,
+				}).Warn("Rejected GitHub host override")
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"github host not allowed","message":"` + err.Error() + `"}`))
+				return
+			}
+
+			r = r.WithContext(ContextWithGitHubHost(r.Context(), host))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// gitHubClients is a REST and GraphQL client pair targeting one GitHub host
+type gitHubClients struct {
+	rest *gogithub.Client
+	gql  *githubv4.Client
+}
+
+// newGitHubClients builds the clients for a host, authenticating with token over transport
+func newGitHubClients(host apiHost, token, userAgent string, transport http.RoundTripper) *gitHubClients {
+	restClient := gogithub.NewClient(&http.Client{Transport: transport}).WithAuthToken(token)
+	restClient.UserAgent = userAgent
+	restClient.BaseURL = host.baseRESTURL
+	restClient.UploadURL = host.uploadURL
+
+	gqlHTTPClient := &http.Client{
+		Transport: &userAgentTransport{
+			transport: &bearerAuthTransport{
+				transport: transport,
+				token:     token,
+			},
+			agent: userAgent,
+		},
+	}
+	gqlClient := githubv4.NewEnterpriseClient(host.graphqlURL.String(), gqlHTTPClient)
+
+	return &gitHubClients{rest: restClient, gql: gqlClient}
+}
+
+// hostClientCache lazily builds and keeps the clients for hosts selected per request, each authenticating
+// with the token of its host
+type hostClientCache struct {
+	tokens    map[string]string
+	userAgent string
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	clients map[string]*gitHubClients
+}
+
+func newHostClientCache(tokens map[string]string, userAgent string, transport http.RoundTripper) *hostClientCache {
+	return &hostClientCache{
+		tokens:    tokens,
+		userAgent: userAgent,
+		transport: transport,
+		clients:   make(map[string]*gitHubClients),
+	}
+}
+
+func (c *hostClientCache) get(host string) (*gitHubClients, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if clients, ok := c.clients[host]; ok {
+		return clients, nil
+	}
+
+	token, ok := c.tokens[host]
+	if !ok {
+		return nil, fmt.Errorf("no token is configured for GitHub host %s", host)
+	}
+	apiHost, err := parseAPIHost(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API host: %w", err)
+	}
+
+	clients := newGitHubClients(apiHost, token, c.userAgent, c.transport)
+	c.clients[host] = clients
+	return clients, nil
+}
@@ -0,0 +1,236 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GitHubHostMiddleware(t *testing.T) {
+	allowed, err := newHostAllowList("", []string{"https://github.example.com", "https://ghe.example.com:8443"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+		expectedHost   string
+	}{
+		{
+			name:           "no header uses the default host",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "allowed host without scheme",
+			header:         "GitHub.Example.com",
+			expectedStatus: http.StatusOK,
+			expectedHost:   "https://github.example.com",
+		},
+		{
+			name:           "the port is kept",
+			header:         "ghe.example.com:8443",
+			expectedStatus: http.StatusOK,
+			expectedHost:   "https://ghe.example.com:8443",
+		},
+		{
+			name:           "another port of an allowed host is rejected",
+			header:         "ghe.example.com",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "default host is always allowed",
+			header:         "https://github.com",
+			expectedStatus: http.StatusOK,
+			expectedHost:   "https://github.com",
+		},
+		{
+			name:           "host outside the allow-list is rejected",
+			header:         "https://169.254.169.254",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotHost string
+			handler := gitHubHostMiddleware(allowed)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHost, _ = GitHubHostFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/message", nil)
+			if tc.header != "" {
+				req.Header.Set(GitHubHostHeader, tc.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedHost, gotHost)
+		})
+	}
+}
+
+func Test_ParseAllowedHosts(t *testing.T) {
+	env := map[string]string{"GHE_TOKEN": "ghe-token", "OTHER_TOKEN": "other-token"}
+	getenv := func(name string) string { return env[name] }
+
+	tokens, err := parseAllowedHosts("", []string{"GitHub.Example.com=GHE_TOKEN", "http://other.example.com=OTHER_TOKEN"}, getenv)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"https://github.example.com": "ghe-token",
+		"http://other.example.com":   "other-token",
+	}, tokens)
+
+	tests := map[string]string{
+		"github.example.com":                               `allowed host "github.example.com" has no token, expected host=TOKEN_ENV_VAR`,
+		"github.example.com=":                              `allowed host "github.example.com=" has no token, expected host=TOKEN_ENV_VAR`,
+		"github.example.com=UNSET_TOKEN":                   "the token of allowed host https://github.example.com is missing, UNSET_TOKEN is not set",
+		"https://github.com=GHE_TOKEN":                     "allowed host https://github.com is the default host, which always uses the configured token",
+		"ftp://github.example.com=GHE_TOKEN":               `invalid allowed host "ftp://github.example.com": host must use http or https: ftp://github.example.com`,
+		"github.example.com=GHE_TOKEN,github.example.com=": "allowed host https://github.example.com is given twice",
+	}
+	for entries, expectedErr := range tests {
+		_, err := parseAllowedHosts("", strings.Split(entries, ","), getenv)
+		assert.EqualError(t, err, expectedErr, entries)
+	}
+}
+
+func Test_HostClientCache(t *testing.T) {
+	cache := newHostClientCache(map[string]string{
+		"https://github.example.com":   "token",
+		"https://ghe.example.com:8443": "ghe-token",
+	}, "github-mcp-server/test", http.DefaultTransport)
+
+	clients, err := cache.get("https://github.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/api/v3/", clients.rest.BaseURL.String())
+
+	again, err := cache.get("https://github.example.com")
+	require.NoError(t, err)
+	assert.Same(t, clients, again)
+
+	withPort, err := cache.get("https://ghe.example.com:8443")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ghe.example.com:8443/api/v3/", withPort.rest.BaseURL.String())
+
+	// Hosts without a token of their own are refused rather than sent another token
+	_, err = cache.get("https://other.example.com")
+	assert.EqualError(t, err, "no token is configured for GitHub host https://other.example.com")
+}
+
+type tokenExchangerFunc func(ctx context.Context, user *UserContext) (ExchangedToken, error)
+
+func (f tokenExchangerFunc) ExchangeToken(ctx context.Context, user *UserContext) (ExchangedToken, error) {
+	return f(ctx, user)
+}
+
+func Test_HostTokens(t *testing.T) {
+	// NewMCPServer builds the upstream transport chain on top of http.DefaultTransport
+	var mu sync.Mutex
+	authorization := make(map[string]string)
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		authorization[req.URL.Host] = req.Header.Get("Authorization")
+		mu.Unlock()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       http.NoBody,
+		}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:         "test",
+		Token:           "default-token",
+		HostTokens:      map[string]string{"https://ghe.example.com": "ghe-token"},
+		EnabledToolsets: []string{"repos"},
+		TokenExchanger: tokenExchangerFunc(func(_ context.Context, user *UserContext) (ExchangedToken, error) {
+			return ExchangedToken{Token: "exchanged-" + user.UserID}, nil
+		}),
+		TokenExchangeTTL: time.Minute,
+		Translator:       translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	getMe := func(ctx context.Context) {
+		ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_me"}}`))
+	}
+	userCtx := WithUserContext(context.Background(), &UserContext{UserID: "mona", Token: "sso-mona"})
+
+	// The default host gets the configured token, or the exchanged one of the gateway user
+	getMe(context.Background())
+	assert.Equal(t, "Bearer default-token", authorization["api.github.com"])
+	getMe(userCtx)
+	assert.Equal(t, "Bearer exchanged-mona", authorization["api.github.com"])
+
+	// Another host only ever gets its own token, the exchanged token is for the default host
+	getMe(ContextWithGitHubHost(context.Background(), "https://ghe.example.com"))
+	assert.Equal(t, "Bearer ghe-token", authorization["ghe.example.com"])
+	delete(authorization, "ghe.example.com")
+	getMe(ContextWithGitHubHost(userCtx, "https://ghe.example.com"))
+	assert.Equal(t, "Bearer ghe-token", authorization["ghe.example.com"])
+
+	// A host without a token of its own is never called
+	getMe(ContextWithGitHubHost(userCtx, "https://other.example.com"))
+	assert.NotContains(t, authorization, "other.example.com")
+}
+
+func Test_HostOutageLeavesDefaultHostAlone(t *testing.T) {
+	// NewMCPServer builds the upstream transport chain on top of http.DefaultTransport
+	var mu sync.Mutex
+	calls := make(map[string]int)
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls[req.URL.Host]++
+		mu.Unlock()
+		status := http.StatusOK
+		if req.URL.Host == "ghe.example.com" {
+			status = http.StatusBadGateway
+		}
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Header: http.Header{}, Body: http.NoBody}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	exit := NewUpstreamFailureExit(2, time.Minute)
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:             "test",
+		Token:               "default-token",
+		HostTokens:          map[string]string{"https://ghe.example.com": "ghe-token"},
+		EnabledToolsets:     []string{"repos"},
+		CircuitBreaker:      NewCircuitBreaker(2, time.Hour),
+		UpstreamFailureExit: exit,
+		Translator:          translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	getMe := func(ctx context.Context) {
+		ghServer.HandleMessage(ctx, json.RawMessage(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_me"}}`))
+	}
+	for i := 0; i < 3; i++ {
+		getMe(ContextWithGitHubHost(context.Background(), "https://ghe.example.com"))
+	}
+	getMe(context.Background())
+
+	// The failing host is called every time, and neither opens the breaker of the default host nor trips the exit
+	assert.Equal(t, 3, calls["ghe.example.com"])
+	assert.Equal(t, 1, calls["api.github.com"])
+	select {
+	case <-exit.Tripped():
+		t.Fatal("expected the outage of another host not to stop the server")
+	default:
+	}
+}
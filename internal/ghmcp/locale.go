@@ -0,0 +1,46 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// requestLocale returns the locale the client asked for. X-Locale takes precedence over
+// Accept-Language so that clients can pick a locale without changing their HTTP stack's defaults.
+func requestLocale(r *http.Request) string {
+	if locale := strings.TrimSpace(r.Header.Get("X-Locale")); locale != "" {
+		return locale
+	}
+	return r.Header.Get("Accept-Language")
+}
+
+// localizedToolsFilter translates the tool titles and descriptions listed to a client into the locale of
+// its user context. Tools are looked up by the TOOL_<NAME>_DESCRIPTION and TOOL_<NAME>_USER_TITLE keys
+// they were defined with, without toolPrefix, and anything the locale does not translate keeps the server
+// default text.
+func localizedToolsFilter(locales translations.LocaleTranslations, toolPrefix string) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		userCtx, ok := GetUserContext(ctx)
+		if !ok || userCtx.Locale == "" {
+			return tools
+		}
+		locale, ok := locales.Match(userCtx.Locale)
+		if !ok {
+			return tools
+		}
+
+		localized := make([]mcp.Tool, len(tools))
+		for i, tool := range tools {
+			prefix := "TOOL_" + strings.ToUpper(strings.TrimPrefix(tool.Name, toolPrefix))
+			tool.Description = locales.Translate(locale, prefix+"_DESCRIPTION", tool.Description)
+			tool.Annotations.Title = locales.Translate(locale, prefix+"_USER_TITLE", tool.Annotations.Title)
+			localized[i] = tool
+		}
+		return localized
+	}
+}
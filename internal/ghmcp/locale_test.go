@@ -0,0 +1,63 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestLocale(t *testing.T) {
+	req := httptest.NewRequest("POST", "/message", nil)
+	assert.Empty(t, requestLocale(req))
+
+	req.Header.Set("Accept-Language", "fr-CA,fr;q=0.9")
+	assert.Equal(t, "fr-CA,fr;q=0.9", requestLocale(req))
+
+	req.Header.Set("X-Locale", "de")
+	assert.Equal(t, "de", requestLocale(req))
+}
+
+func Test_LocalizedToolsFilter(t *testing.T) {
+	filter := localizedToolsFilter(translations.LocaleTranslations{
+		"fr": {
+			"TOOL_GET_ME_DESCRIPTION": "Obtenir mon profil",
+			"TOOL_GET_ME_USER_TITLE":  "Mon profil",
+		},
+	}, "")
+	tools := []mcp.Tool{
+		mcp.NewTool("get_me",
+			mcp.WithDescription("Get my user profile"),
+			mcp.WithTitleAnnotation("My profile"),
+		),
+		mcp.NewTool("get_issue",
+			mcp.WithDescription("Get an issue"),
+		),
+	}
+
+	t.Run("translates for a supported locale", func(t *testing.T) {
+		ctx := WithUserContext(context.Background(), &UserContext{Locale: "fr-CA,en;q=0.5"})
+		localized := filter(ctx, tools)
+
+		assert.Equal(t, "Obtenir mon profil", localized[0].Description)
+		assert.Equal(t, "Mon profil", localized[0].Annotations.Title)
+		assert.Equal(t, "Get an issue", localized[1].Description)
+		// The registered tools are left untouched for other clients
+		assert.Equal(t, "Get my user profile", tools[0].Description)
+	})
+
+	t.Run("falls back to the server default", func(t *testing.T) {
+		for _, ctx := range []context.Context{
+			context.Background(),
+			WithUserContext(context.Background(), &UserContext{}),
+			WithUserContext(context.Background(), &UserContext{Locale: "ja"}),
+		} {
+			localized := filter(ctx, tools)
+			assert.Equal(t, "Get my user profile", localized[0].Description)
+			assert.Equal(t, "My profile", localized[0].Annotations.Title)
+		}
+	})
+}
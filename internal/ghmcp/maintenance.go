@@ -0,0 +1,116 @@
+package ghmcp
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maintenanceMode is an in-memory switch that stops the MCP endpoints from serving while the
+// process, its health check and its status endpoint keep running. It is lost on restart.
+type maintenanceMode struct {
+	retryAfter time.Duration
+
+	mu      sync.RWMutex
+	enabled bool
+	since   time.Time
+}
+
+// MaintenanceStatus is the maintenance state reported by /health, /status and the admin endpoint
+type MaintenanceStatus struct {
+	Enabled bool       `json:"enabled"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// newMaintenanceMode creates a switch that asks clients to retry after retryAfter while it is on
+func newMaintenanceMode(retryAfter time.Duration) *maintenanceMode {
+	if retryAfter <= 0 {
+		retryAfter = time.Minute
+	}
+	return &maintenanceMode{retryAfter: retryAfter}
+}
+
+func (m *maintenanceMode) status() MaintenanceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !m.enabled {
+		return MaintenanceStatus{}
+	}
+	since := m.since
+	return MaintenanceStatus{Enabled: true, Since: &since}
+}
+
+// set switches maintenance mode on or off, or toggles it when enabled is nil
+func (m *maintenanceMode) set(enabled *bool) MaintenanceStatus {
+	m.mu.Lock()
+	if enabled == nil || *enabled != m.enabled {
+		m.enabled = !m.enabled
+		m.since = time.Now()
+	}
+	m.mu.Unlock()
+	return m.status()
+}
+
+// middleware rejects requests with a 503 and a Retry-After header while maintenance mode is on
+func (m *maintenanceMode) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.status().Enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		seconds := int((m.retryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"error":       "maintenance",
+			"message":     "The server is in maintenance mode, try again later",
+			"retry_after": seconds,
+		})
+	})
+}
+
+// adminHandler serves POST /admin/maintenance, guarded by a bearer admin token. The JSON body
+// {"enabled": true} or {"enabled": false} sets the mode, an empty body toggles it.
+func (m *maintenanceMode) adminHandler(adminToken string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			logrus.WithField("path", r.URL.Path).Warn("Rejected admin request with an invalid admin token")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid admin token"}`))
+			return
+		}
+
+		var body struct {
+			Enabled *bool `json:"enabled"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		status := m.set(body.Enabled)
+		logrus.WithField("enabled", status.Enabled).Warn("Maintenance mode changed")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
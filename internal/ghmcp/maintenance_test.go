@@ -0,0 +1,71 @@
+package ghmcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MaintenanceMode(t *testing.T) {
+	maintenance := newMaintenanceMode(90 * time.Second)
+	admin := maintenance.adminHandler("admin-secret")
+	endpoint := maintenance.middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	callEndpoint := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		endpoint.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/message", nil))
+		return rec
+	}
+	callAdmin := func(method, token, body string) (*httptest.ResponseRecorder, MaintenanceStatus) {
+		req := httptest.NewRequest(method, "/admin/maintenance", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		admin.ServeHTTP(rec, req)
+
+		var status MaintenanceStatus
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		}
+		return rec, status
+	}
+
+	assert.Equal(t, http.StatusOK, callEndpoint().Code)
+
+	// The admin token is required
+	rec, _ := callAdmin(http.MethodPost, "", `{"enabled": true}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec, _ = callAdmin(http.MethodPost, "wrong", `{"enabled": true}`)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec, _ = callAdmin(http.MethodGet, "admin-secret", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.False(t, maintenance.status().Enabled)
+
+	rec, status := callAdmin(http.MethodPost, "admin-secret", `{"enabled": true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, status.Enabled)
+	assert.NotNil(t, status.Since)
+
+	rec = callEndpoint()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "90", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), `"error":"maintenance"`)
+
+	// Setting the current state again keeps it, an empty body toggles it
+	_, status = callAdmin(http.MethodPost, "admin-secret", `{"enabled": true}`)
+	assert.True(t, status.Enabled)
+	_, status = callAdmin(http.MethodPost, "admin-secret", "")
+	assert.False(t, status.Enabled)
+	assert.Nil(t, status.Since)
+
+	assert.Equal(t, http.StatusOK, callEndpoint().Code)
+}
@@ -0,0 +1,79 @@
+package ghmcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// messageValidationMiddleware rejects POSTs to /message whose body is not a JSON object with a 400 and a
+// JSON-RPC error explaining what is wrong with it. The message handler only reports a bare "Parse error",
+// and accepts JSON that is not an object only to fail on it later over the SSE stream.
+func messageValidationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeMalformedMessage(w, r, len(body), mcp.PARSE_ERROR, "Parse error", fmt.Sprintf("failed to read request body: %v", err))
+			return
+		}
+
+		if code, message, details := validateMessage(body); details != "" {
+			writeMalformedMessage(w, r, len(body), code, message, details)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateMessage checks that body is a single JSON object, returning the JSON-RPC error code, message
+// and details when it is not. The details are empty for a valid message.
+func validateMessage(body []byte) (int, string, string) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return mcp.PARSE_ERROR, "Parse error", "request body is empty"
+	}
+
+	var message any
+	if err := json.Unmarshal(trimmed, &message); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return mcp.PARSE_ERROR, "Parse error", fmt.Sprintf("invalid JSON at offset %d: %v", syntaxErr.Offset, err)
+		}
+		return mcp.PARSE_ERROR, "Parse error", fmt.Sprintf("invalid JSON: %v", err)
+	}
+	if _, ok := message.(map[string]any); !ok {
+		return mcp.INVALID_REQUEST, "Invalid Request", "request body must be a JSON-RPC message object"
+	}
+	return 0, "", ""
+}
+
+// writeMalformedMessage logs a rejected message, without its content, and answers with a JSON-RPC error
+func writeMalformedMessage(w http.ResponseWriter, r *http.Request, size, code int, message, details string) {
+	fields := logrus.Fields{
+		"path":       r.URL.Path,
+		"session_id": r.URL.Query().Get("sessionId"),
+		"size":       size,
+		"error":      details,
+	}
+	if ip, ok := ClientIPFromContext(r.Context()); ok {
+		fields["client_ip"] = ip
+	}
+	logrus.WithFields(fields).Warn("Rejected malformed MCP message")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(mcp.NewJSONRPCError(mcp.NewRequestId(nil), code, message, details))
+}
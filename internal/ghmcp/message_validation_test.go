@@ -0,0 +1,95 @@
+package ghmcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MessageValidationMiddleware(t *testing.T) {
+	var received string
+	handler := messageValidationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedStatus  int
+		expectedCode    int
+		expectedDetails string
+	}{
+		{
+			name:           "valid message is passed on unchanged",
+			body:           `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:            "empty body",
+			body:            "  ",
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    mcp.PARSE_ERROR,
+			expectedDetails: "request body is empty",
+		},
+		{
+			name:            "truncated JSON",
+			body:            `{"jsonrpc":"2.0","id":1,`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    mcp.PARSE_ERROR,
+			expectedDetails: "invalid JSON",
+		},
+		{
+			name:            "invalid character",
+			body:            `{"jsonrpc":'2.0'}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    mcp.PARSE_ERROR,
+			expectedDetails: "invalid JSON at offset 12",
+		},
+		{
+			name:            "not an object",
+			body:            `["tools/list"]`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    mcp.INVALID_REQUEST,
+			expectedDetails: "must be a JSON-RPC message object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			received = ""
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/message?sessionId=abc", strings.NewReader(tc.body)))
+
+			require.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusAccepted {
+				assert.Equal(t, tc.body, received)
+				return
+			}
+
+			assert.Empty(t, received)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			var response struct {
+				JSONRPC string `json:"jsonrpc"`
+				ID      any    `json:"id"`
+				Error   struct {
+					Code int    `json:"code"`
+					Data string `json:"data"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "2.0", response.JSONRPC)
+			assert.Nil(t, response.ID)
+			assert.Equal(t, tc.expectedCode, response.Error.Code)
+			assert.Contains(t, response.Error.Data, tc.expectedDetails)
+		})
+	}
+}
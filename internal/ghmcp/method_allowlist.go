@@ -0,0 +1,72 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrorCodeMethodNotAllowed is returned for the MCP requests of method families the server does not accept
+const ErrorCodeMethodNotAllowed = "method_not_allowed"
+
+// MCPMethodFamilies are the families of MCP methods that can be allowed, named after the prefix of their
+// methods, e.g. resources for resources/list and resources/read. initialize and ping are always allowed.
+var MCPMethodFamilies = []string{"tools", "resources", "prompts", "logging"}
+
+// mcpMethodAllowList rejects the requests of the method families it does not allow.
+type mcpMethodAllowList struct {
+	allowed map[string]bool
+}
+
+// newMCPMethodAllowList checks families against MCPMethodFamilies. It returns nil, allowing every
+// method, when families is empty.
+func newMCPMethodAllowList(families []string) (*mcpMethodAllowList, error) {
+	if len(families) == 0 {
+		return nil, nil
+	}
+
+	allowed := make(map[string]bool, len(families))
+	for _, family := range families {
+		family = strings.TrimSpace(family)
+		if !slices.Contains(MCPMethodFamilies, family) {
+			return nil, fmt.Errorf("unknown MCP method family %q, expected one of %s", family, strings.Join(MCPMethodFamilies, ", "))
+		}
+		allowed[family] = true
+	}
+	return &mcpMethodAllowList{allowed: allowed}, nil
+}
+
+// disabled returns the method families that are not allowed, for logging
+func (l *mcpMethodAllowList) disabled() []string {
+	var disabled []string
+	for _, family := range MCPMethodFamilies {
+		if !l.allowed[family] {
+			disabled = append(disabled, family)
+		}
+	}
+	return disabled
+}
+
+// check is an OnRequestInitialization hook, mcp-go answers the requests it fails with an invalid request
+// error carrying the message of the returned error
+func (l *mcpMethodAllowList) check(_ context.Context, _ any, message any) error {
+	raw, ok := message.(json.RawMessage)
+	if !ok {
+		return nil
+	}
+	var request struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(raw, &request); err != nil {
+		// Left to mcp-go, which answers with the parse error
+		return nil
+	}
+
+	family, _, _ := strings.Cut(request.Method, "/")
+	if !slices.Contains(MCPMethodFamilies, family) || l.allowed[family] {
+		return nil
+	}
+	return fmt.Errorf("%s: %s is disabled on this server", ErrorCodeMethodNotAllowed, request.Method)
+}
@@ -0,0 +1,57 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewMCPMethodAllowList(t *testing.T) {
+	allowList, err := newMCPMethodAllowList(nil)
+	require.NoError(t, err)
+	assert.Nil(t, allowList)
+
+	allowList, err = newMCPMethodAllowList([]string{"tools", " logging"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"resources", "prompts"}, allowList.disabled())
+
+	_, err = newMCPMethodAllowList([]string{"tools", "sampling"})
+	assert.ErrorContains(t, err, `unknown MCP method family "sampling"`)
+}
+
+func Test_MCPMethodAllowList(t *testing.T) {
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:           "test",
+		EnabledToolsets:   []string{"repos"},
+		AllowedMCPMethods: []string{"tools"},
+		Translator:        translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	handle := func(method string) mcp.JSONRPCMessage {
+		message, err := json.Marshal(map[string]any{
+			"jsonrpc": mcp.JSONRPC_VERSION,
+			"id":      1,
+			"method":  method,
+			"params":  map[string]any{},
+		})
+		require.NoError(t, err)
+		return ghServer.HandleMessage(context.Background(), message)
+	}
+
+	for _, method := range []string{"ping", "tools/list"} {
+		_, isError := handle(method).(mcp.JSONRPCError)
+		assert.False(t, isError, "expected %s to be accepted", method)
+	}
+
+	for _, method := range []string{"resources/list", "resources/templates/list", "prompts/list", "logging/setLevel"} {
+		response, isError := handle(method).(mcp.JSONRPCError)
+		require.True(t, isError, "expected %s to be rejected", method)
+		assert.Equal(t, "method_not_allowed: "+method+" is disabled on this server", response.Error.Message)
+	}
+}
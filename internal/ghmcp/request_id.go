@@ -0,0 +1,35 @@
+package ghmcp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// parseRequestIDHeader validates the name of the header carrying the gateway request ID to GitHub, an empty
+// name disables it
+func parseRequestIDHeader(header string) (string, error) {
+	header = strings.TrimSpace(header)
+	if header != "" && !isHTTPToken(header) {
+		return "", fmt.Errorf("invalid request ID header name %q", header)
+	}
+	return header, nil
+}
+
+// requestIDTransport sets the gateway request ID of the tool call on the GitHub API requests it makes, so that
+// audit tooling inspecting the proxied traffic can correlate it with our logs. Requests without a request ID,
+// such as those of the startup self-test or of unauthenticated sessions, are sent unchanged.
+type requestIDTransport struct {
+	transport http.RoundTripper
+	header    string
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	userCtx, ok := GetUserContext(req.Context())
+	if !ok || userCtx.RequestID == "" {
+		return t.transport.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, userCtx.RequestID)
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,51 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequestIDTransport(t *testing.T) {
+	var sent http.Header
+	client := &http.Client{Transport: &requestIDTransport{
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			sent = req.Header
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		header: "X-Correlation-ID",
+	}}
+
+	get := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	get(WithUserContext(context.Background(), &UserContext{UserID: "user", RequestID: "req-123"}))
+	assert.Equal(t, "req-123", sent.Get("X-Correlation-ID"))
+
+	// Without a request ID the header is left out
+	get(WithUserContext(context.Background(), &UserContext{UserID: "user"}))
+	assert.NotContains(t, sent, "X-Correlation-Id")
+	get(context.Background())
+	assert.NotContains(t, sent, "X-Correlation-Id")
+}
+
+func Test_ParseRequestIDHeader(t *testing.T) {
+	header, err := parseRequestIDHeader(" X-Correlation-ID ")
+	require.NoError(t, err)
+	assert.Equal(t, "X-Correlation-ID", header)
+
+	header, err = parseRequestIDHeader("")
+	require.NoError(t, err)
+	assert.Empty(t, header)
+
+	_, err = parseRequestIDHeader("X Correlation")
+	assert.ErrorContains(t, err, `invalid request ID header name "X Correlation"`)
+}
@@ -0,0 +1,151 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	gogithub "github.com/google/go-github/v72/github"
+	"github.com/sirupsen/logrus"
+)
+
+// selfTestProbeTimeout bounds each startup self-test probe
+const selfTestProbeTimeout = 10 * time.Second
+
+// selfTestProbe is a harmless read representative of what the tools of a toolset need from the token.
+// A mandatory probe failing means no tool can work, so the server refuses to start.
+type selfTestProbe struct {
+	toolset   string
+	name      string
+	mandatory bool
+	run       func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error)
+}
+
+// selfTestProbes are run in order. The context probes run whatever toolsets are enabled.
+var selfTestProbes = []selfTestProbe{
+	{
+		toolset:   "context",
+		name:      "rate_limit",
+		mandatory: true,
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.RateLimit.Get(ctx)
+			return resp, err
+		},
+	},
+	{
+		toolset:   "context",
+		name:      "authenticated_user",
+		mandatory: true,
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.Users.Get(ctx, "")
+			return resp, err
+		},
+	},
+	{
+		toolset: "repos",
+		name:    "list_repositories",
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.Repositories.ListByAuthenticatedUser(ctx, &gogithub.RepositoryListByAuthenticatedUserOptions{
+				ListOptions: gogithub.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		},
+	},
+	{
+		toolset: "issues",
+		name:    "list_issues",
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.Issues.List(ctx, false, &gogithub.IssueListOptions{
+				ListOptions: gogithub.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		},
+	},
+	{
+		toolset: "pull_requests",
+		name:    "search_pull_requests",
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.Search.Issues(ctx, "is:pr involves:@me", &gogithub.SearchOptions{
+				ListOptions: gogithub.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		},
+	},
+	{
+		toolset: "users",
+		name:    "list_organizations",
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.Organizations.List(ctx, "", &gogithub.ListOptions{PerPage: 1})
+			return resp, err
+		},
+	},
+	{
+		toolset: "notifications",
+		name:    "list_notifications",
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.Activity.ListNotifications(ctx, &gogithub.NotificationListOptions{
+				ListOptions: gogithub.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		},
+	},
+	{
+		toolset: "gists",
+		name:    "list_gists",
+		run: func(ctx context.Context, client *gogithub.Client) (*gogithub.Response, error) {
+			_, resp, err := client.Gists.List(ctx, "", &gogithub.GistListOptions{
+				ListOptions: gogithub.ListOptions{PerPage: 1},
+			})
+			return resp, err
+		},
+	},
+}
+
+// runStartupSelfTest runs the probes of the context toolset and of every toolset isEnabled reports,
+// logging whether each passed. It only returns an error when a mandatory probe fails, other failures
+// are logged as warnings since the toolset's tools may still work for some repositories. With failOpen,
+// mandatory probes failing for a reason other than a 401, such as a GitHub outage, are logged rather than
+// failing startup, since the token could not be validated either way.
+func runStartupSelfTest(ctx context.Context, client *gogithub.Client, isEnabled func(toolset string) bool, failOpen bool) error {
+	var mandatoryErrs []error
+	for _, probe := range selfTestProbes {
+		if probe.toolset != "context" && !isEnabled(probe.toolset) {
+			continue
+		}
+
+		probeCtx, cancel := context.WithTimeout(ctx, selfTestProbeTimeout)
+		start := time.Now()
+		resp, err := probe.run(probeCtx, client)
+		cancel()
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		logger := logrus.WithFields(logrus.Fields{
+			"toolset":  probe.toolset,
+			"probe":    probe.name,
+			"duration": time.Since(start).String(),
+		})
+		if resp != nil {
+			logger = logger.WithField("status", resp.StatusCode)
+		}
+		switch {
+		case err == nil:
+			logger.Info("Startup self-test passed")
+		case probe.mandatory && failOpen && (resp == nil || resp.StatusCode != http.StatusUnauthorized):
+			logger.WithError(err).Warn("Startup self-test could not validate the token, failing open")
+		case probe.mandatory:
+			logger.WithError(err).Error("Startup self-test failed, failing closed")
+			mandatoryErrs = append(mandatoryErrs, fmt.Errorf("%s: %w", probe.name, err))
+		default:
+			logger.WithError(err).Warn("Startup self-test failed, the tools of this toolset may not work with the configured token")
+		}
+	}
+
+	if len(mandatoryErrs) > 0 {
+		return fmt.Errorf("startup self-test failed: %w", errors.Join(mandatoryErrs...))
+	}
+	return nil
+}
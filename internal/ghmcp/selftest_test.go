@@ -0,0 +1,123 @@
+package ghmcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	gogithub "github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RunStartupSelfTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		statuses      map[string]int
+		enabled       []string
+		expectedPaths []string
+		failOpen      bool
+		expectedErr   string
+	}{
+		{
+			name:          "all probes pass",
+			enabled:       []string{"repos", "issues"},
+			expectedPaths: []string{"/rate_limit", "/user", "/user/repos", "/user/issues"},
+		},
+		{
+			name:          "optional probe failing only warns",
+			statuses:      map[string]int{"/notifications": http.StatusForbidden},
+			enabled:       []string{"notifications", "gists"},
+			expectedPaths: []string{"/rate_limit", "/user", "/notifications", "/gists"},
+		},
+		{
+			name:          "mandatory probe failing fails startup",
+			statuses:      map[string]int{"/user": http.StatusUnauthorized},
+			enabled:       []string{"repos"},
+			expectedPaths: []string{"/rate_limit", "/user", "/user/repos"},
+			expectedErr:   "startup self-test failed: authenticated_user",
+		},
+		{
+			name:          "mandatory probe erroring fails closed by default",
+			statuses:      map[string]int{"/rate_limit": http.StatusServiceUnavailable},
+			expectedPaths: []string{"/rate_limit", "/user"},
+			expectedErr:   "startup self-test failed: rate_limit",
+		},
+		{
+			name:          "mandatory probe erroring fails open",
+			statuses:      map[string]int{"/rate_limit": http.StatusServiceUnavailable, "/user": http.StatusBadGateway},
+			expectedPaths: []string{"/rate_limit", "/user"},
+			failOpen:      true,
+		},
+		{
+			name:          "rejected token fails closed even when failing open",
+			statuses:      map[string]int{"/user": http.StatusUnauthorized},
+			expectedPaths: []string{"/rate_limit", "/user"},
+			failOpen:      true,
+			expectedErr:   "startup self-test failed: authenticated_user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var paths []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				paths = append(paths, r.URL.Path)
+				mu.Unlock()
+
+				w.Header().Set("Content-Type", "application/json")
+				if status, ok := tc.statuses[r.URL.Path]; ok {
+					w.WriteHeader(status)
+					_, _ = w.Write([]byte(`{"message": "denied"}`))
+					return
+				}
+				switch r.URL.Path {
+				case "/rate_limit", "/user":
+					_, _ = w.Write([]byte(`{}`))
+				default:
+					_, _ = w.Write([]byte(`[]`))
+				}
+			}))
+			defer ts.Close()
+
+			client := gogithub.NewClient(nil)
+			baseURL, err := url.Parse(ts.URL + "/")
+			require.NoError(t, err)
+			client.BaseURL = baseURL
+
+			isEnabled := func(toolset string) bool {
+				for _, name := range tc.enabled {
+					if name == toolset {
+						return true
+					}
+				}
+				return false
+			}
+
+			err = runStartupSelfTest(context.Background(), client, isEnabled, tc.failOpen)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func Test_TokenValidationFailOpenRequiresSelfTest(t *testing.T) {
+	_, err := NewMCPServer(MCPServerConfig{
+		Version:                 "test",
+		EnabledToolsets:         []string{"repos"},
+		TokenValidationFailOpen: true,
+		Translator:              translations.NullTranslationHelper,
+	})
+	assert.ErrorContains(t, err, "requires the startup self-test")
+}
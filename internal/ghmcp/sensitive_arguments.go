@@ -0,0 +1,21 @@
+package ghmcp
+
+import (
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+)
+
+// sensitiveArgumentsOf returns the arguments marked sensitive of every tool in tsg that has some, keyed by
+// tool name. Their values are redacted from the arguments sent to the authorization service and recorded
+// in fixtures.
+func sensitiveArgumentsOf(tsg *toolsets.ToolsetGroup) map[string][]string {
+	sensitive := make(map[string][]string)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			if names := github.SensitiveArguments(tool.Tool); len(names) > 0 {
+				sensitive[tool.Tool.Name] = names
+			}
+		}
+	}
+	return sensitive
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/github/github-mcp-server/pkg/github"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -33,6 +34,10 @@ type MCPServerConfig struct {
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// HostTokens holds the token of every other GitHub host a request may select with the X-GitHub-Host header,
+	// keyed by normalized host. Calls to a host without a token are refused.
+	HostTokens map[string]string
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -44,8 +49,99 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// OutputFormat selects compact or pretty JSON for tool results, unless overridden per request
+	OutputFormat string
+
+	// CircuitBreaker, when set, short-circuits GitHub API calls while the upstream is failing
+	CircuitBreaker *CircuitBreaker
+
+	// UpstreamFailureExit, when set, is told the outcome of every GitHub API call to stop the server once the
+	// upstream keeps failing
+	UpstreamFailureExit *UpstreamFailureExit
+
+	// ETagCacheSize is the number of GitHub API responses kept to revalidate with conditional requests, 0 disables it
+	ETagCacheSize int
+
+	// AuditWebhook, when set, receives an audit event for every tool call
+	AuditWebhook *AuditWebhook
+
+	// Authorizer decides whether each tool call may go ahead, every call is allowed when it is nil
+	Authorizer Authorizer
+
+	// TokenExchanger, when set, supplies the GitHub token of each gateway user, whose calls otherwise use Token
+	TokenExchanger TokenExchanger
+
+	// TokenExchangeTTL is how long the token of a gateway user is cached before it is exchanged again
+	TokenExchangeTTL time.Duration
+
+	// ToolTimeouts bounds how long tool calls may take
+	ToolTimeouts ToolTimeouts
+
+	// ToolsetRateLimits throttles the tool calls of toolsets or tool categories, keyed by name, per gateway user
+	ToolsetRateLimits map[string]ToolsetRateLimit
+
+	// SecretScanner, when set, stops the file tools from writing content that looks like a secret
+	SecretScanner *github.SecretScanner
+
+	// PaginationConcurrency is how many pages tools reading whole lists may fetch at once
+	PaginationConcurrency int
+
+	// StreamResults sends each page tools reading whole lists fetch to the client as a partial result
+	// notification ahead of the tool result
+	StreamResults bool
+
+	// MaxBodyChars, when positive, is the length in characters body fields of tool results, such as the body of
+	// an issue or comment, are truncated to
+	MaxBodyChars int
+
+	// AllowedMCPMethods lists the MCP method families the server accepts, see MCPMethodFamilies. Empty allows all of them
+	AllowedMCPMethods []string
+
+	// ToolPolicyFile is the path of a YAML tool policy enabling or disabling individual tools
+	ToolPolicyFile string
+
+	// MaxArgumentChars bounds the length in characters of the string arguments of tool calls, 0 means no
+	// limit. The tool policy may override it per tool.
+	MaxArgumentChars int
+
+	// ToolPrefix is prepended to the name of every tool, so that the tools can be told apart from those
+	// of other MCP servers behind the same gateway
+	ToolPrefix string
+
+	// SoftErrors returns recoverable GitHub errors as tool results with an error field instead of failing the call
+	SoftErrors bool
+
+	// StartupSelfTest probes the permissions of the token for each enabled toolset before serving, see runStartupSelfTest
+	StartupSelfTest bool
+
+	// TokenValidationFailOpen starts the server anyway when the self-test cannot validate the token for a reason
+	// other than GitHub rejecting it with 401, such as an outage
+	TokenValidationFailOpen bool
+
+	// FixturesDir, when set, replays or records tool results in this directory instead of only calling GitHub, see fixturesMiddleware
+	FixturesDir string
+
+	// FixturesMode selects whether FixturesDir is replayed or recorded
+	FixturesMode FixtureMode
+
+	// MaxSessionConcurrency bounds the tool calls a client session may have in flight, 0 means no limit, see sessionCallLimiter
+	MaxSessionConcurrency int
+
+	// SessionConcurrencyWait is how long a call over MaxSessionConcurrency waits for a slot before it is rejected
+	SessionConcurrencyWait time.Duration
+
+	// MaxSessionAPICalls caps the GitHub API calls a client session may make, 0 means no cap, see sessionAPIBudget
+	MaxSessionAPICalls int
+
+	// RequestIDHeader, when set, is the header carrying the gateway request ID of a tool call on the GitHub API
+	// requests it makes, see requestIDTransport
+	RequestIDHeader string
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
+
+	// Locales translate the tools listed to clients that ask for a locale, see localizedToolsFilter
+	Locales translations.LocaleTranslations
 }
 
 func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
@@ -54,8 +150,81 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
 
+	outputFormat, err := github.ParseOutputFormat(cfg.OutputFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse output format: %w", err)
+	}
+	if err := validateToolPrefix(cfg.ToolPrefix); err != nil {
+		return nil, err
+	}
+	methodAllowList, err := newMCPMethodAllowList(cfg.AllowedMCPMethods)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TokenValidationFailOpen && !cfg.StartupSelfTest {
+		// Fail-open only changes how the self-test treats an unreachable GitHub, so it does nothing on its own
+		return nil, fmt.Errorf("token validation fail-open requires the startup self-test, set --startup-selftest too")
+	}
+
+	// Both API clients share the upstream transport so they also share the circuit breaker
+	var upstreamTransport http.RoundTripper = http.DefaultTransport
+	if cfg.ETagCacheSize > 0 {
+		upstreamTransport = &etagCacheTransport{
+			transport: upstreamTransport,
+			cache:     newETagCache(cfg.ETagCacheSize),
+		}
+	}
+	// The other hosts a request may select go around the circuit breaker and the upstream failure exit, which
+	// track the default host, so that an outage of one of them neither opens the breaker nor stops the server
+	hostTransport := upstreamTransport
+	if cfg.CircuitBreaker != nil {
+		upstreamTransport = &circuitBreakerTransport{
+			transport: upstreamTransport,
+			breaker:   cfg.CircuitBreaker,
+		}
+	}
+	// Above the circuit breaker, so that the requests it short-circuits count as failures
+	if cfg.UpstreamFailureExit != nil {
+		upstreamTransport = &upstreamFailureExitTransport{
+			transport: upstreamTransport,
+			exit:      cfg.UpstreamFailureExit,
+		}
+	}
+	var apiBudget *sessionAPIBudget
+	if cfg.MaxSessionAPICalls > 0 {
+		apiBudget = newSessionAPIBudget(cfg.MaxSessionAPICalls)
+		upstreamTransport = apiBudget.transport(upstreamTransport)
+		hostTransport = apiBudget.transport(hostTransport)
+	}
+	requestIDHeader, err := parseRequestIDHeader(cfg.RequestIDHeader)
+	if err != nil {
+		return nil, err
+	}
+	if requestIDHeader != "" {
+		upstreamTransport = &requestIDTransport{
+			transport: upstreamTransport,
+			header:    requestIDHeader,
+		}
+		hostTransport = &requestIDTransport{
+			transport: hostTransport,
+			header:    requestIDHeader,
+		}
+	}
+	// Artifact and archive downloads go through the upstream transport too, but before the token exchange,
+	// as their URLs carry their own credentials
+	downloadClient := &http.Client{Transport: upstreamTransport}
+	// The other hosts are never given the token exchange either, which only supplies tokens for the default
+	// host, they use their own token
+	// Outermost, so that the exchanged token replaces the one the API clients set
+	if cfg.TokenExchanger != nil {
+		upstreamTransport = &tokenExchangeTransport{
+			transport: upstreamTransport,
+			tokens:    newTokenExchangeCache(cfg.TokenExchanger, cfg.TokenExchangeTTL),
+		}
+	}
+
 	// Construct our REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	restClient := gogithub.NewClient(&http.Client{Transport: upstreamTransport}).WithAuthToken(cfg.Token)
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = apiHost.baseRESTURL
 	restClient.UploadURL = apiHost.uploadURL
@@ -65,7 +234,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	// did the necessary API host parsing so that github.com will return the correct URL anyway.
 	gqlHTTPClient := &http.Client{
 		Transport: &bearerAuthTransport{
-			transport: http.DefaultTransport,
+			transport: upstreamTransport,
 			token:     cfg.Token,
 		},
 	} // We're going to wrap the Transport later in beforeInit
@@ -88,11 +257,96 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
+	// Cancel the calls of clients that disconnect, see sessionContexts
+	sessions := newSessionContexts()
+
 	hooks := &server.Hooks{
-		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
+		OnBeforeInitialize:  []server.OnBeforeInitializeFunc{beforeInit},
+		OnRegisterSession:   []server.OnRegisterSessionHookFunc{sessions.register},
+		OnUnregisterSession: []server.OnUnregisterSessionHookFunc{sessions.unregister},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	if methodAllowList != nil {
+		logrus.WithField("disabled_methods", strings.Join(methodAllowList.disabled(), ",")).Info("Rejecting the MCP requests of disabled method families")
+		hooks.AddOnRequestInitialization(methodAllowList.check)
+	}
+
+	// Filled in once the toolsets are created, before the server handles any call
+	toolCategories := make(map[string]ToolCategory)
+	sensitiveArguments := make(map[string][]string)
+
+	serverOpts := []server.ServerOption{
+		server.WithHooks(hooks),
+	}
+	if cfg.ToolPrefix != "" {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(toolPrefixMiddleware(cfg.ToolPrefix)))
+	}
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(sessions.middleware))
+	if cfg.MaxSessionConcurrency > 0 {
+		// Outside the tool timeout, so that waiting for a slot does not count against it
+		limiter := newSessionCallLimiter(cfg.MaxSessionConcurrency, cfg.SessionConcurrencyWait)
+		hooks.AddOnUnregisterSession(limiter.unregister)
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(limiter.middleware))
+	}
+	// Filled in once the toolsets are created, like toolCategories
+	toolsetOf := make(map[string]string)
+	var rateLimiter *toolsetRateLimiter
+	if len(cfg.ToolsetRateLimits) > 0 {
+		rateLimiter = newToolsetRateLimiter(cfg.ToolsetRateLimits)
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(rateLimiter.middleware(toolsetOf, toolCategories)))
+	}
+	if apiBudget != nil {
+		hooks.AddOnUnregisterSession(apiBudget.unregister)
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(apiBudget.middleware))
+	}
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.OutputFormatMiddleware(outputFormat)))
+	if cfg.MaxBodyChars > 0 {
+		// Outside the fields filter, so that the <field>_truncated fields are kept along with the fields asked for
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.BodyTruncationMiddleware(cfg.MaxBodyChars)))
+	}
+	serverOpts = append(serverOpts,
+		server.WithToolHandlerMiddleware(github.FieldsMiddleware),
+		server.WithToolHandlerMiddleware(github.GitHubErrorMiddleware),
+	)
+	if cfg.SoftErrors {
+		// Inside the other error handling, but outside logging so that failed calls are still logged as failures
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.SoftErrorsMiddleware))
+	}
+	serverOpts = append(serverOpts,
+		server.WithToolHandlerMiddleware(toolCallLoggingMiddleware),
+	)
+	if cfg.AuditWebhook != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(auditMiddleware(cfg.AuditWebhook)))
+	}
+	// Before authorization, so that oversized arguments are not sent on to the authorization service
+	limits := &argumentLimits{Default: cfg.MaxArgumentChars}
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(argumentLimitMiddleware(limits)))
+	if cfg.Authorizer != nil {
+		// Inside auditing, so that denied calls are audited too
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(authzMiddleware(cfg.Authorizer, sensitiveArguments)))
+	}
+	serverOpts = append(serverOpts,
+		server.WithToolHandlerMiddleware(toolTimeoutMiddleware(cfg.ToolTimeouts, toolCategories)),
+	)
+	if cfg.SecretScanner != nil {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.SecretScanMiddleware(cfg.SecretScanner)))
+	}
+	serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.DownloadClientMiddleware(downloadClient)))
+	if cfg.PaginationConcurrency > 1 {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.PaginationConcurrencyMiddleware(cfg.PaginationConcurrency)))
+	}
+	if cfg.StreamResults {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(github.ResultStreamingMiddleware))
+	}
+	if cfg.FixturesDir != "" {
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(fixturesMiddleware(cfg.FixturesDir, cfg.FixturesMode, sensitiveArguments)))
+	}
+
+	if len(cfg.Locales) > 0 {
+		serverOpts = append(serverOpts, server.WithToolFilter(localizedToolsFilter(cfg.Locales, cfg.ToolPrefix)))
+	}
+
+	ghServer := github.NewServer(cfg.Version, serverOpts...)
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -105,11 +359,40 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		}
 	}
 
-	getClient := func(_ context.Context) (*gogithub.Client, error) {
+	// Requests may target another allowed GitHub host, see gitHubHostMiddleware
+	defaultHost := "https://github.com"
+	if cfg.Host != "" {
+		// parseAPIHost already accepted the host, so this only fails on hosts it would also reject
+		defaultHost, _ = normalizeGitHubHost(cfg.Host)
+	}
+	hostClients := newHostClientCache(cfg.HostTokens, restClient.UserAgent, hostTransport)
+	clientsForHost := func(ctx context.Context) (*gitHubClients, error) {
+		host, ok := GitHubHostFromContext(ctx)
+		if !ok || host == defaultHost {
+			return nil, nil
+		}
+		return hostClients.get(host)
+	}
+
+	getClient := func(ctx context.Context) (*gogithub.Client, error) {
+		clients, err := clientsForHost(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if clients != nil {
+			return clients.rest, nil
+		}
 		return restClient, nil // closing over client
 	}
 
-	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
+	getGQLClient := func(ctx context.Context) (*githubv4.Client, error) {
+		clients, err := clientsForHost(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if clients != nil {
+			return clients.gql, nil
+		}
 		return gqlClient, nil // closing over client
 	}
 
@@ -120,22 +403,75 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to enable toolsets: %w", err)
 	}
+	if cfg.StartupSelfTest {
+		if err := runStartupSelfTest(context.Background(), restClient, tsg.IsEnabled, cfg.TokenValidationFailOpen); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.ToolPolicyFile != "" {
+		policy, err := loadToolPolicy(cfg.ToolPolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, warning := range tsg.ApplyToolPolicy(policy) {
+			logrus.WithField("tool_policy", cfg.ToolPolicyFile).Warnf("Ignoring tool policy entry, %s", warning)
+		}
+		limits.PerTool = policy.MaxArgumentChars
+	}
+	for name, category := range classifyTools(tsg) {
+		toolCategories[name] = category
+	}
+	for name, arguments := range sensitiveArgumentsOf(tsg) {
+		sensitiveArguments[name] = arguments
+	}
+	if rateLimiter != nil {
+		if err := rateLimiter.validate(tsg); err != nil {
+			return nil, err
+		}
+		for name, toolset := range toolsetOfTools(tsg) {
+			toolsetOf[name] = toolset
+		}
+	}
 
 	context := github.InitContextToolset(getClient, cfg.Translator)
 	github.RegisterResources(ghServer, getClient, cfg.Translator)
 
+	// Prefix the tools only now, the tool policy and the categories above use the names they are defined with
+	if cfg.ToolPrefix != "" {
+		tsg.ApplyToolOptions(withToolPrefix(cfg.ToolPrefix))
+		context.ApplyToolOptions(withToolPrefix(cfg.ToolPrefix))
+	}
+
 	// Register the tools with the server
 	tsg.RegisterTools(ghServer)
 	context.RegisterTools(ghServer)
 
 	if cfg.DynamicToolsets {
 		dynamic := github.InitDynamicToolset(ghServer, tsg, cfg.Translator)
+		if cfg.ToolPrefix != "" {
+			dynamic.ApplyToolOptions(withToolPrefix(cfg.ToolPrefix))
+		}
 		dynamic.RegisterTools(ghServer)
 	}
 
 	return ghServer, nil
 }
 
+// loadToolPolicy reads the YAML tool policy at path
+func loadToolPolicy(path string) (*toolsets.ToolPolicy, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tool policy: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	policy, err := toolsets.ParseToolPolicy(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tool policy %s: %w", path, err)
+	}
+	return policy, nil
+}
+
 type StdioServerConfig struct {
 	// Version of the server
 	Version string
@@ -164,8 +500,97 @@ type StdioServerConfig struct {
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
+	// OutputFormat selects compact or pretty JSON for tool results
+	OutputFormat string
+
+	// CircuitBreakerThreshold is the number of consecutive upstream failures that opens the circuit breaker, 0 disables it
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long the circuit breaker stays open before probing the upstream again
+	CircuitBreakerCooldown time.Duration
+
+	// ExitOnUpstreamFailures is the number of consecutive upstream failures within UpstreamFailureWindow
+	// that makes the server exit, for a supervisor to restart it. 0 disables it
+	ExitOnUpstreamFailures int
+
+	// UpstreamFailureWindow is the time the consecutive upstream failures counted by ExitOnUpstreamFailures
+	// have to happen within
+	UpstreamFailureWindow time.Duration
+
+	// ETagCacheSize is the number of GitHub API responses kept to revalidate with conditional requests, 0 disables it
+	ETagCacheSize int
+
+	// ToolTimeouts bounds how long tool calls may take, per tool category
+	ToolTimeouts ToolTimeouts
+
+	// ToolsetRateLimits throttles the tool calls of toolsets or tool categories, keyed by name, per gateway user
+	ToolsetRateLimits map[string]ToolsetRateLimit
+
+	// SecretScanner, when set, stops the file tools from writing content that looks like a secret
+	SecretScanner *github.SecretScanner
+
+	// PaginationConcurrency is how many pages tools reading whole lists may fetch at once
+	PaginationConcurrency int
+
+	// StreamResults sends each page tools reading whole lists fetch to the client as a partial result
+	// notification ahead of the tool result
+	StreamResults bool
+
+	// MaxBodyChars, when positive, is the length in characters body fields of tool results, such as the body of
+	// an issue or comment, are truncated to
+	MaxBodyChars int
+
+	// AllowedMCPMethods lists the MCP method families the server accepts, see MCPMethodFamilies. Empty allows all of them
+	AllowedMCPMethods []string
+
+	// ToolPolicyFile is the path of a YAML tool policy enabling or disabling individual tools
+	ToolPolicyFile string
+
+	// MaxArgumentChars bounds the length in characters of the string arguments of tool calls, 0 means no
+	// limit. The tool policy may override it per tool.
+	MaxArgumentChars int
+
+	// ToolPrefix is prepended to the name of every tool, so that the tools can be told apart from those
+	// of other MCP servers behind the same gateway
+	ToolPrefix string
+
+	// SoftErrors returns recoverable GitHub errors as tool results with an error field instead of failing the call
+	SoftErrors bool
+
+	// StartupSelfTest probes the permissions of the token for each enabled toolset before serving
+	StartupSelfTest bool
+
+	// TokenValidationFailOpen starts the server anyway when the self-test cannot validate the token for a reason
+	// other than a 401
+	TokenValidationFailOpen bool
+
+	// FixturesDir, when set, replays or records tool results in this directory
+	FixturesDir string
+
+	// FixturesMode selects whether FixturesDir is replayed or recorded
+	FixturesMode FixtureMode
+
+	// MaxSessionConcurrency bounds the tool calls a client session may have in flight, 0 means no limit
+	MaxSessionConcurrency int
+
+	// SessionConcurrencyWait is how long a call over MaxSessionConcurrency waits for a slot before it is rejected
+	SessionConcurrencyWait time.Duration
+
+	// MaxSessionAPICalls caps the GitHub API calls a client session may make, 0 means no cap
+	MaxSessionAPICalls int
+
+	// AuditWebhookURL, when set, is sent an audit event as JSON for every tool call
+	AuditWebhookURL string
+
+	// AuthzURL, when set, is asked whether each tool call may go ahead, see HTTPAuthorizer
+	AuthzURL string
+
 	// Path to the log file if not stderr
 	LogFilePath string
+
+	// LogDedupWindow, when positive, collapses identical consecutive log lines written within it into one
+	// with a repeat count
+	LogDedupWindow time.Duration
 }
 
 // RunStdioServer is not concurrent safe.
@@ -176,14 +601,50 @@ func RunStdioServer(cfg StdioServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
+	auditWebhook, err := startAuditWebhook(ctx, cfg.AuditWebhookURL)
+	if err != nil {
+		return err
+	}
+
+	authorizer, err := newAuthorizer(cfg.AuthzURL)
+	if err != nil {
+		return err
+	}
+
+	upstreamFailureExit := NewUpstreamFailureExit(cfg.ExitOnUpstreamFailures, cfg.UpstreamFailureWindow)
+
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
+		Version:                 cfg.Version,
+		Host:                    cfg.Host,
+		Token:                   cfg.Token,
+		EnabledToolsets:         cfg.EnabledToolsets,
+		DynamicToolsets:         cfg.DynamicToolsets,
+		ReadOnly:                cfg.ReadOnly,
+		OutputFormat:            cfg.OutputFormat,
+		CircuitBreaker:          NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown),
+		UpstreamFailureExit:     upstreamFailureExit,
+		ETagCacheSize:           cfg.ETagCacheSize,
+		AuditWebhook:            auditWebhook,
+		Authorizer:              authorizer,
+		ToolTimeouts:            cfg.ToolTimeouts,
+		ToolsetRateLimits:       cfg.ToolsetRateLimits,
+		SecretScanner:           cfg.SecretScanner,
+		PaginationConcurrency:   cfg.PaginationConcurrency,
+		StreamResults:           cfg.StreamResults,
+		MaxBodyChars:            cfg.MaxBodyChars,
+		AllowedMCPMethods:       cfg.AllowedMCPMethods,
+		ToolPolicyFile:          cfg.ToolPolicyFile,
+		MaxArgumentChars:        cfg.MaxArgumentChars,
+		ToolPrefix:              cfg.ToolPrefix,
+		SoftErrors:              cfg.SoftErrors,
+		StartupSelfTest:         cfg.StartupSelfTest,
+		TokenValidationFailOpen: cfg.TokenValidationFailOpen,
+		FixturesDir:             cfg.FixturesDir,
+		FixturesMode:            cfg.FixturesMode,
+		MaxSessionConcurrency:   cfg.MaxSessionConcurrency,
+		SessionConcurrencyWait:  cfg.SessionConcurrencyWait,
+		MaxSessionAPICalls:      cfg.MaxSessionAPICalls,
+		Translator:              t,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -201,6 +662,7 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		logrusLogger.SetLevel(logrus.DebugLevel)
 		logrusLogger.SetOutput(file)
 	}
+	deduplicateLogs(cfg.LogDedupWindow, logrusLogger)
 	stdLogger := log.New(logrusLogger.Writer(), "stdioserver", 0)
 	stdioServer.SetErrorLogger(stdLogger)
 
@@ -229,6 +691,8 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	select {
 	case <-ctx.Done():
 		logrusLogger.Infof("shutting down server...")
+	case <-upstreamFailureExit.Tripped():
+		return upstreamFailureExit.Err()
 	case err := <-errC:
 		if err != nil {
 			return fmt.Errorf("error running server: %w", err)
@@ -266,15 +730,180 @@ type SSEServerConfig struct {
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
+	// OutputFormat selects compact or pretty JSON for tool results
+	OutputFormat string
+
+	// CircuitBreakerThreshold is the number of consecutive upstream failures that opens the circuit breaker, 0 disables it
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long the circuit breaker stays open before probing the upstream again
+	CircuitBreakerCooldown time.Duration
+
+	// ExitOnUpstreamFailures is the number of consecutive upstream failures within UpstreamFailureWindow
+	// that makes the server exit, for a supervisor to restart it. 0 disables it
+	ExitOnUpstreamFailures int
+
+	// UpstreamFailureWindow is the time the consecutive upstream failures counted by ExitOnUpstreamFailures
+	// have to happen within
+	UpstreamFailureWindow time.Duration
+
+	// ETagCacheSize is the number of GitHub API responses kept to revalidate with conditional requests, 0 disables it
+	ETagCacheSize int
+
+	// ToolTimeouts bounds how long tool calls may take, per tool category
+	ToolTimeouts ToolTimeouts
+
+	// ToolsetRateLimits throttles the tool calls of toolsets or tool categories, keyed by name, per gateway user
+	ToolsetRateLimits map[string]ToolsetRateLimit
+
+	// SecretScanner, when set, stops the file tools from writing content that looks like a secret
+	SecretScanner *github.SecretScanner
+
+	// PaginationConcurrency is how many pages tools reading whole lists may fetch at once
+	PaginationConcurrency int
+
+	// StreamResults sends each page tools reading whole lists fetch to the client as a partial result
+	// notification ahead of the tool result
+	StreamResults bool
+
+	// MaxBodyChars, when positive, is the length in characters body fields of tool results, such as the body of
+	// an issue or comment, are truncated to
+	MaxBodyChars int
+
+	// AllowedMCPMethods lists the MCP method families the server accepts, see MCPMethodFamilies. Empty allows all of them
+	AllowedMCPMethods []string
+
+	// ToolPolicyFile is the path of a YAML tool policy enabling or disabling individual tools
+	ToolPolicyFile string
+
+	// MaxArgumentChars bounds the length in characters of the string arguments of tool calls, 0 means no
+	// limit. The tool policy may override it per tool.
+	MaxArgumentChars int
+
+	// ToolPrefix is prepended to the name of every tool, so that the tools can be told apart from those
+	// of other MCP servers behind the same gateway
+	ToolPrefix string
+
+	// SoftErrors returns recoverable GitHub errors as tool results with an error field instead of failing the call
+	SoftErrors bool
+
+	// StartupSelfTest probes the permissions of the token for each enabled toolset before serving
+	StartupSelfTest bool
+
+	// TokenValidationFailOpen starts the server anyway when the self-test cannot validate the token for a reason
+	// other than a 401
+	TokenValidationFailOpen bool
+
+	// FixturesDir, when set, replays or records tool results in this directory
+	FixturesDir string
+
+	// FixturesMode selects whether FixturesDir is replayed or recorded
+	FixturesMode FixtureMode
+
+	// MaxSessionConcurrency bounds the tool calls a client session may have in flight, 0 means no limit
+	MaxSessionConcurrency int
+
+	// SessionConcurrencyWait is how long a call over MaxSessionConcurrency waits for a slot before it is rejected
+	SessionConcurrencyWait time.Duration
+
+	// MaxSessionAPICalls caps the GitHub API calls a client session may make, 0 means no cap
+	MaxSessionAPICalls int
+
+	// AuditWebhookURL, when set, is sent an audit event as JSON for every tool call
+	AuditWebhookURL string
+
+	// AuthzURL, when set, is asked whether each tool call may go ahead, see HTTPAuthorizer
+	AuthzURL string
+
+	// TokenExchangeURL, when set, is asked for the GitHub token of each gateway user, see HTTPTokenExchanger
+	TokenExchangeURL string
+
+	// TokenExchangeTTL is how long an exchanged token is cached, the token service may shorten it
+	TokenExchangeTTL time.Duration
+
 	// Path to the log file if not stderr
 	LogFilePath string
 
+	// LogDedupWindow, when positive, collapses identical consecutive log lines written within it into one
+	// with a repeat count
+	LogDedupWindow time.Duration
+
+	// LogContextHeaders lists request headers whose values are attached to every log line for a request
+	LogContextHeaders []string
+
+	// LogSampleRate is the fraction of successfully authenticated requests logged at info level
+	LogSampleRate float64
+
+	// RequiredHeaders lists request headers, such as X-Gateway-Request-ID, that every request to the MCP
+	// endpoints must carry. Requests missing one are rejected with 400.
+	RequiredHeaders []string
+
+	// RequestIDHeader, when set, is the header carrying the X-Gateway-Request-ID of a request on the GitHub API
+	// requests its tool calls make
+	RequestIDHeader string
+
+	// AllowedHosts lists the GitHub hosts a request may select with the X-GitHub-Host header, in addition to
+	// Host, each with the environment variable holding its token, such as ghe.example.com=GHE_TOKEN. The header
+	// is rejected for any other host.
+	AllowedHosts []string
+
+	// TrustedProxies lists the CIDRs of the proxies whose X-Forwarded-For and X-Real-IP headers are
+	// believed when working out the client IP. The headers of any other peer are ignored.
+	TrustedProxies []string
+
+	// CORSAllowedHeaders lists request headers browsers may send in addition to the default ones
+	CORSAllowedHeaders []string
+
+	// CORSAllowedMethods replaces the methods browsers may use, which default to GET, POST, PUT, DELETE and OPTIONS
+	CORSAllowedMethods []string
+
+	// StatusRequiresAuth routes the /status endpoint through the authentication middleware
+	StatusRequiresAuth bool
+
+	// DisableStatus removes the /status endpoint altogether
+	DisableStatus bool
+
+	// MaxSSEConnections caps the number of open SSE streams, 0 means no limit
+	MaxSSEConnections int
+
+	// SSERetryBase and SSERetryMax bound the retry hint sent to clients shed over the connection limit
+	SSERetryBase time.Duration
+	SSERetryMax  time.Duration
+
+	// AdminToken guards the /admin endpoints, which are disabled when it is empty
+	AdminToken string
+
+	// MaintenanceRetryAfter is the Retry-After sent by the MCP endpoints while in maintenance mode
+	MaintenanceRetryAfter time.Duration
+
+	// ShutdownGracePeriod is how long open SSE streams may stay open after the shutdown notification, new
+	// streams are refused meanwhile. 0 shuts down without notifying the clients
+	ShutdownGracePeriod time.Duration
+
+	// ShutdownReconnectDelay is the reconnect delay suggested to clients while the server shuts down
+	ShutdownReconnectDelay time.Duration
+
 	// SSE-specific configuration
 	ListenAddr        string
 	BaseURL           string
 	BasePath          string
 	KeepAlive         bool
 	KeepAliveInterval time.Duration
+
+	// KeepAliveComment is the SSE comment written to streams every KeepAliveInterval, next to the ping events
+	// of mcp-go, it must begin with ':'
+	KeepAliveComment string
+}
+
+// sseKeepAlive returns the middleware writing the keep-alive comment of cfg to SSE streams
+func sseKeepAlive(cfg SSEServerConfig) (func(http.Handler) http.Handler, error) {
+	if !cfg.KeepAlive || cfg.KeepAliveComment == "" {
+		return sseKeepAliveMiddleware("", 0), nil
+	}
+	if err := validateSSEKeepAliveComment(cfg.KeepAliveComment); err != nil {
+		return nil, err
+	}
+	return sseKeepAliveMiddleware(cfg.KeepAliveComment, cfg.KeepAliveInterval), nil
 }
 
 func RunSSEServer(cfg SSEServerConfig) error {
@@ -284,14 +913,58 @@ func RunSSEServer(cfg SSEServerConfig) error {
 
 	t, dumpTranslations := translations.TranslationHelper()
 
+	auditWebhook, err := startAuditWebhook(ctx, cfg.AuditWebhookURL)
+	if err != nil {
+		return err
+	}
+
+	authorizer, err := newAuthorizer(cfg.AuthzURL)
+	if err != nil {
+		return err
+	}
+
+	tokenExchanger, err := newTokenExchanger(cfg.TokenExchangeURL)
+	if err != nil {
+		return err
+	}
+
+	upstreamFailureExit := NewUpstreamFailureExit(cfg.ExitOnUpstreamFailures, cfg.UpstreamFailureWindow)
+
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
+		Version:                 cfg.Version,
+		Host:                    cfg.Host,
+		Token:                   cfg.Token,
+		EnabledToolsets:         cfg.EnabledToolsets,
+		DynamicToolsets:         cfg.DynamicToolsets,
+		ReadOnly:                cfg.ReadOnly,
+		OutputFormat:            cfg.OutputFormat,
+		CircuitBreaker:          NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown),
+		UpstreamFailureExit:     upstreamFailureExit,
+		ETagCacheSize:           cfg.ETagCacheSize,
+		AuditWebhook:            auditWebhook,
+		Authorizer:              authorizer,
+		TokenExchanger:          tokenExchanger,
+		TokenExchangeTTL:        cfg.TokenExchangeTTL,
+		ToolTimeouts:            cfg.ToolTimeouts,
+		ToolsetRateLimits:       cfg.ToolsetRateLimits,
+		SecretScanner:           cfg.SecretScanner,
+		PaginationConcurrency:   cfg.PaginationConcurrency,
+		StreamResults:           cfg.StreamResults,
+		MaxBodyChars:            cfg.MaxBodyChars,
+		AllowedMCPMethods:       cfg.AllowedMCPMethods,
+		ToolPolicyFile:          cfg.ToolPolicyFile,
+		MaxArgumentChars:        cfg.MaxArgumentChars,
+		ToolPrefix:              cfg.ToolPrefix,
+		SoftErrors:              cfg.SoftErrors,
+		StartupSelfTest:         cfg.StartupSelfTest,
+		TokenValidationFailOpen: cfg.TokenValidationFailOpen,
+		FixturesDir:             cfg.FixturesDir,
+		FixturesMode:            cfg.FixturesMode,
+		MaxSessionConcurrency:   cfg.MaxSessionConcurrency,
+		SessionConcurrencyWait:  cfg.SessionConcurrencyWait,
+		MaxSessionAPICalls:      cfg.MaxSessionAPICalls,
+		RequestIDHeader:         cfg.RequestIDHeader,
+		Translator:              t,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -312,6 +985,10 @@ func RunSSEServer(cfg SSEServerConfig) error {
 	}
 
 	sseServer := server.NewSSEServer(ghServer, sseOptions...)
+	keepAlive, err := sseKeepAlive(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Configure logging
 	logrusLogger := logrus.New()
@@ -324,6 +1001,7 @@ func RunSSEServer(cfg SSEServerConfig) error {
 		logrusLogger.SetLevel(logrus.DebugLevel)
 		logrusLogger.SetOutput(file)
 	}
+	deduplicateLogs(cfg.LogDedupWindow, logrusLogger)
 
 	if cfg.ExportTranslations {
 		// Once server is initialized, all translations are loaded
@@ -336,8 +1014,9 @@ func RunSSEServer(cfg SSEServerConfig) error {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
-	mux.Handle(cfg.BasePath+"/sse", sseServer.SSEHandler())
-	mux.Handle(cfg.BasePath+"/message", sseServer.MessageHandler())
+	drain := newShutdownDrain(cfg.ShutdownGracePeriod, cfg.ShutdownReconnectDelay)
+	mux.Handle(cfg.BasePath+"/sse", drain.middleware(keepAlive(sseServer.SSEHandler())))
+	mux.Handle(cfg.BasePath+"/message", outputFormatMiddleware(sseServer.MessageHandler()))
 
 	httpServer := &http.Server{
 		Addr:    cfg.ListenAddr,
@@ -359,6 +1038,7 @@ func RunSSEServer(cfg SSEServerConfig) error {
 	select {
 	case <-ctx.Done():
 		logrusLogger.Infof("shutting down server...")
+		drain.drain(ghServer.SendNotificationToAllClients)
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 		if err := httpServer.Shutdown(shutdownCtx); err != nil {
@@ -367,6 +1047,9 @@ func RunSSEServer(cfg SSEServerConfig) error {
 		if err := sseServer.Shutdown(ctx); err != nil {
 			logrusLogger.Errorf("error shutting down SSE server: %v", err)
 		}
+	case <-upstreamFailureExit.Tripped():
+		shutdownOnUpstreamFailure(httpServer, drain, ghServer.SendNotificationToAllClients, upstreamFailureShutdownTimeout)
+		return upstreamFailureExit.Err()
 	case err := <-errC:
 		if err != nil && err != http.ErrServerClosed {
 			return fmt.Errorf("error running server: %w", err)
@@ -444,17 +1127,17 @@ func newGHESHost(hostname string) (apiHost, error) {
 		return apiHost{}, fmt.Errorf("failed to parse GHES URL: %w", err)
 	}
 
-	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Hostname()))
+	restURL, err := url.Parse(fmt.Sprintf("%s://%s/api/v3/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES REST URL: %w", err)
 	}
 
-	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Hostname()))
+	gqlURL, err := url.Parse(fmt.Sprintf("%s://%s/api/graphql", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES GraphQL URL: %w", err)
 	}
 
-	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Hostname()))
+	uploadURL, err := url.Parse(fmt.Sprintf("%s://%s/api/uploads/", u.Scheme, u.Host))
 	if err != nil {
 		return apiHost{}, fmt.Errorf("failed to parse GHES Upload URL: %w", err)
 	}
@@ -466,7 +1149,7 @@ func newGHESHost(hostname string) (apiHost, error) {
 	}, nil
 }
 
-// Note that this does not handle ports yet, so development environments are out.
+// Ports are only kept for GHES hosts, github.com and GHEC hosts are always served on the default port.
 func parseAPIHost(s string) (apiHost, error) {
 	if s == "" {
 		return newDotcomHost()
@@ -492,6 +1175,40 @@ func parseAPIHost(s string) (apiHost, error) {
 	return newGHESHost(s)
 }
 
+// deduplicateLogs collapses identical consecutive lines of logger and of the standard logger, which most of
+// the server logs to, when window is positive
+func deduplicateLogs(window time.Duration, logger *logrus.Logger) {
+	if window <= 0 {
+		return
+	}
+	mcplog.Deduplicate(logrus.StandardLogger(), window)
+	mcplog.Deduplicate(logger, window)
+}
+
+// toolCallLoggingMiddleware logs every tool call with the request scoped logger, so that fields
+// carried from gateway headers appear alongside the tool name.
+func toolCallLoggingMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := next(ctx, request)
+
+		logger := mcplog.FromContext(ctx).WithFields(logrus.Fields{
+			"tool":     request.Params.Name,
+			"duration": time.Since(start).String(),
+		})
+		switch {
+		case err != nil:
+			logger.WithError(err).Warn("Tool call failed")
+		case result != nil && result.IsError:
+			logger.Debug("Tool call returned an error result")
+		default:
+			logger.Debug("Tool call completed")
+		}
+
+		return result, err
+	}
+}
+
 type userAgentTransport struct {
 	transport http.RoundTripper
 	agent     string
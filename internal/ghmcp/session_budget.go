@@ -0,0 +1,119 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrorCodeSessionAPIBudgetExhausted is returned for tool calls of a session that has made all the GitHub API
+// calls it may make
+const ErrorCodeSessionAPIBudgetExhausted = "session_api_budget_exhausted"
+
+// ErrSessionAPIBudgetExhausted is returned instead of calling the GitHub API once the session has spent its budget
+var ErrSessionAPIBudgetExhausted = errors.New(ErrorCodeSessionAPIBudgetExhausted + ": this session has made all the GitHub API calls it may make")
+
+// APIBudgetRemainingMetaKey is the result metadata key of the GitHub API calls the session may still make
+const APIBudgetRemainingMetaKey = "github_api_budget_remaining"
+
+// sessionAPIBudget caps the GitHub API calls each client session may make, so that a runaway agent cannot spend
+// more of the rate limit than it was budgeted. Every request counts, including each page a tool fetches and
+// requests GitHub fails. Once a session has spent its budget its tool calls fail until it ends.
+type sessionAPIBudget struct {
+	limit int
+
+	mu   sync.Mutex
+	used map[string]int
+}
+
+func newSessionAPIBudget(limit int) *sessionAPIBudget {
+	return &sessionAPIBudget{
+		limit: limit,
+		used:  make(map[string]int),
+	}
+}
+
+// spend counts a GitHub API call of a session, and reports false without counting it when the budget is spent
+func (b *sessionAPIBudget) spend(sessionID string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.used[sessionID] >= b.limit {
+		return false
+	}
+	b.used[sessionID]++
+	return true
+}
+
+// remaining returns how many GitHub API calls a session may still make
+func (b *sessionAPIBudget) remaining(sessionID string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit - b.used[sessionID]
+}
+
+// unregister is an OnUnregisterSession hook that forgets a closed session
+func (b *sessionAPIBudget) unregister(_ context.Context, session server.ClientSession) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.used, session.SessionID())
+}
+
+// transport counts the GitHub API calls made by the tool calls of each session. Requests without a session,
+// such as the startup self-test, are not counted.
+func (b *sessionAPIBudget) transport(next http.RoundTripper) http.RoundTripper {
+	return &sessionAPIBudgetTransport{transport: next, budget: b}
+}
+
+type sessionAPIBudgetTransport struct {
+	transport http.RoundTripper
+	budget    *sessionAPIBudget
+}
+
+func (t *sessionAPIBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if session := server.ClientSessionFromContext(req.Context()); session != nil {
+		if !t.budget.spend(session.SessionID()) {
+			return nil, ErrSessionAPIBudgetExhausted
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// middleware rejects the tool calls of sessions that have spent their budget, including calls that spend the
+// last of it part way through, and adds the remaining budget to the metadata of every result
+func (b *sessionAPIBudget) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil {
+			return next(ctx, request)
+		}
+		sessionID := session.SessionID()
+
+		var result *mcp.CallToolResult
+		var err error
+		if b.remaining(sessionID) <= 0 {
+			result = b.exhausted()
+		} else {
+			result, err = next(ctx, request)
+			if errors.Is(err, ErrSessionAPIBudgetExhausted) {
+				result, err = b.exhausted(), nil
+			}
+		}
+
+		if result != nil {
+			if result.Meta == nil {
+				result.Meta = make(map[string]any)
+			}
+			result.Meta[APIBudgetRemainingMetaKey] = b.remaining(sessionID)
+		}
+		return result, err
+	}
+}
+
+func (b *sessionAPIBudget) exhausted() *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("%s: this session has made all the %d GitHub API calls it may make, start a new session to continue", ErrorCodeSessionAPIBudgetExhausted, b.limit))
+}
@@ -0,0 +1,79 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SessionAPIBudget(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0.0.1")
+	ctxA := mcpServer.WithContext(context.Background(), &fakeSession{id: "a"})
+	ctxB := mcpServer.WithContext(context.Background(), &fakeSession{id: "b"})
+
+	budget := newSessionAPIBudget(3)
+	calls := 0
+	client := &http.Client{Transport: budget.transport(roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))}
+
+	// callGitHub is a tool making n GitHub API calls
+	callGitHub := func(n int) server.ToolHandlerFunc {
+		return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			for range n {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+				if err != nil {
+					return nil, err
+				}
+				resp, err := client.Do(req)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get user: %w", err)
+				}
+				_ = resp.Body.Close()
+			}
+			return mcp.NewToolResultText("done"), nil
+		}
+	}
+
+	result, err := budget.middleware(callGitHub(2))(ctxA, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, 1, result.Meta[APIBudgetRemainingMetaKey])
+
+	// The call running out of budget part way through fails
+	result, err = budget.middleware(callGitHub(2))(ctxA, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, ErrorCodeSessionAPIBudgetExhausted)
+	assert.Equal(t, 0, result.Meta[APIBudgetRemainingMetaKey])
+	assert.Equal(t, 3, calls)
+
+	// Later calls fail without calling GitHub
+	result, err = budget.middleware(callGitHub(1))(ctxA, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, 3, calls)
+
+	// Other sessions have their own budget
+	result, err = budget.middleware(callGitHub(1))(ctxB, mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, 2, result.Meta[APIBudgetRemainingMetaKey])
+
+	// A closed session is forgotten
+	budget.unregister(context.Background(), &fakeSession{id: "a"})
+	assert.Equal(t, 3, budget.remaining("a"))
+
+	// Calls without a session are not counted
+	result, err = budget.middleware(callGitHub(5))(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Nil(t, result.Meta)
+}
@@ -0,0 +1,73 @@
+package ghmcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// sessionContexts ties tool calls to the lifetime of the client session that made them. The SSE
+// transport answers each POST to /message with 202 before handling it, and handles it with a context
+// detached from that request, so without this a call keeps running, and keeps spending the GitHub rate
+// limit, after its client has disconnected.
+type sessionContexts struct {
+	mu       sync.Mutex
+	sessions map[string]sessionContext
+}
+
+type sessionContext struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newSessionContexts() *sessionContexts {
+	return &sessionContexts{sessions: make(map[string]sessionContext)}
+}
+
+// register is an OnRegisterSession hook that starts tracking a session
+func (s *sessionContexts) register(_ context.Context, session server.ClientSession) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[session.SessionID()] = sessionContext{ctx: ctx, cancel: cancel}
+}
+
+// unregister is an OnUnregisterSession hook that cancels the in-flight calls of a closed session
+func (s *sessionContexts) unregister(_ context.Context, session server.ClientSession) {
+	s.mu.Lock()
+	tracked, ok := s.sessions[session.SessionID()]
+	delete(s.sessions, session.SessionID())
+	s.mu.Unlock()
+
+	if ok {
+		tracked.cancel()
+	}
+}
+
+// middleware cancels the context of a tool call when its session closes. Calls without a tracked
+// session keep their context as is.
+func (s *sessionContexts) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil {
+			return next(ctx, request)
+		}
+
+		s.mu.Lock()
+		tracked, ok := s.sessions[session.SessionID()]
+		s.mu.Unlock()
+		if !ok {
+			return next(ctx, request)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stop := context.AfterFunc(tracked.ctx, cancel)
+		defer stop()
+
+		return next(ctx, request)
+	}
+}
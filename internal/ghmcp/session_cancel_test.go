@@ -0,0 +1,112 @@
+package ghmcp
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SessionContextsCancelCallsOnDisconnect(t *testing.T) {
+	// The upstream stands in for GitHub and holds every request until its context ends
+	received := make(chan struct{})
+	cancelled := make(chan struct{})
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		close(received)
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-release:
+		}
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	sessions := newSessionContexts()
+	mcpServer := server.NewMCPServer("test", "1.0.0",
+		server.WithHooks(&server.Hooks{
+			OnRegisterSession:   []server.OnRegisterSessionHookFunc{sessions.register},
+			OnUnregisterSession: []server.OnUnregisterSessionHookFunc{sessions.unregister},
+		}),
+		server.WithToolHandlerMiddleware(sessions.middleware),
+	)
+	mcpServer.AddTool(mcp.NewTool("slow"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	ts := httptest.NewServer(server.NewSSEServer(mcpServer))
+	defer ts.Close()
+
+	// Connect and read the message endpoint of the session
+	streamCtx, disconnect := context.WithCancel(context.Background())
+	defer disconnect()
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, ts.URL+"/sse", nil)
+	require.NoError(t, err)
+	stream, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = stream.Body.Close() }()
+
+	var endpoint string
+	scanner := bufio.NewScanner(stream.Body)
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			endpoint = strings.TrimSpace(data)
+			break
+		}
+	}
+	require.NotEmpty(t, endpoint)
+	if strings.HasPrefix(endpoint, "/") {
+		endpoint = ts.URL + endpoint
+	}
+
+	resp, err := http.Post(endpoint, "application/json",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slow"}}`))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool call never reached the upstream")
+	}
+
+	// Dropping the SSE stream must cancel the outbound request of the call
+	disconnect()
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("outbound request was not cancelled after the client disconnected")
+	}
+}
+
+func Test_SessionContextsMiddlewareWithoutSession(t *testing.T) {
+	sessions := newSessionContexts()
+	handler := sessions.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		assert.NoError(t, ctx.Err())
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
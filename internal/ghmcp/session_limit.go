@@ -0,0 +1,88 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrorCodeTooManyConcurrentCalls is returned for tool calls over the concurrency limit of their session
+const ErrorCodeTooManyConcurrentCalls = "too_many_concurrent_calls"
+
+// sessionCallLimiter bounds how many tool calls each client session may have in flight, so that a single
+// agent firing many calls in parallel cannot spend the whole GitHub rate limit at once. Calls over the
+// limit wait up to wait for a call of their session to finish, and are rejected after that.
+type sessionCallLimiter struct {
+	limit int
+	wait  time.Duration
+
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+func newSessionCallLimiter(limit int, wait time.Duration) *sessionCallLimiter {
+	return &sessionCallLimiter{
+		limit: limit,
+		wait:  wait,
+		slots: make(map[string]chan struct{}),
+	}
+}
+
+// sessionSlots returns the semaphore of a session, creating it on its first call
+func (l *sessionCallLimiter) sessionSlots(sessionID string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slots, ok := l.slots[sessionID]
+	if !ok {
+		slots = make(chan struct{}, l.limit)
+		l.slots[sessionID] = slots
+	}
+	return slots
+}
+
+// unregister is an OnUnregisterSession hook that forgets a closed session. Calls still in flight release
+// their slot into the forgotten semaphore, which is harmless.
+func (l *sessionCallLimiter) unregister(_ context.Context, session server.ClientSession) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.slots, session.SessionID())
+}
+
+// middleware holds a slot of the session of the call while it runs. Calls without a session are not limited.
+func (l *sessionCallLimiter) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session := server.ClientSessionFromContext(ctx)
+		if session == nil {
+			return next(ctx, request)
+		}
+		slots := l.sessionSlots(session.SessionID())
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			if l.wait <= 0 {
+				return l.rejected(), nil
+			}
+			timer := time.NewTimer(l.wait)
+			defer timer.Stop()
+			select {
+			case slots <- struct{}{}:
+			case <-timer.C:
+				return l.rejected(), nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		defer func() { <-slots }()
+
+		return next(ctx, request)
+	}
+}
+
+func (l *sessionCallLimiter) rejected() *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("%s: this session already has %d tool calls in flight, retry once one of them has finished", ErrorCodeTooManyConcurrentCalls, l.limit))
+}
@@ -0,0 +1,113 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession is a client session that only has an ID
+type fakeSession struct {
+	id string
+}
+
+func (s *fakeSession) Initialize()                                         {}
+func (s *fakeSession) Initialized() bool                                   { return true }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s *fakeSession) SessionID() string                                   { return s.id }
+
+func Test_SessionCallLimiter(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0.0.1")
+	ctxA := mcpServer.WithContext(context.Background(), &fakeSession{id: "a"})
+	ctxB := mcpServer.WithContext(context.Background(), &fakeSession{id: "b"})
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+	blocking := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		started <- struct{}{}
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	}
+
+	t.Run("calls over the limit are rejected", func(t *testing.T) {
+		limiter := newSessionCallLimiter(1, 0)
+		handler := limiter.middleware(blocking)
+
+		done := make(chan *mcp.CallToolResult)
+		go func() {
+			result, _ := handler(ctxA, mcp.CallToolRequest{})
+			done <- result
+		}()
+		<-started
+
+		result, err := handler(ctxA, mcp.CallToolRequest{})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "too_many_concurrent_calls")
+
+		// Other sessions have their own limit
+		go func() {
+			result, _ := handler(ctxB, mcp.CallToolRequest{})
+			done <- result
+		}()
+		<-started
+
+		release <- struct{}{}
+		release <- struct{}{}
+		assert.False(t, (<-done).IsError)
+		assert.False(t, (<-done).IsError)
+	})
+
+	t.Run("calls over the limit wait for a slot", func(t *testing.T) {
+		limiter := newSessionCallLimiter(1, time.Minute)
+		handler := limiter.middleware(blocking)
+
+		done := make(chan *mcp.CallToolResult)
+		for range 2 {
+			go func() {
+				result, _ := handler(ctxA, mcp.CallToolRequest{})
+				done <- result
+			}()
+		}
+		<-started
+		release <- struct{}{}
+		<-started
+		release <- struct{}{}
+		assert.False(t, (<-done).IsError)
+		assert.False(t, (<-done).IsError)
+	})
+
+	t.Run("waiting calls are rejected after the wait", func(t *testing.T) {
+		limiter := newSessionCallLimiter(1, 10*time.Millisecond)
+		handler := limiter.middleware(blocking)
+
+		done := make(chan *mcp.CallToolResult)
+		go func() {
+			result, _ := handler(ctxA, mcp.CallToolRequest{})
+			done <- result
+		}()
+		<-started
+
+		result, err := handler(ctxA, mcp.CallToolRequest{})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		release <- struct{}{}
+		assert.False(t, (<-done).IsError)
+	})
+
+	t.Run("calls without a session are not limited", func(t *testing.T) {
+		limiter := newSessionCallLimiter(1, 0)
+		handler := limiter.middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("done"), nil
+		})
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+	})
+}
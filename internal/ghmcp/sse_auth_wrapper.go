@@ -2,13 +2,19 @@ package ghmcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"sort"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/sirupsen/logrus"
@@ -33,16 +39,80 @@ func RunSSEServerWithSimpleAuth(cfg SSEServerConfig, allowUnauthenticated bool)
 	defer stop()
 
 	t, dumpTranslations := translations.TranslationHelper()
+	locales, err := translations.LoadLocaleTranslations(".")
+	if err != nil {
+		return fmt.Errorf("failed to load locale translations: %w", err)
+	}
+	if len(locales) > 0 {
+		names := make([]string, 0, len(locales))
+		for locale := range locales {
+			names = append(names, locale)
+		}
+		sort.Strings(names)
+		logrus.WithField("locales", names).Info("Loaded locale translations")
+	}
+
+	circuitBreaker := NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown)
+	upstreamFailureExit := NewUpstreamFailureExit(cfg.ExitOnUpstreamFailures, cfg.UpstreamFailureWindow)
+
+	auditWebhook, err := startAuditWebhook(ctx, cfg.AuditWebhookURL)
+	if err != nil {
+		return err
+	}
+
+	authorizer, err := newAuthorizer(cfg.AuthzURL)
+	if err != nil {
+		return err
+	}
+
+	tokenExchanger, err := newTokenExchanger(cfg.TokenExchangeURL)
+	if err != nil {
+		return err
+	}
+
+	hostTokens, err := parseAllowedHosts(cfg.Host, cfg.AllowedHosts, os.Getenv)
+	if err != nil {
+		return fmt.Errorf("failed to parse allowed hosts: %w", err)
+	}
 
 	// Create the MCP server using existing approach
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:         cfg.Version,
-		Host:            cfg.Host,
-		Token:           cfg.Token,
-		EnabledToolsets: cfg.EnabledToolsets,
-		DynamicToolsets: cfg.DynamicToolsets,
-		ReadOnly:        cfg.ReadOnly,
-		Translator:      t,
+		Version:                 cfg.Version,
+		Host:                    cfg.Host,
+		Token:                   cfg.Token,
+		HostTokens:              hostTokens,
+		EnabledToolsets:         cfg.EnabledToolsets,
+		DynamicToolsets:         cfg.DynamicToolsets,
+		ReadOnly:                cfg.ReadOnly,
+		OutputFormat:            cfg.OutputFormat,
+		CircuitBreaker:          circuitBreaker,
+		UpstreamFailureExit:     upstreamFailureExit,
+		ETagCacheSize:           cfg.ETagCacheSize,
+		AuditWebhook:            auditWebhook,
+		Authorizer:              authorizer,
+		TokenExchanger:          tokenExchanger,
+		TokenExchangeTTL:        cfg.TokenExchangeTTL,
+		ToolTimeouts:            cfg.ToolTimeouts,
+		ToolsetRateLimits:       cfg.ToolsetRateLimits,
+		SecretScanner:           cfg.SecretScanner,
+		PaginationConcurrency:   cfg.PaginationConcurrency,
+		StreamResults:           cfg.StreamResults,
+		MaxBodyChars:            cfg.MaxBodyChars,
+		AllowedMCPMethods:       cfg.AllowedMCPMethods,
+		ToolPolicyFile:          cfg.ToolPolicyFile,
+		MaxArgumentChars:        cfg.MaxArgumentChars,
+		ToolPrefix:              cfg.ToolPrefix,
+		SoftErrors:              cfg.SoftErrors,
+		StartupSelfTest:         cfg.StartupSelfTest,
+		TokenValidationFailOpen: cfg.TokenValidationFailOpen,
+		FixturesDir:             cfg.FixturesDir,
+		FixturesMode:            cfg.FixturesMode,
+		MaxSessionConcurrency:   cfg.MaxSessionConcurrency,
+		SessionConcurrencyWait:  cfg.SessionConcurrencyWait,
+		MaxSessionAPICalls:      cfg.MaxSessionAPICalls,
+		RequestIDHeader:         cfg.RequestIDHeader,
+		Translator:              t,
+		Locales:                 locales,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -63,6 +133,10 @@ func RunSSEServerWithSimpleAuth(cfg SSEServerConfig, allowUnauthenticated bool)
 	}
 
 	sseServer := server.NewSSEServer(ghServer, sseOptions...)
+	keepAlive, err := sseKeepAlive(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Configure logging (same as existing)
 	logrusLogger := logrus.New()
@@ -74,6 +148,7 @@ func RunSSEServerWithSimpleAuth(cfg SSEServerConfig, allowUnauthenticated bool)
 		logrusLogger.SetLevel(logrus.DebugLevel)
 		logrusLogger.SetOutput(file)
 	}
+	deduplicateLogs(cfg.LogDedupWindow, logrusLogger)
 
 	if cfg.ExportTranslations {
 		dumpTranslations()
@@ -82,48 +157,108 @@ func RunSSEServerWithSimpleAuth(cfg SSEServerConfig, allowUnauthenticated bool)
 	// Create HTTP mux with authentication middleware
 	mux := http.NewServeMux()
 
+	requiredHeaders, err := parseRequiredHeaders(cfg.RequiredHeaders)
+	if err != nil {
+		return fmt.Errorf("failed to parse required headers: %w", err)
+	}
+
 	// Choose authentication middleware
+	authOptions := AuthOptions{
+		LogContextHeaders: cfg.LogContextHeaders,
+		LogSampleRate:     cfg.LogSampleRate,
+		RequiredHeaders:   requiredHeaders,
+	}
 	var authMiddleware func(http.Handler) http.Handler
 	if allowUnauthenticated {
-		authMiddleware = OptionalAuthenticationMiddleware
+		authMiddleware = NewOptionalAuthenticationMiddleware(authOptions)
 		logrus.Warn("Authentication is optional - some operations may be limited")
 	} else {
-		authMiddleware = AuthenticationMiddleware
+		authMiddleware = NewAuthenticationMiddleware(authOptions)
 		logrus.Info("Authentication is required for all operations")
 	}
 
-	// Add health check (no auth required)
+	allowedHosts, err := newHostAllowList(cfg.Host, slices.Sorted(maps.Keys(hostTokens)))
+	if err != nil {
+		return fmt.Errorf("failed to parse allowed hosts: %w", err)
+	}
+	hostMiddleware := gitHubHostMiddleware(allowedHosts)
+
+	proxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("failed to parse trusted proxies: %w", err)
+	}
+
+	corsHeaders, corsMethods, err := corsAllowList(cfg.CORSAllowedHeaders, cfg.CORSAllowedMethods)
+	if err != nil {
+		return fmt.Errorf("failed to parse CORS settings: %w", err)
+	}
+
+	maintenance := newMaintenanceMode(cfg.MaintenanceRetryAfter)
+
+	// Add health check (no auth required). It stays healthy in maintenance mode so the process is not restarted.
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"healthy","timestamp":"` + time.Now().Format(time.RFC3339) + `"}`))
+		w.Write([]byte(`{"status":"healthy","maintenance":` + strconv.FormatBool(maintenance.status().Enabled) +
+			`,"timestamp":"` + time.Now().Format(time.RFC3339) + `"}`))
 	})
 
-	// Add status endpoint (no auth required)
-	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+	// Add status endpoint (no auth required unless configured otherwise)
+	statusHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
+		breaker := circuitBreaker.Status()
+		maintenanceStatus, _ := json.Marshal(maintenance.status())
+		auditStatus := []byte("null")
+		if auditWebhook != nil {
+			auditStatus, _ = json.Marshal(auditWebhook.Status())
+		}
 		status := fmt.Sprintf(`{
 			"status": "running",
 			"version": "%s",
 			"host": "%s",
 			"authentication_required": %t,
 			"read_only": %t,
+			"circuit_breaker": {
+				"state": "%s",
+				"consecutive_failures": %d,
+				"threshold": %d,
+				"cooldown": "%s"
+			},
+			"maintenance": %s,
+			"audit_webhook": %s,
 			"timestamp": "%s"
-		}`, cfg.Version, cfg.Host, !allowUnauthenticated, cfg.ReadOnly, time.Now().Format(time.RFC3339))
+		}`, cfg.Version, cfg.Host, !allowUnauthenticated, cfg.ReadOnly,
+			breaker.State, breaker.ConsecutiveFailures, breaker.Threshold, breaker.Cooldown,
+			maintenanceStatus, auditStatus, time.Now().Format(time.RFC3339))
 		w.Write([]byte(status))
 	})
+	switch {
+	case cfg.DisableStatus:
+		logrus.Info("Status endpoint is disabled")
+	case cfg.StatusRequiresAuth:
+		mux.Handle("/status", authMiddleware(statusHandler))
+	default:
+		mux.Handle("/status", statusHandler)
+	}
 
 	// Add MCP endpoints WITH authentication middleware
-	mux.Handle(cfg.BasePath+"/sse", authMiddleware(sseServer.SSEHandler()))
-	mux.Handle(cfg.BasePath+"/message", authMiddleware(sseServer.MessageHandler()))
+	connectionLimiter := newSSEConnectionLimiter(cfg.MaxSSEConnections, cfg.SSERetryBase, cfg.SSERetryMax)
+	drain := newShutdownDrain(cfg.ShutdownGracePeriod, cfg.ShutdownReconnectDelay)
+	mux.Handle(cfg.BasePath+"/sse", drain.middleware(maintenance.middleware(connectionLimiter.middleware(authMiddleware(keepAlive(sseServer.SSEHandler()))))))
+	mux.Handle(cfg.BasePath+"/message", maintenance.middleware(authMiddleware(messageValidationMiddleware(hostMiddleware(outputFormatMiddleware(sseServer.MessageHandler()))))))
+
+	// The admin endpoint only exists when an admin token is configured
+	if cfg.AdminToken != "" {
+		mux.Handle("/admin/maintenance", maintenance.adminHandler(cfg.AdminToken))
+	}
 
 	// Add CORS support
-	corsHandler := addSimpleCORS(mux)
+	corsHandler := addSimpleCORS(mux, corsHeaders, corsMethods)
 
 	httpServer := &http.Server{
 		Addr:              cfg.ListenAddr,
-		Handler:           corsHandler,
+		Handler:           clientIPMiddleware(proxies)(corsHandler),
 		ReadTimeout:       30 * time.Second,
 		WriteTimeout:      30 * time.Second,
 		IdleTimeout:       60 * time.Second,
@@ -145,6 +280,11 @@ func RunSSEServerWithSimpleAuth(cfg SSEServerConfig, allowUnauthenticated bool)
 	select {
 	case <-ctx.Done():
 		logrus.Info("Shutting down server...")
+		drain.drain(ghServer.SendNotificationToAllClients)
+	case <-upstreamFailureExit.Tripped():
+		// The process exits with the error right after
+		shutdownOnUpstreamFailure(httpServer, drain, ghServer.SendNotificationToAllClients, upstreamFailureShutdownTimeout)
+		return upstreamFailureExit.Err()
 	case err := <-errC:
 		if err != nil && err != http.ErrServerClosed {
 			return fmt.Errorf("error running server: %w", err)
@@ -157,16 +297,20 @@ func RunSSEServerWithSimpleAuth(cfg SSEServerConfig, allowUnauthenticated bool)
 	return httpServer.Shutdown(shutdownCtx)
 }
 
-// addSimpleCORS adds basic CORS support
-func addSimpleCORS(next http.Handler) http.Handler {
+// outputFormatMiddleware honours the X-Output-Format header so a client can ask for compact or
+// pretty JSON tool results on a per-request basis. Unknown values fall back to the server default.
+func outputFormatMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-User-ID, X-User-Email, X-User-Name, X-Session-ID, X-Gateway-Request-ID")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+		if header := r.Header.Get("X-Output-Format"); header != "" {
+			format, err := github.ParseOutputFormat(header)
+			if err != nil {
+				logrus.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"path":  r.URL.Path,
+				}).Debug("Ignoring invalid output format header")
+			} else {
+				r = r.WithContext(github.ContextWithOutputFormat(r.Context(), format))
+			}
 		}
 
 		next.ServeHTTP(w, r)
@@ -0,0 +1,106 @@
+package ghmcp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultSSEKeepAliveComment is the comment written to SSE streams to keep them from going idle
+const DefaultSSEKeepAliveComment = ":ping"
+
+// validateSSEKeepAliveComment checks that comment is a single SSE comment line, which starts with a colon
+func validateSSEKeepAliveComment(comment string) error {
+	if !strings.HasPrefix(comment, ":") {
+		return fmt.Errorf("invalid SSE keep-alive comment %q, it must begin with ':'", comment)
+	}
+	if strings.ContainsAny(comment, "\r\n") {
+		return fmt.Errorf("invalid SSE keep-alive comment %q, it must be a single line", comment)
+	}
+	return nil
+}
+
+// sseKeepAliveMiddleware writes comment to SSE streams every interval. The keep-alive of mcp-go is a JSON-RPC
+// ping event, which some proxies do not count as activity, and others drop empty comment lines, so the comment
+// can be set to whatever the proxies in front of the server expect. Clients ignore comment lines.
+func sseKeepAliveMiddleware(comment string, interval time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if comment == "" || interval <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			stream := &keepAliveWriter{ResponseWriter: w, flusher: flusher}
+			done := make(chan struct{})
+			go func() {
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						stream.writeComment(comment)
+					case <-done:
+						return
+					case <-r.Context().Done():
+						return
+					}
+				}
+			}()
+
+			next.ServeHTTP(stream, r)
+			close(done)
+			stream.close()
+		})
+	}
+}
+
+// keepAliveWriter serializes the writes of the SSE handler and of the keep-alive, so that a comment never lands
+// in the middle of an event
+type keepAliveWriter struct {
+	http.ResponseWriter
+	flusher http.Flusher
+
+	mu sync.Mutex
+	// started is set once the handler wrote to the stream, comments are only written to streams that started
+	started bool
+	// closed is set once the handler returned, after which the response must not be written to
+	closed bool
+}
+
+func (w *keepAliveWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.started = true
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *keepAliveWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flusher.Flush()
+}
+
+func (w *keepAliveWriter) writeComment(comment string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.started || w.closed {
+		return
+	}
+	_, _ = io.WriteString(w.ResponseWriter, comment+"\n\n")
+	w.flusher.Flush()
+}
+
+func (w *keepAliveWriter) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+}
@@ -0,0 +1,61 @@
+package ghmcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateSSEKeepAliveComment(t *testing.T) {
+	assert.NoError(t, validateSSEKeepAliveComment(":ping"))
+	assert.NoError(t, validateSSEKeepAliveComment(":"))
+	assert.NoError(t, validateSSEKeepAliveComment(": keep-alive"))
+
+	assert.ErrorContains(t, validateSSEKeepAliveComment("ping"), "must begin with ':'")
+	assert.ErrorContains(t, validateSSEKeepAliveComment(" :ping"), "must begin with ':'")
+	assert.ErrorContains(t, validateSSEKeepAliveComment(":ping\ndata: x"), "must be a single line")
+	assert.ErrorContains(t, validateSSEKeepAliveComment(":ping\r"), "must be a single line")
+}
+
+func Test_SSEKeepAliveMiddleware(t *testing.T) {
+	handler := sseKeepAliveMiddleware(": keep-alive", 5*time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("event: endpoint\ndata: /message\n\n"))
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+
+	// Comments follow the first event, each as a block of its own
+	body := rec.Body.String()
+	require.True(t, strings.HasPrefix(body, "event: endpoint\ndata: /message\n\n"))
+	comments := strings.TrimPrefix(body, "event: endpoint\ndata: /message\n\n")
+	assert.NotEmpty(t, comments)
+	assert.Empty(t, strings.ReplaceAll(comments, ": keep-alive\n\n", ""))
+}
+
+func Test_SSEKeepAliveMiddlewareWaitsForStream(t *testing.T) {
+	// Nothing is written to a response the handler has not started, such as one refused by the auth middleware
+	handler := sseKeepAliveMiddleware(":ping", 5*time.Millisecond)(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	assert.Empty(t, rec.Body.String())
+}
+
+func Test_SSEKeepAlive(t *testing.T) {
+	_, err := sseKeepAlive(SSEServerConfig{KeepAlive: true, KeepAliveInterval: time.Second, KeepAliveComment: "ping"})
+	assert.ErrorContains(t, err, "must begin with ':'")
+
+	// The comment is not validated when keep-alive is off
+	_, err = sseKeepAlive(SSEServerConfig{KeepAliveComment: "ping"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,106 @@
+package ghmcp
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// sseConnectionLimiter caps the number of open SSE streams. Connections over the limit are shed
+// with an SSE retry hint, which grows exponentially while the server keeps shedding so that
+// compliant clients back off instead of reconnecting in a tight loop.
+type sseConnectionLimiter struct {
+	max       int
+	baseRetry time.Duration
+	maxRetry  time.Duration
+
+	mu     sync.Mutex
+	active int
+	shed   int
+}
+
+// newSSEConnectionLimiter creates a limiter allowing max concurrent streams, max <= 0 means no limit
+func newSSEConnectionLimiter(max int, baseRetry, maxRetry time.Duration) *sseConnectionLimiter {
+	if baseRetry <= 0 {
+		baseRetry = time.Second
+	}
+	if maxRetry < baseRetry {
+		maxRetry = baseRetry
+	}
+	return &sseConnectionLimiter{
+		max:       max,
+		baseRetry: baseRetry,
+		maxRetry:  maxRetry,
+	}
+}
+
+// acquire takes a connection slot. When none is left, it returns false and how long the client should wait.
+func (l *sseConnectionLimiter) acquire() (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active < l.max {
+		l.active++
+		l.shed = 0
+		return true, 0
+	}
+
+	l.shed++
+	return false, l.retryDelay(l.shed)
+}
+
+func (l *sseConnectionLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+}
+
+// retryDelay doubles the base retry for every connection shed in a row, up to the max retry
+func (l *sseConnectionLimiter) retryDelay(shed int) time.Duration {
+	delay := l.baseRetry
+	for i := 1; i < shed && delay < l.maxRetry; i++ {
+		delay *= 2
+	}
+	if delay > l.maxRetry {
+		delay = l.maxRetry
+	}
+	return delay
+}
+
+func (l *sseConnectionLimiter) middleware(next http.Handler) http.Handler {
+	if l.max <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, retry := l.acquire()
+		if !ok {
+			logrus.WithFields(logrus.Fields{
+				"max_connections": l.max,
+				"retry":           retry.String(),
+			}).Warn("Shedding SSE connection, connection limit reached")
+			writeSSERetry(w, retry)
+			return
+		}
+		defer l.release()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeSSERetry ends an SSE stream right away with a retry field. The response is a 200 because
+// EventSource clients only honour retry on a successful stream, and give up on error statuses.
+func writeSSERetry(w http.ResponseWriter, retry time.Duration) {
+	seconds := int((retry + time.Second - 1) / time.Second)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\nevent: error\ndata: {\"error\":\"server_overloaded\",\"retry_ms\":%d}\n\n",
+		retry.Milliseconds(), retry.Milliseconds())
+}
@@ -0,0 +1,68 @@
+package ghmcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SSEConnectionLimiter(t *testing.T) {
+	limiter := newSSEConnectionLimiter(1, time.Second, 5*time.Second)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	handler := limiter.middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		close(started)
+		<-block
+	}))
+
+	// Hold the only slot open
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/sse", nil))
+	}()
+	<-started
+
+	shed := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+		return rec
+	}
+
+	rec := shed()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "retry: 1000\n")
+
+	// The retry hint doubles while connections keep being shed, up to the max
+	assert.Contains(t, shed().Body.String(), "retry: 2000\n")
+	assert.Contains(t, shed().Body.String(), "retry: 4000\n")
+	assert.Contains(t, shed().Body.String(), "retry: 5000\n")
+	assert.Contains(t, shed().Body.String(), "retry: 5000\n")
+
+	// Once the slot is released, connections are accepted again and the backoff resets
+	close(block)
+	<-done
+	ok, _ := limiter.acquire()
+	require.True(t, ok)
+	limiter.release()
+	assert.Equal(t, 0, limiter.shed)
+}
+
+func Test_SSEConnectionLimiterDisabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := newSSEConnectionLimiter(0, time.Second, time.Minute).middleware(next)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sse", nil))
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+}
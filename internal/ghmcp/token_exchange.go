@@ -0,0 +1,195 @@
+package ghmcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// tokenExchangeTimeout bounds each request of the HTTP token exchanger
+const tokenExchangeTimeout = 5 * time.Second
+
+// ExchangedToken is the GitHub token a TokenExchanger returns for a gateway user. ExpiresIn, in seconds, shortens
+// how long the token is cached when it is positive.
+type ExchangedToken struct {
+	Token     string `json:"token"`
+	ExpiresIn int    `json:"expires_in,omitempty"`
+}
+
+// TokenExchanger swaps the identity of a gateway user for the GitHub token their tool calls use, for gateways
+// whose bearer tokens are not GitHub tokens, such as those of an SSO provider.
+type TokenExchanger interface {
+	ExchangeToken(ctx context.Context, user *UserContext) (ExchangedToken, error)
+}
+
+// HTTPTokenExchanger POSTs the gateway user as JSON to a token service, along with their bearer token, and
+// expects a 200 with an ExchangedToken.
+type HTTPTokenExchanger struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPTokenExchanger checks rawURL and returns an exchanger asking it for tokens
+func NewHTTPTokenExchanger(rawURL string) (*HTTPTokenExchanger, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid token exchange URL %q, expected an http or https URL", rawURL)
+	}
+	return &HTTPTokenExchanger{
+		url:    rawURL,
+		client: &http.Client{Timeout: tokenExchangeTimeout},
+	}, nil
+}
+
+// ExchangeToken asks the token service for the GitHub token of user
+func (e *HTTPTokenExchanger) ExchangeToken(ctx context.Context, user *UserContext) (ExchangedToken, error) {
+	body, err := json.Marshal(AuthzUser{
+		UserID:    user.UserID,
+		Email:     user.Email,
+		Name:      user.Name,
+		SessionID: user.SessionID,
+		RequestID: user.RequestID,
+	})
+	if err != nil {
+		return ExchangedToken{}, fmt.Errorf("failed to encode token exchange request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return ExchangedToken{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// The service verifies the identity of the user with the token the gateway authenticated them with
+	req.Header.Set("Authorization", "Bearer "+user.Token)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return ExchangedToken{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return ExchangedToken{}, fmt.Errorf("token service responded with %s", resp.Status)
+	}
+
+	var token ExchangedToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return ExchangedToken{}, fmt.Errorf("failed to decode exchanged token: %w", err)
+	}
+	if token.Token == "" {
+		return ExchangedToken{}, errors.New("token service returned no token")
+	}
+	return token, nil
+}
+
+// newTokenExchanger returns the HTTP token exchanger for rawURL, or nil when rawURL is empty
+func newTokenExchanger(rawURL string) (TokenExchanger, error) {
+	if rawURL == "" {
+		return nil, nil
+	}
+	exchanger, err := NewHTTPTokenExchanger(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("token_exchange_url", rawURL).Info("Exchanging gateway identities for GitHub tokens with token service")
+	return exchanger, nil
+}
+
+type cachedToken struct {
+	token   string
+	expires time.Time
+}
+
+// tokenExchangeCache keeps the exchanged token of each gateway user for a TTL, so that the token service is
+// asked once per user rather than for every GitHub API call. Tokens are cached per user and bearer token, so
+// that a request only gets a cached token with the same bearer token the service verified.
+type tokenExchangeCache struct {
+	exchanger TokenExchanger
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+func newTokenExchangeCache(exchanger TokenExchanger, ttl time.Duration) *tokenExchangeCache {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &tokenExchangeCache{
+		exchanger: exchanger,
+		ttl:       ttl,
+		now:       time.Now,
+		tokens:    make(map[string]cachedToken),
+	}
+}
+
+// tokenExchangeCacheKey returns the cache key of user, the user ID and a hash of their bearer token, which
+// is not kept itself
+func tokenExchangeCacheKey(user *UserContext) string {
+	sum := sha256.Sum256([]byte(user.Token))
+	return user.UserID + ":" + hex.EncodeToString(sum[:])
+}
+
+// token returns the cached token of user, exchanging it once it expired
+func (c *tokenExchangeCache) token(ctx context.Context, user *UserContext) (string, error) {
+	key := tokenExchangeCacheKey(user)
+	c.mu.Lock()
+	cached, ok := c.tokens[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expires) {
+		return cached.token, nil
+	}
+
+	exchanged, err := c.exchanger.ExchangeToken(ctx, user)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange the token of user %s: %w", user.UserID, err)
+	}
+	ttl := c.ttl
+	if expiresIn := time.Duration(exchanged.ExpiresIn) * time.Second; expiresIn > 0 && expiresIn < ttl {
+		ttl = expiresIn
+	}
+
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Drop the tokens of users who have gone, so that the cache does not grow with every user ever seen
+	for cachedKey, cached := range c.tokens {
+		if !now.Before(cached.expires) {
+			delete(c.tokens, cachedKey)
+		}
+	}
+	c.tokens[key] = cachedToken{token: exchanged.Token, expires: now.Add(ttl)}
+	return exchanged.Token, nil
+}
+
+// tokenExchangeTransport authenticates the GitHub API requests of a gateway user with their exchanged token
+// instead of the configured one. Requests without a gateway user, such as those of stdio sessions, keep the
+// configured token.
+type tokenExchangeTransport struct {
+	transport http.RoundTripper
+	tokens    *tokenExchangeCache
+}
+
+func (t *tokenExchangeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	user, ok := GetUserContext(req.Context())
+	if !ok {
+		return t.transport.RoundTrip(req)
+	}
+
+	token, err := t.tokens.token(req.Context(), user)
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.transport.RoundTrip(req)
+}
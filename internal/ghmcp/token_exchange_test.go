@@ -0,0 +1,126 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewTokenExchanger(t *testing.T) {
+	exchanger, err := newTokenExchanger("")
+	require.NoError(t, err)
+	assert.Nil(t, exchanger)
+
+	exchanger, err = newTokenExchanger("https://tokens.example.com/exchange")
+	require.NoError(t, err)
+	assert.IsType(t, &HTTPTokenExchanger{}, exchanger)
+
+	for _, rawURL := range []string{"tokens.example.com/exchange", "ftp://tokens.example.com", "https://"} {
+		_, err := NewHTTPTokenExchanger(rawURL)
+		assert.Error(t, err, rawURL)
+	}
+}
+
+func Test_TokenExchangeTransport(t *testing.T) {
+	exchanges := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var user AuthzUser
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+
+		switch user.UserID {
+		case "mona":
+			switch r.Header.Get("Authorization") {
+			case "Bearer sso-mona":
+				_ = json.NewEncoder(w).Encode(ExchangedToken{Token: "ghu_mona"})
+			case "Bearer sso-mona-laptop":
+				_ = json.NewEncoder(w).Encode(ExchangedToken{Token: "ghu_mona_laptop"})
+			default:
+				w.WriteHeader(http.StatusForbidden)
+			}
+		case "hubot":
+			_ = json.NewEncoder(w).Encode(ExchangedToken{Token: "ghu_hubot", ExpiresIn: 60})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	exchanger, err := NewHTTPTokenExchanger(srv.URL)
+	require.NoError(t, err)
+	tokens := newTokenExchangeCache(exchanger, 5*time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokens.now = func() time.Time { return now }
+
+	var authorization string
+	transport := &tokenExchangeTransport{
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		tokens: tokens,
+	}
+	roundTrip := func(user *UserContext) error {
+		ctx := context.Background()
+		if user != nil {
+			ctx = WithUserContext(ctx, user)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer configured")
+		_, err = transport.RoundTrip(req)
+		return err
+	}
+	mona := &UserContext{UserID: "mona", Token: "sso-mona"}
+	hubot := &UserContext{UserID: "hubot", Token: "sso-hubot"}
+
+	// Requests without a gateway user keep the configured token
+	require.NoError(t, roundTrip(nil))
+	assert.Equal(t, "Bearer configured", authorization)
+	assert.Equal(t, 0, exchanges)
+
+	require.NoError(t, roundTrip(mona))
+	assert.Equal(t, "Bearer ghu_mona", authorization)
+	require.NoError(t, roundTrip(mona))
+	assert.Equal(t, "Bearer ghu_mona", authorization)
+	assert.Equal(t, 1, exchanges, "the token of mona is cached")
+
+	require.NoError(t, roundTrip(hubot))
+	assert.Equal(t, "Bearer ghu_hubot", authorization)
+	assert.Equal(t, 2, exchanges)
+
+	// expires_in shortens the TTL of hubot, mona is still within the configured one
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, roundTrip(hubot))
+	require.NoError(t, roundTrip(mona))
+	assert.Equal(t, 3, exchanges)
+
+	now = now.Add(5 * time.Minute)
+	require.NoError(t, roundTrip(mona))
+	assert.Equal(t, 4, exchanges)
+
+	// Each bearer token of a user gets its own exchange, so that the service verifies every one of them
+	require.NoError(t, roundTrip(&UserContext{UserID: "mona", Token: "sso-mona-laptop"}))
+	assert.Equal(t, "Bearer ghu_mona_laptop", authorization)
+	assert.Equal(t, 5, exchanges)
+	err = roundTrip(&UserContext{UserID: "mona", Token: "forged"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Equal(t, 6, exchanges, "a bearer token the service did not verify never gets a cached token")
+	require.NoError(t, roundTrip(mona))
+	assert.Equal(t, "Bearer ghu_mona", authorization)
+	assert.Equal(t, 6, exchanges)
+
+	err = roundTrip(&UserContext{UserID: "octocat"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to exchange the token of user octocat")
+	assert.Contains(t, err.Error(), "403")
+}
@@ -0,0 +1,41 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// toolPrefixPattern restricts tool prefixes to characters that are valid in tool names for every client
+var toolPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// validateToolPrefix checks that prefix can start a tool name
+func validateToolPrefix(prefix string) error {
+	if !toolPrefixPattern.MatchString(prefix) {
+		return fmt.Errorf("invalid tool prefix %q, only letters, digits, _ and - are allowed", prefix)
+	}
+	return nil
+}
+
+// withToolPrefix prepends prefix to the name of a tool
+func withToolPrefix(prefix string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		tool.Name = prefix + tool.Name
+	}
+}
+
+// toolPrefixMiddleware removes prefix from the name of the called tool, so that the middlewares and
+// handlers after it, which know the tools by their own names, see the name the tool was defined with.
+// It has to come first.
+func toolPrefixMiddleware(prefix string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			request.Params.Name = strings.TrimPrefix(request.Params.Name, prefix)
+			return next(ctx, request)
+		}
+	}
+}
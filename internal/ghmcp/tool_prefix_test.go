@@ -0,0 +1,87 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateToolPrefix(t *testing.T) {
+	for _, prefix := range []string{"", "gh_", "github-"} {
+		assert.NoError(t, validateToolPrefix(prefix), prefix)
+	}
+	for _, prefix := range []string{"gh ", "gh/", "gh.", "ghé"} {
+		assert.Error(t, validateToolPrefix(prefix), prefix)
+	}
+}
+
+// handleMessage sends a JSON-RPC message to s and returns its response
+func handleMessage(t *testing.T, s *server.MCPServer, message string) mcp.JSONRPCResponse {
+	t.Helper()
+	response, ok := s.HandleMessage(context.Background(), json.RawMessage(message)).(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected a JSON-RPC response")
+	return response
+}
+
+func Test_ToolPrefix(t *testing.T) {
+	t.Run("every tool is registered with the prefix", func(t *testing.T) {
+		ghServer, err := NewMCPServer(MCPServerConfig{
+			Version:         "test",
+			EnabledToolsets: []string{"repos"},
+			DynamicToolsets: true,
+			ToolPrefix:      "gh_",
+			Translator:      translations.NullTranslationHelper,
+		})
+		require.NoError(t, err)
+
+		response := handleMessage(t, ghServer, `{"jsonrpc": "2.0", "id": 1, "method": "tools/list"}`)
+		result, ok := response.Result.(mcp.ListToolsResult)
+		require.True(t, ok)
+
+		names := make([]string, 0, len(result.Tools))
+		for _, tool := range result.Tools {
+			assert.True(t, strings.HasPrefix(tool.Name, "gh_"), tool.Name)
+			names = append(names, tool.Name)
+		}
+		assert.Contains(t, names, "gh_get_me")
+		assert.Contains(t, names, "gh_get_file_contents")
+		assert.Contains(t, names, "gh_enable_toolset")
+	})
+
+	t.Run("prefixed calls reach the tool under its own name", func(t *testing.T) {
+		s := server.NewMCPServer("test", "0.0.1",
+			server.WithToolHandlerMiddleware(toolPrefixMiddleware("gh_")),
+		)
+		tool := mcp.NewTool("get_me")
+		withToolPrefix("gh_")(&tool)
+		s.AddTool(tool, func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(request.Params.Name), nil
+		})
+
+		response := handleMessage(t, s, `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "gh_get_me"}}`)
+		result, ok := response.Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		assert.Equal(t, "get_me", result.Content[0].(mcp.TextContent).Text)
+
+		// The tool is no longer known by its unprefixed name
+		unprefixed := s.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc": "2.0", "id": 2, "method": "tools/call", "params": {"name": "get_me"}}`))
+		_, isError := unprefixed.(mcp.JSONRPCError)
+		assert.True(t, isError)
+	})
+
+	t.Run("invalid prefixes are rejected", func(t *testing.T) {
+		_, err := NewMCPServer(MCPServerConfig{
+			Version:    "test",
+			ToolPrefix: "gh/",
+			Translator: translations.NullTranslationHelper,
+		})
+		assert.ErrorContains(t, err, "invalid tool prefix")
+	})
+}
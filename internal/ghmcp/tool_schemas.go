@@ -0,0 +1,92 @@
+package ghmcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	gogithub "github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// ToolSchema is the exported description of a single tool, meant for generating typed client bindings
+type ToolSchema struct {
+	Name        string              `json:"name"`
+	Toolset     string              `json:"toolset"`
+	Description string              `json:"description"`
+	ReadOnly    bool                `json:"readOnly"`
+	InputSchema mcp.ToolInputSchema `json:"inputSchema"`
+}
+
+// ExportToolSchemasConfig selects the tools whose schemas are exported
+type ExportToolSchemasConfig struct {
+	// Path of the JSON file the schemas are written to
+	Path string
+
+	// EnabledToolsets is a list of toolsets to export
+	EnabledToolsets []string
+
+	// ReadOnly leaves out the tools that are not available in read-only mode
+	ReadOnly bool
+}
+
+// ExportToolSchemas writes the schema of every tool the server would register to a JSON file.
+// Tools are sorted by name, so that the file can be diffed to track schema changes.
+func ExportToolSchemas(cfg ExportToolSchemasConfig) error {
+	t, _ := translations.TranslationHelper()
+
+	// The tools are never called, so they don't need working clients
+	getClient := func(_ context.Context) (*gogithub.Client, error) {
+		return nil, errors.New("no GitHub client when exporting tool schemas")
+	}
+	getGQLClient := func(_ context.Context) (*githubv4.Client, error) {
+		return nil, errors.New("no GitHub client when exporting tool schemas")
+	}
+
+	tsg := github.DefaultToolsetGroup(cfg.ReadOnly, getClient, getGQLClient, t)
+	if err := tsg.EnableToolsets(cfg.EnabledToolsets); err != nil {
+		return fmt.Errorf("failed to enable toolsets: %w", err)
+	}
+
+	data, err := json.MarshalIndent(collectToolSchemas(tsg), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tool schemas: %w", err)
+	}
+
+	if err := os.WriteFile(cfg.Path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write tool schemas: %w", err)
+	}
+	return nil
+}
+
+// collectToolSchemas returns the schemas of the active tools of tsg, sorted by name
+func collectToolSchemas(tsg *toolsets.ToolsetGroup) []ToolSchema {
+	schemas := []ToolSchema{}
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			readOnly := false
+			if tool.Tool.Annotations.ReadOnlyHint != nil {
+				readOnly = *tool.Tool.Annotations.ReadOnlyHint
+			}
+			schemas = append(schemas, ToolSchema{
+				Name:        tool.Tool.Name,
+				Toolset:     toolset.Name,
+				Description: tool.Tool.Description,
+				ReadOnly:    readOnly,
+				InputSchema: tool.Tool.InputSchema,
+			})
+		}
+	}
+
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Name < schemas[j].Name
+	})
+	return schemas
+}
@@ -0,0 +1,62 @@
+package ghmcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExportToolSchemas(t *testing.T) {
+	export := func(t *testing.T, cfg ExportToolSchemasConfig) ([]byte, []ToolSchema) {
+		cfg.Path = filepath.Join(t.TempDir(), "schemas.json")
+		require.NoError(t, ExportToolSchemas(cfg))
+
+		data, err := os.ReadFile(cfg.Path)
+		require.NoError(t, err)
+
+		var schemas []ToolSchema
+		require.NoError(t, json.Unmarshal(data, &schemas))
+		return data, schemas
+	}
+
+	t.Run("exports enabled toolsets sorted by name", func(t *testing.T) {
+		data, schemas := export(t, ExportToolSchemasConfig{EnabledToolsets: []string{"all"}})
+		require.NotEmpty(t, schemas)
+
+		names := make([]string, 0, len(schemas))
+		for _, schema := range schemas {
+			names = append(names, schema.Name)
+		}
+		assert.True(t, sort.StringsAreSorted(names))
+		assert.Contains(t, names, "create_issue")
+		assert.Contains(t, names, "get_issue")
+
+		// The output is stable across runs
+		again, _ := export(t, ExportToolSchemasConfig{EnabledToolsets: []string{"all"}})
+		assert.Equal(t, string(data), string(again))
+	})
+
+	t.Run("respects toolsets and read-only mode", func(t *testing.T) {
+		_, schemas := export(t, ExportToolSchemasConfig{EnabledToolsets: []string{"issues"}, ReadOnly: true})
+		require.NotEmpty(t, schemas)
+
+		for _, schema := range schemas {
+			assert.Equal(t, "issues", schema.Toolset)
+			assert.True(t, schema.ReadOnly, schema.Name)
+			assert.Equal(t, "object", schema.InputSchema.Type)
+		}
+	})
+
+	t.Run("unknown toolset", func(t *testing.T) {
+		err := ExportToolSchemas(ExportToolSchemasConfig{
+			Path:            filepath.Join(t.TempDir(), "schemas.json"),
+			EnabledToolsets: []string{"nope"},
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,115 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolCategory groups tools that get the same timeout
+type ToolCategory string
+
+const (
+	ToolCategoryRead   ToolCategory = "read"
+	ToolCategoryWrite  ToolCategory = "write"
+	ToolCategorySearch ToolCategory = "search"
+)
+
+// ToolTimeouts bounds how long a tool call may take. A zero duration means no timeout.
+type ToolTimeouts struct {
+	// Default applies to tools whose category has no timeout of its own
+	Default time.Duration
+
+	// PerCategory overrides Default for the tools of a category
+	PerCategory map[ToolCategory]time.Duration
+}
+
+// ParseToolCategoryTimeouts parses category=duration entries, e.g. "search=60s"
+func ParseToolCategoryTimeouts(entries []string) (map[ToolCategory]time.Duration, error) {
+	timeouts := make(map[ToolCategory]time.Duration, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tool timeout %q, expected category=duration", entry)
+		}
+
+		category := ToolCategory(strings.TrimSpace(name))
+		switch category {
+		case ToolCategoryRead, ToolCategoryWrite, ToolCategorySearch:
+		default:
+			return nil, fmt.Errorf("unknown tool category %q, expected read, write or search", name)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for tool category %s: %w", category, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("timeout for tool category %s must not be negative", category)
+		}
+		timeouts[category] = timeout
+	}
+	return timeouts, nil
+}
+
+// classifyTool puts search tools in their own category, and the other tools in read or write
+// depending on their read-only hint.
+func classifyTool(tool mcp.Tool) ToolCategory {
+	if strings.HasPrefix(tool.Name, "search_") {
+		return ToolCategorySearch
+	}
+	if tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+		return ToolCategoryRead
+	}
+	return ToolCategoryWrite
+}
+
+// classifyTools returns the category of every tool in tsg, keyed by tool name
+func classifyTools(tsg *toolsets.ToolsetGroup) map[string]ToolCategory {
+	categories := make(map[string]ToolCategory)
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			categories[tool.Tool.Name] = classifyTool(tool.Tool)
+		}
+	}
+	return categories
+}
+
+// timeout returns the timeout for a tool category
+func (t ToolTimeouts) timeout(category ToolCategory) time.Duration {
+	if timeout, ok := t.PerCategory[category]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// toolTimeoutMiddleware cancels tool calls that run past the timeout of their category. Tools that are
+// not in categories, such as the dynamic toolset tools, only get the default timeout.
+func toolTimeoutMiddleware(timeouts ToolTimeouts, categories map[string]ToolCategory) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timeout := timeouts.Default
+			if category, ok := categories[request.Params.Name]; ok {
+				timeout = timeouts.timeout(category)
+			}
+			if timeout <= 0 {
+				return next(ctx, request)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			result, err := next(ctx, request)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return mcp.NewToolResultError(fmt.Sprintf("tool %s timed out after %s", request.Params.Name, timeout)), nil
+			}
+			return result, err
+		}
+	}
+}
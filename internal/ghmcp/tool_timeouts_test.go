@@ -0,0 +1,88 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseToolCategoryTimeouts(t *testing.T) {
+	timeouts, err := ParseToolCategoryTimeouts([]string{"search=60s", " read = 5s "})
+	require.NoError(t, err)
+	assert.Equal(t, map[ToolCategory]time.Duration{
+		ToolCategorySearch: time.Minute,
+		ToolCategoryRead:   5 * time.Second,
+	}, timeouts)
+
+	for _, invalid := range []string{"search", "admin=5s", "write=soon", "read=-1s"} {
+		_, err := ParseToolCategoryTimeouts([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_ClassifyTool(t *testing.T) {
+	readOnly := true
+	assert.Equal(t, ToolCategorySearch, classifyTool(mcp.NewTool("search_code", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly}))))
+	assert.Equal(t, ToolCategoryRead, classifyTool(mcp.NewTool("get_issue", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: &readOnly}))))
+	assert.Equal(t, ToolCategoryWrite, classifyTool(mcp.NewTool("create_issue")))
+}
+
+func Test_ToolTimeoutMiddleware(t *testing.T) {
+	timeouts := ToolTimeouts{
+		Default:     time.Hour,
+		PerCategory: map[ToolCategory]time.Duration{ToolCategorySearch: 10 * time.Millisecond},
+	}
+	categories := map[string]ToolCategory{
+		"search_code": ToolCategorySearch,
+		"get_issue":   ToolCategoryRead,
+	}
+
+	var deadline time.Duration
+	handler := toolTimeoutMiddleware(timeouts, categories)(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		d, ok := ctx.Deadline()
+		require.True(t, ok)
+		deadline = time.Until(d)
+		if deadline < time.Second {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	call := func(name string) (*mcp.CallToolResult, error) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		return handler(context.Background(), request)
+	}
+
+	t.Run("category timeout overrides the default", func(t *testing.T) {
+		result, err := call("search_code")
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(mcp.TextContent).Text, "tool search_code timed out after 10ms")
+	})
+
+	t.Run("default timeout applies otherwise", func(t *testing.T) {
+		result, err := call("get_issue")
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Greater(t, deadline, time.Minute)
+	})
+
+	t.Run("no timeout when disabled", func(t *testing.T) {
+		called := false
+		handler := toolTimeoutMiddleware(ToolTimeouts{}, categories)(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			_, ok := ctx.Deadline()
+			assert.False(t, ok)
+			called = true
+			return mcp.NewToolResultText("ok"), nil
+		})
+		_, err := handler(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+		assert.True(t, called)
+	})
+}
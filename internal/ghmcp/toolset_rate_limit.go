@@ -0,0 +1,204 @@
+package ghmcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrorCodeRateLimited is returned for tool calls over the rate limit of their toolset
+const ErrorCodeRateLimited = "rate_limited"
+
+// ToolsetRateLimit lets the tools of a toolset, or of a tool category, be called Calls times Per period, in
+// bursts of up to Calls
+type ToolsetRateLimit struct {
+	Calls int
+	Per   time.Duration
+}
+
+func (l ToolsetRateLimit) String() string {
+	unit := map[time.Duration]string{time.Second: "second", time.Minute: "minute", time.Hour: "hour"}[l.Per]
+	return fmt.Sprintf("%d times per %s", l.Calls, unit)
+}
+
+// ParseToolsetRateLimits parses toolset=calls/unit entries, e.g. "actions=60/m", the unit being s, m or h. A
+// tool category may be named instead of a toolset, e.g. "search=30/m" for the search tools of every toolset.
+func ParseToolsetRateLimits(entries []string) (map[string]ToolsetRateLimit, error) {
+	units := map[string]time.Duration{"s": time.Second, "m": time.Minute, "h": time.Hour}
+
+	limits := make(map[string]ToolsetRateLimit, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid toolset rate limit %q, expected toolset=calls/unit", entry)
+		}
+		name = strings.TrimSpace(name)
+
+		calls, unit, ok := strings.Cut(strings.TrimSpace(value), "/")
+		per, knownUnit := units[strings.TrimSpace(unit)]
+		n, err := strconv.Atoi(strings.TrimSpace(calls))
+		if !ok || !knownUnit || err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid rate limit for toolset %s %q, expected a positive number of calls per s, m or h, e.g. 30/m", name, value)
+		}
+		limits[name] = ToolsetRateLimit{Calls: n, Per: per}
+	}
+	return limits, nil
+}
+
+// toolsetOfTools returns the toolset of every tool in tsg, keyed by tool name
+func toolsetOfTools(tsg *toolsets.ToolsetGroup) map[string]string {
+	toolsetOf := make(map[string]string)
+	for name, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			toolsetOf[tool.Tool.Name] = name
+		}
+	}
+	return toolsetOf
+}
+
+type tokenBucket struct {
+	group   string
+	tokens  float64
+	updated time.Time
+}
+
+// refill adds the tokens earned since the bucket was last updated, up to the burst of limit
+func (b *tokenBucket) refill(limit ToolsetRateLimit, now time.Time) {
+	earned := float64(now.Sub(b.updated)) * float64(limit.Calls) / float64(limit.Per)
+	b.tokens = math.Min(float64(limit.Calls), b.tokens+earned)
+	b.updated = now
+}
+
+// toolsetRateLimiter throttles tool calls with a token bucket per toolset or tool category and gateway user, so
+// that expensive tools such as searches cannot spend the GitHub rate limit that cheap reads need. A call takes a
+// token from the buckets of both its toolset and its category. Calls without a gateway user get the buckets of
+// their client IP, see clientIPMiddleware, and calls without either, such as those over stdio, share a bucket.
+type toolsetRateLimiter struct {
+	limits map[string]ToolsetRateLimit
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newToolsetRateLimiter(limits map[string]ToolsetRateLimit) *toolsetRateLimiter {
+	return &toolsetRateLimiter{
+		limits:  limits,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// validate checks that the limits name toolsets of tsg or tool categories, and keys the limits naming a
+// toolset by a former name, such as code_security, by the toolset the name now refers to
+func (l *toolsetRateLimiter) validate(tsg *toolsets.ToolsetGroup) error {
+	limits := make(map[string]ToolsetRateLimit, len(l.limits))
+	givenAs := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(l.limits)) {
+		switch ToolCategory(name) {
+		case ToolCategoryRead, ToolCategoryWrite, ToolCategorySearch:
+			limits[name] = l.limits[name]
+			continue
+		}
+		toolset, ok := tsg.ResolveName(name)
+		if !ok {
+			return fmt.Errorf("rate limit for unknown toolset %s, expected a toolset or one of the read, write and search tool categories", name)
+		}
+		if other, ok := givenAs[toolset]; ok {
+			return fmt.Errorf("rate limit for toolset %s given twice, as %s and as %s", toolset, other, name)
+		}
+		givenAs[toolset] = name
+		limits[toolset] = l.limits[name]
+	}
+	l.limits = limits
+	return nil
+}
+
+// take spends a token of each bucket of user for groups, or none when one of them is empty, returning the
+// empty group and how long to wait for a token
+func (l *toolsetRateLimiter) take(groups []string, user string) (string, time.Duration) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	buckets := make([]*tokenBucket, len(groups))
+	for i, group := range groups {
+		limit := l.limits[group]
+		key := group + "\x00" + user
+		bucket, ok := l.buckets[key]
+		if !ok {
+			l.prune(now)
+			bucket = &tokenBucket{group: group, tokens: float64(limit.Calls), updated: now}
+			l.buckets[key] = bucket
+		}
+		bucket.refill(limit, now)
+		if bucket.tokens < 1 {
+			return group, time.Duration((1 - bucket.tokens) * float64(limit.Per) / float64(limit.Calls))
+		}
+		buckets[i] = bucket
+	}
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+	return "", 0
+}
+
+// prune forgets the buckets that have refilled, they are the same as new ones
+func (l *toolsetRateLimiter) prune(now time.Time) {
+	for key, bucket := range l.buckets {
+		limit := l.limits[bucket.group]
+		if bucket.refill(limit, now); bucket.tokens >= float64(limit.Calls) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// rateLimitKey returns whose buckets a call takes tokens from: its gateway user, or its client IP when it has
+// none, so that unauthenticated clients cannot exhaust the buckets of one another
+func rateLimitKey(ctx context.Context) string {
+	if userCtx, ok := GetUserContext(ctx); ok && userCtx.UserID != "" {
+		return userCtx.UserID
+	}
+	if ip, ok := ClientIPFromContext(ctx); ok {
+		// Prefixed, so that an IP cannot collide with a user ID
+		return "ip:" + ip
+	}
+	return ""
+}
+
+// middleware fails the calls over the rate limit of their toolset or category with rate_limited, before the
+// tool runs. toolsetOf and categories are read at call time, so that they can be filled in once the toolsets
+// are created.
+func (l *toolsetRateLimiter) middleware(toolsetOf map[string]string, categories map[string]ToolCategory) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var groups []string
+			for _, group := range []string{toolsetOf[request.Params.Name], string(categories[request.Params.Name])} {
+				if _, ok := l.limits[group]; ok {
+					groups = append(groups, group)
+				}
+			}
+			if len(groups) == 0 {
+				return next(ctx, request)
+			}
+
+			if group, wait := l.take(groups, rateLimitKey(ctx)); group != "" {
+				retryAfter := max(1, int(math.Ceil(wait.Seconds())))
+				result := mcp.NewToolResultError(fmt.Sprintf("%s: %s tools may be called %s, retry in %ds", ErrorCodeRateLimited, group, l.limits[group], retryAfter))
+				result.Meta = map[string]any{"retry_after_seconds": retryAfter}
+				return result, nil
+			}
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package ghmcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseToolsetRateLimits(t *testing.T) {
+	limits, err := ParseToolsetRateLimits([]string{"search=30/m", " actions = 120/h "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ToolsetRateLimit{
+		"search":  {Calls: 30, Per: time.Minute},
+		"actions": {Calls: 120, Per: time.Hour},
+	}, limits)
+
+	for _, entry := range []string{"search", "search=30", "search=30/d", "search=0/m", "search=many/m"} {
+		_, err := ParseToolsetRateLimits([]string{entry})
+		assert.Error(t, err, entry)
+	}
+}
+
+func Test_ToolsetRateLimits(t *testing.T) {
+	_, err := NewMCPServer(MCPServerConfig{
+		Version:           "test",
+		EnabledToolsets:   []string{"repos"},
+		ToolsetRateLimits: map[string]ToolsetRateLimit{"searches": {Calls: 1, Per: time.Minute}},
+		Translator:        translations.NullTranslationHelper,
+	})
+	assert.ErrorContains(t, err, "rate limit for unknown toolset searches")
+
+	// Former toolset names resolve like they do for --toolsets
+	aliased := newToolsetRateLimiter(map[string]ToolsetRateLimit{"code_security": {Calls: 1, Per: time.Minute}})
+	tsg := github.DefaultToolsetGroup(false, nil, nil, translations.NullTranslationHelper)
+	require.NoError(t, aliased.validate(tsg))
+	assert.Equal(t, map[string]ToolsetRateLimit{"security": {Calls: 1, Per: time.Minute}}, aliased.limits)
+	twice := newToolsetRateLimiter(map[string]ToolsetRateLimit{
+		"code_security": {Calls: 1, Per: time.Minute},
+		"security":      {Calls: 2, Per: time.Minute},
+	})
+	assert.ErrorContains(t, twice.validate(tsg), "rate limit for toolset security given twice, as code_security and as security")
+
+	limiter := newToolsetRateLimiter(map[string]ToolsetRateLimit{
+		"search":  {Calls: 2, Per: time.Minute},
+		"actions": {Calls: 1, Per: time.Minute},
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	toolsetOf := map[string]string{"search_code": "repos", "get_file_contents": "repos", "list_workflow_runs": "actions"}
+	categories := map[string]ToolCategory{"search_code": ToolCategorySearch, "get_file_contents": ToolCategoryRead, "list_workflow_runs": ToolCategoryRead}
+	handler := limiter.middleware(toolsetOf, categories)(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	call := func(ctx context.Context, tool string) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = tool
+		result, err := handler(ctx, request)
+		require.NoError(t, err)
+		return result
+	}
+	mona := WithUserContext(context.Background(), &UserContext{UserID: "mona"})
+	hubot := WithUserContext(context.Background(), &UserContext{UserID: "hubot"})
+
+	// A burst of up to the limit goes through, cheap reads of the same toolset are not throttled
+	assert.False(t, call(mona, "search_code").IsError)
+	assert.False(t, call(mona, "search_code").IsError)
+	result := call(mona, "search_code")
+	require.True(t, result.IsError)
+	assert.Equal(t, "rate_limited: search tools may be called 2 times per minute, retry in 30s", result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, 30, result.Meta["retry_after_seconds"])
+	for i := 0; i < 5; i++ {
+		assert.False(t, call(mona, "get_file_contents").IsError)
+	}
+
+	// Each user has their own buckets
+	assert.False(t, call(hubot, "search_code").IsError)
+
+	// Tokens come back over time
+	now = now.Add(30 * time.Second)
+	assert.False(t, call(mona, "search_code").IsError)
+	assert.True(t, call(mona, "search_code").IsError)
+
+	// Calls without a user have the buckets of their client IP
+	firstClient := ContextWithClientIP(context.Background(), "203.0.113.7")
+	secondClient := ContextWithClientIP(context.Background(), "203.0.113.8")
+	assert.False(t, call(firstClient, "list_workflow_runs").IsError)
+	assert.True(t, call(firstClient, "list_workflow_runs").IsError)
+	assert.False(t, call(secondClient, "list_workflow_runs").IsError)
+	// A user calling from the same IP keeps their own buckets
+	assert.False(t, call(ContextWithClientIP(mona, "203.0.113.7"), "list_workflow_runs").IsError)
+
+	// Calls without either, such as those over stdio, share a bucket
+	assert.False(t, call(context.Background(), "list_workflow_runs").IsError)
+	assert.True(t, call(context.Background(), "list_workflow_runs").IsError)
+}
@@ -0,0 +1,133 @@
+package ghmcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// upstreamFailureShutdownTimeout is how long the requests in flight get to finish when the server exits because
+// of upstream failures. It is short, as the requests that need GitHub are failing anyway.
+const upstreamFailureShutdownTimeout = 5 * time.Second
+
+// UpstreamFailureExit stops the server once the GitHub API keeps failing, so that a supervisor restarts the
+// process instead of it carrying on with an upstream it cannot reach. It trips after threshold consecutive
+// failures, all within window of the first one.
+type UpstreamFailureExit struct {
+	threshold int
+	window    time.Duration
+	now       func() time.Time
+
+	mu           sync.Mutex
+	failures     int
+	firstFailure time.Time
+	err          error
+	tripped      chan struct{}
+}
+
+// NewUpstreamFailureExit creates an UpstreamFailureExit tripping after threshold consecutive upstream failures
+// within window. It returns nil when threshold is zero or less, which leaves the server running.
+func NewUpstreamFailureExit(threshold int, window time.Duration) *UpstreamFailureExit {
+	if threshold <= 0 {
+		return nil
+	}
+	return &UpstreamFailureExit{
+		threshold: threshold,
+		window:    window,
+		now:       time.Now,
+		tripped:   make(chan struct{}),
+	}
+}
+
+// Tripped is closed once the server should exit. It is nil, and so never ready, for a nil UpstreamFailureExit.
+func (e *UpstreamFailureExit) Tripped() <-chan struct{} {
+	if e == nil {
+		return nil
+	}
+	return e.tripped
+}
+
+// Err is the reason the server exits, once Tripped is closed.
+func (e *UpstreamFailureExit) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.err
+}
+
+// record updates the consecutive failures with the outcome of an upstream request, failure being nil on success.
+func (e *UpstreamFailureExit) record(failure error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return
+	}
+	if failure == nil {
+		e.failures = 0
+		return
+	}
+
+	// A streak older than the window starts over, the upstream may just fail now and then
+	now := e.now()
+	if e.failures == 0 || now.Sub(e.firstFailure) > e.window {
+		e.failures = 0
+		e.firstFailure = now
+	}
+	e.failures++
+	if e.failures < e.threshold {
+		return
+	}
+
+	e.err = fmt.Errorf("exiting after %d consecutive GitHub API failures within %s, last: %w", e.failures, e.window, failure)
+	logrus.WithFields(logrus.Fields{
+		"consecutive_failures": e.failures,
+		"window":               e.window.String(),
+		"last_error":           failure.Error(),
+	}).Error("GitHub API is persistently unreachable, exiting so that the supervisor restarts the server")
+	close(e.tripped)
+}
+
+// upstreamFailureExitTransport reports transport errors and 5xx responses to an UpstreamFailureExit. It sits
+// above the circuit breaker, so that the requests the breaker short-circuits count as failures. Once the
+// breaker opens only one probe per cooldown reaches the upstream, which would otherwise keep any threshold
+// much above the breaker's from being reached within the window.
+type upstreamFailureExitTransport struct {
+	transport http.RoundTripper
+	exit      *UpstreamFailureExit
+}
+
+func (t *upstreamFailureExitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		// The caller gave up, that says nothing about the health of the upstream
+	case err != nil:
+		t.exit.record(err)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		t.exit.record(fmt.Errorf("%s %s returned %s", req.Method, req.URL.Path, resp.Status))
+	default:
+		t.exit.record(nil)
+	}
+
+	return resp, err
+}
+
+// shutdownOnUpstreamFailure stops httpServer once the upstream failure exit tripped. Like on a signal, the SSE
+// clients are told to reconnect through drain first. The requests in flight then get up to timeout to finish,
+// after which the connections left, such as the SSE streams that never end by themselves, are closed.
+func shutdownOnUpstreamFailure(httpServer *http.Server, drain *shutdownDrain, notify func(method string, params map[string]any), timeout time.Duration) {
+	drain.drain(notify)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logrus.WithError(err).Warn("Requests still open after the shutdown timeout, closing their connections")
+		_ = httpServer.Close()
+	}
+}
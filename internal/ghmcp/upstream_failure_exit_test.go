@@ -0,0 +1,145 @@
+package ghmcp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpstreamFailureExitTransport(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	exit := NewUpstreamFailureExit(3, time.Minute)
+	exit.now = func() time.Time { return now }
+
+	var upstreamErr error
+	status := http.StatusBadGateway
+	transport := &upstreamFailureExitTransport{
+		exit: exit,
+		transport: roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+			if upstreamErr != nil {
+				return nil, upstreamErr
+			}
+			return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: http.NoBody}, nil
+		}),
+	}
+
+	do := func() {
+		req, err := http.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		require.NoError(t, err)
+		_, _ = transport.RoundTrip(req)
+	}
+	tripped := func() bool {
+		select {
+		case <-exit.Tripped():
+			return true
+		default:
+			return false
+		}
+	}
+
+	// A success breaks the streak
+	do()
+	do()
+	status = http.StatusNotFound
+	do()
+	status = http.StatusBadGateway
+	do()
+	do()
+	assert.False(t, tripped())
+
+	// A streak lasting longer than the window starts over
+	now = now.Add(2 * time.Minute)
+	do()
+	do()
+	assert.False(t, tripped())
+
+	// Reaching the threshold within the window trips it
+	upstreamErr = errors.New("connection refused")
+	do()
+	require.True(t, tripped())
+	assert.ErrorContains(t, exit.Err(), "exiting after 3 consecutive GitHub API failures within 1m0s")
+	assert.ErrorContains(t, exit.Err(), "connection refused")
+}
+
+func Test_UpstreamFailureExitWithCircuitBreaker(t *testing.T) {
+	// NewMCPServer builds the upstream transport chain on top of http.DefaultTransport
+	var upstreamCalls atomic.Int32
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+		upstreamCalls.Add(1)
+		return &http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway", Header: http.Header{}, Body: http.NoBody}, nil
+	})
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	// The breaker opens long before the exit threshold, and stays open for the rest of the test
+	exit := NewUpstreamFailureExit(5, time.Minute)
+	ghServer, err := NewMCPServer(MCPServerConfig{
+		Version:             "test",
+		EnabledToolsets:     []string{"repos"},
+		CircuitBreaker:      NewCircuitBreaker(2, time.Hour),
+		UpstreamFailureExit: exit,
+		Translator:          translations.NullTranslationHelper,
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		handleMessage(t, ghServer, `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "get_me"}}`)
+	}
+
+	// Only the calls made before the breaker opened reached GitHub, the short-circuited ones still count
+	assert.Equal(t, int32(2), upstreamCalls.Load())
+	select {
+	case <-exit.Tripped():
+	default:
+		t.Fatal("expected the requests short-circuited by the breaker to count as failures")
+	}
+	assert.ErrorIs(t, exit.Err(), ErrUpstreamUnavailable)
+}
+
+func Test_UpstreamFailureExitDisabled(t *testing.T) {
+	exit := NewUpstreamFailureExit(0, time.Minute)
+	assert.Nil(t, exit)
+
+	select {
+	case <-exit.Tripped():
+		t.Fatal("a disabled upstream failure exit never trips")
+	default:
+	}
+}
+
+func Test_ShutdownOnUpstreamFailure(t *testing.T) {
+	drain := newShutdownDrain(50*time.Millisecond, time.Second)
+	streaming := make(chan struct{})
+	httpServer := httptest.NewUnstartedServer(drain.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Like an SSE stream, the response only ends when the client goes away
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		close(streaming)
+		<-r.Context().Done()
+	})))
+	httpServer.Start()
+	t.Cleanup(httpServer.Close)
+
+	resp, err := httpServer.Client().Get(httpServer.URL + "/sse")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	<-streaming
+
+	var method string
+	start := time.Now()
+	shutdownOnUpstreamFailure(httpServer.Config, drain, func(m string, _ map[string]any) { method = m }, 50*time.Millisecond)
+
+	// The clients are told to reconnect, and the open stream does not hold the exit up
+	assert.Equal(t, ShutdownNotificationMethod, method)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	_, err = io.ReadAll(resp.Body)
+	assert.Error(t, err)
+}
@@ -0,0 +1,699 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalWorkflowRun is the subset of a workflow run needed to follow and manage it.
+type MinimalWorkflowRun struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name,omitempty"`
+	RunNumber  int        `json:"run_number,omitempty"`
+	Event      string     `json:"event,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	Conclusion string     `json:"conclusion,omitempty"`
+	HeadBranch string     `json:"head_branch,omitempty"`
+	HeadSHA    string     `json:"head_sha,omitempty"`
+	WorkflowID int64      `json:"workflow_id,omitempty"`
+	HTMLURL    string     `json:"html_url,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+func newMinimalWorkflowRun(run *github.WorkflowRun) MinimalWorkflowRun {
+	minimal := MinimalWorkflowRun{
+		ID:         run.GetID(),
+		Name:       run.GetName(),
+		RunNumber:  run.GetRunNumber(),
+		Event:      run.GetEvent(),
+		Status:     run.GetStatus(),
+		Conclusion: run.GetConclusion(),
+		HeadBranch: run.GetHeadBranch(),
+		HeadSHA:    run.GetHeadSHA(),
+		WorkflowID: run.GetWorkflowID(),
+		HTMLURL:    run.GetHTMLURL(),
+	}
+	if run.CreatedAt != nil {
+		minimal.CreatedAt = &run.CreatedAt.Time
+	}
+	if run.UpdatedAt != nil {
+		minimal.UpdatedAt = &run.UpdatedAt.Time
+	}
+	return minimal
+}
+
+// ListWorkflowRuns creates a tool to list the workflow runs of a repository.
+func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_workflow_runs",
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_RUNS_DESCRIPTION", "List GitHub Actions workflow runs of a repository, most recent first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("status",
+				mcp.Description("Only return runs with this status or conclusion"),
+				mcp.Enum("queued", "in_progress", "requested", "waiting", "pending", "completed",
+					"success", "failure", "cancelled", "skipped", "timed_out", "action_required", "neutral", "stale"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Only return runs for this branch"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			status, err := OptionalParam[string](request, "status")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListWorkflowRunsOptions{
+				Status: status,
+				Branch: branch,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			runs, resp, err := client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list workflow runs: %s", string(body))), nil
+			}
+
+			minimalRuns := make([]MinimalWorkflowRun, 0, len(runs.WorkflowRuns))
+			for _, run := range runs.WorkflowRuns {
+				minimalRuns = append(minimalRuns, newMinimalWorkflowRun(run))
+			}
+
+			return MarshalledListResult(minimalRuns, resp, runs.TotalCount), nil
+		}
+}
+
+// CancelWorkflowRun creates a tool to cancel an in-progress workflow run.
+func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("cancel_workflow_run",
+			mcp.WithDescription(t("TOOL_CANCEL_WORKFLOW_RUN_DESCRIPTION", "Cancel an in-progress GitHub Actions workflow run and return its status after the cancellation was requested. Cancellation is asynchronous, so the run may still be in progress for a moment.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CANCEL_WORKFLOW_RUN_USER_TITLE", "Cancel workflow run"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("ID of the workflow run to cancel"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub accepts the cancellation with a 202, which go-github reports as an AcceptedError
+			resp, err := client.Actions.CancelWorkflowRunByID(ctx, owner, repo, int64(runID))
+			if err != nil && !isAcceptedError(err) {
+				return nil, fmt.Errorf("failed to cancel workflow run: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to cancel workflow run: %s", string(body))), nil
+			}
+
+			return workflowRunResult(ctx, client, owner, repo, int64(runID))
+		}
+}
+
+// workflowRunResult gets a workflow run, for the tools that return the status of the run they acted on
+func workflowRunResult(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, error) {
+	run, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow run: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to get workflow run: %s", string(body))), nil
+	}
+
+	r, err := json.Marshal(newMinimalWorkflowRun(run))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(r)), nil
+}
+
+// MinimalWorkflowJob is the subset of a workflow job needed to tell which jobs of a run failed.
+type MinimalWorkflowJob struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Conclusion  string     `json:"conclusion,omitempty"`
+	RunAttempt  int64      `json:"run_attempt,omitempty"`
+	HTMLURL     string     `json:"html_url,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// FailedSteps are the names of the steps that failed, to look for in the job logs
+	FailedSteps []string `json:"failed_steps,omitempty"`
+}
+
+func newMinimalWorkflowJob(job *github.WorkflowJob) MinimalWorkflowJob {
+	minimal := MinimalWorkflowJob{
+		ID:         job.GetID(),
+		Name:       job.GetName(),
+		Status:     job.GetStatus(),
+		Conclusion: job.GetConclusion(),
+		RunAttempt: job.GetRunAttempt(),
+		HTMLURL:    job.GetHTMLURL(),
+	}
+	if job.StartedAt != nil {
+		minimal.StartedAt = &job.StartedAt.Time
+	}
+	if job.CompletedAt != nil {
+		minimal.CompletedAt = &job.CompletedAt.Time
+	}
+	for _, step := range job.Steps {
+		if step.GetConclusion() == "failure" {
+			minimal.FailedSteps = append(minimal.FailedSteps, step.GetName())
+		}
+	}
+	return minimal
+}
+
+// ListWorkflowJobs creates a tool to list the jobs of a workflow run.
+func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_workflow_jobs",
+			mcp.WithDescription(t("TOOL_LIST_WORKFLOW_JOBS_DESCRIPTION", "List the jobs of a GitHub Actions workflow run with the status and conclusion of each, and the names of their failed steps")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WORKFLOW_JOBS_USER_TITLE", "List workflow jobs"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("ID of the workflow run"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("'latest' for the jobs of the latest attempt of the run, 'all' for the jobs of every attempt. Defaults to 'latest'"),
+				mcp.Enum("latest", "all"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListWorkflowJobsOptions{
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, int64(runID), opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list workflow jobs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list workflow jobs: %s", string(body))), nil
+			}
+
+			minimalJobs := make([]MinimalWorkflowJob, 0, len(jobs.Jobs))
+			for _, job := range jobs.Jobs {
+				minimalJobs = append(minimalJobs, newMinimalWorkflowJob(job))
+			}
+
+			return MarshalledListResult(minimalJobs, resp, jobs.TotalCount), nil
+		}
+}
+
+// RerunFailedJobs creates a tool to re-run the failed jobs of a workflow run.
+func RerunFailedJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerun_failed_jobs",
+			mcp.WithDescription(t("TOOL_RERUN_FAILED_JOBS_DESCRIPTION", "Re-run the failed jobs of a completed GitHub Actions workflow run, along with the jobs depending on them, in a new attempt of the run. Returns the status of the run after the re-run was requested")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_RERUN_FAILED_JOBS_USER_TITLE", "Re-run failed workflow jobs"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Required(),
+				mcp.Description("ID of the workflow run whose failed jobs to re-run"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := RequiredInt(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.RerunFailedJobsByID(ctx, owner, repo, int64(runID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to re-run failed jobs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to re-run failed jobs: %s", string(body))), nil
+			}
+
+			return workflowRunResult(ctx, client, owner, repo, int64(runID))
+		}
+}
+
+// variableNamePattern matches the names GitHub accepts for Actions variables and secrets. Names are
+// also not allowed to start with the GITHUB_ prefix, which is checked separately.
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateVariableName rejects names the Actions variables API would refuse
+func validateVariableName(name string) error {
+	return validateActionsName("variable", name)
+}
+
+// validateSecretName rejects names the Actions secrets API would refuse
+func validateSecretName(name string) error {
+	return validateActionsName("secret", name)
+}
+
+// validateActionsName rejects names of Actions variables and secrets, told apart by kind, that GitHub would refuse
+func validateActionsName(kind, name string) error {
+	if !variableNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid %s name %q: names may only contain letters, digits and underscores, and must not start with a digit", kind, name)
+	}
+	if strings.HasPrefix(strings.ToUpper(name), "GITHUB_") {
+		return fmt.Errorf("invalid %s name %q: names must not start with the GITHUB_ prefix", kind, name)
+	}
+	return nil
+}
+
+// ListRepoVariables creates a tool to list the Actions variables of a repository.
+func ListRepoVariables(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_repo_variables",
+			mcp.WithDescription(t("TOOL_LIST_REPO_VARIABLES_DESCRIPTION", "List the GitHub Actions variables of a repository, including their values")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REPO_VARIABLES_USER_TITLE", "List repository variables"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			variables, resp, err := client.Actions.ListRepoVariables(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository variables: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list repository variables: %s", string(body))), nil
+			}
+
+			return MarshalledListResult(variables.Variables, resp, &variables.TotalCount), nil
+		}
+}
+
+// GetRepoVariable creates a tool to get an Actions variable of a repository.
+func GetRepoVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_variable",
+			mcp.WithDescription(t("TOOL_GET_REPO_VARIABLE_DESCRIPTION", "Get a GitHub Actions variable of a repository, including its value")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_VARIABLE_USER_TITLE", "Get repository variable"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateVariableName(name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			variable, resp, err := client.Actions.GetRepoVariable(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository variable: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository variable: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(variable)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// SetRepoVariable creates a tool to create or update an Actions variable of a repository.
+func SetRepoVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repo_variable",
+			mcp.WithDescription(t("TOOL_SET_REPO_VARIABLE_DESCRIPTION", "Create a GitHub Actions variable in a repository, or update its value if it already exists")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_REPO_VARIABLE_USER_TITLE", "Set repository variable"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable, letters, digits and underscores only"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("Value of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateVariableName(name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := requiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variable := &github.ActionsVariable{Name: name, Value: value}
+
+			// Update first, and only create the variable when it does not exist yet
+			created := false
+			resp, err := client.Actions.UpdateRepoVariable(ctx, owner, repo, variable)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				created = true
+				resp, err = client.Actions.CreateRepoVariable(ctx, owner, repo, variable)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to set repository variable: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			expectedStatus := http.StatusNoContent
+			if created {
+				expectedStatus = http.StatusCreated
+			}
+			if resp.StatusCode != expectedStatus {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set repository variable: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"name":    name,
+				"value":   value,
+				"created": created,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// DeleteRepoVariable creates a tool to delete an Actions variable of a repository.
+func DeleteRepoVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_repo_variable",
+			mcp.WithDescription(t("TOOL_DELETE_REPO_VARIABLE_DESCRIPTION", "Delete a GitHub Actions variable of a repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_REPO_VARIABLE_USER_TITLE", "Delete repository variable"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateVariableName(name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Actions.DeleteRepoVariable(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete repository variable: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete repository variable: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("variable %s deleted", name)), nil
+		}
+}
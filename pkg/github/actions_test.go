@@ -0,0 +1,861 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListWorkflowRuns(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowRuns(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_workflow_runs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "status")
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRuns := &github.WorkflowRuns{
+		TotalCount: github.Ptr(1),
+		WorkflowRuns: []*github.WorkflowRun{
+			{
+				ID:         github.Ptr(int64(30433642)),
+				Name:       github.Ptr("Build"),
+				Status:     github.Ptr("in_progress"),
+				HeadBranch: github.Ptr("main"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRuns   []MinimalWorkflowRun
+		expectedErrMsg string
+	}{
+		{
+			name: "list in progress runs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"status":   "in_progress",
+						"branch":   "main",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRuns),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"status":  "in_progress",
+				"branch":  "main",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectError: false,
+			expectedRuns: []MinimalWorkflowRun{
+				{ID: 30433642, Name: "Build", Status: "in_progress", HeadBranch: "main"},
+			},
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow runs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowRuns(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedRuns []MinimalWorkflowRun
+			pagination := getListResult(t, textContent.Text, &returnedRuns)
+			require.NotNil(t, pagination.TotalCount)
+			assert.Equal(t, len(tc.expectedRuns), *pagination.TotalCount)
+			assert.Equal(t, tc.expectedRuns, returnedRuns)
+		})
+	}
+}
+
+func Test_CancelWorkflowRun(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CancelWorkflowRun(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "cancel_workflow_run", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockRun := &github.WorkflowRun{
+		ID:     github.Ptr(int64(30433642)),
+		Name:   github.Ptr("Build"),
+		Status: github.Ptr("cancelling"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedRun    MinimalWorkflowRun
+		expectedErrMsg string
+	}{
+		{
+			name: "successful cancellation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsCancelByOwnerByRepoByRunId,
+					expectPath(t, "/repos/owner/repo/actions/runs/30433642/cancel").andThen(
+						mockResponse(t, http.StatusAccepted, map[string]any{}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					mockRun,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(30433642),
+			},
+			expectError: false,
+			expectedRun: MinimalWorkflowRun{ID: 30433642, Name: "Build", Status: "cancelling"},
+		},
+		{
+			name: "run cannot be cancelled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsCancelByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Cannot cancel a workflow run that is completed."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(30433642),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to cancel workflow run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CancelWorkflowRun(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedRun MinimalWorkflowRun
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRun)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRun, returnedRun)
+		})
+	}
+}
+
+func Test_ListWorkflowJobs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWorkflowJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_workflow_jobs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	mockJobs := &github.Jobs{
+		TotalCount: github.Ptr(2),
+		Jobs: []*github.WorkflowJob{
+			{
+				ID:         github.Ptr(int64(399444496)),
+				Name:       github.Ptr("build"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("success"),
+				RunAttempt: github.Ptr(int64(1)),
+			},
+			{
+				ID:         github.Ptr(int64(399444497)),
+				Name:       github.Ptr("test"),
+				Status:     github.Ptr("completed"),
+				Conclusion: github.Ptr("failure"),
+				RunAttempt: github.Ptr(int64(1)),
+				Steps: []*github.TaskStep{
+					{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+					{Name: github.Ptr("Run tests"), Conclusion: github.Ptr("failure")},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedJobs   []MinimalWorkflowJob
+		expectedErrMsg string
+	}{
+		{
+			name: "list jobs of every attempt",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					expectPath(t, "/repos/owner/repo/actions/runs/30433642/jobs").andThen(
+						expectQueryParams(t, map[string]string{
+							"filter":   "all",
+							"page":     "1",
+							"per_page": "30",
+						}).andThen(
+							mockResponse(t, http.StatusOK, mockJobs),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(30433642),
+				"filter": "all",
+			},
+			expectedJobs: []MinimalWorkflowJob{
+				{ID: 399444496, Name: "build", Status: "completed", Conclusion: "success", RunAttempt: 1},
+				{ID: 399444497, Name: "test", Status: "completed", Conclusion: "failure", RunAttempt: 1, FailedSteps: []string{"Run tests"}},
+			},
+		},
+		{
+			name: "run not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsJobsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(1),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list workflow jobs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListWorkflowJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			var returnedJobs []MinimalWorkflowJob
+			pagination := getListResult(t, getTextResult(t, result).Text, &returnedJobs)
+			require.NotNil(t, pagination.TotalCount)
+			assert.Equal(t, 2, *pagination.TotalCount)
+			assert.Equal(t, tc.expectedJobs, returnedJobs)
+		})
+	}
+}
+
+func Test_RerunFailedJobs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerunFailedJobs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerun_failed_jobs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "run_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedRun    MinimalWorkflowRun
+		expectedErrMsg string
+	}{
+		{
+			name: "failed jobs re-run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunId,
+					expectPath(t, "/repos/owner/repo/actions/runs/30433642/rerun-failed-jobs").andThen(
+						mockResponse(t, http.StatusCreated, map[string]any{}),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposActionsRunsByOwnerByRepoByRunId,
+					&github.WorkflowRun{
+						ID:     github.Ptr(int64(30433642)),
+						Name:   github.Ptr("Build"),
+						Status: github.Ptr("queued"),
+					},
+				),
+			),
+			expectedRun: MinimalWorkflowRun{ID: 30433642, Name: "Build", Status: "queued"},
+		},
+		{
+			name: "run still in progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "This workflow is already running"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to re-run failed jobs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RerunFailedJobs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(30433642),
+			}))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			var returnedRun MinimalWorkflowRun
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedRun))
+			assert.Equal(t, tc.expectedRun, returnedRun)
+		})
+	}
+}
+
+func Test_ListRepoVariables(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRepoVariables(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_repo_variables", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockVariables := &github.ActionsVariables{
+		TotalCount: 1,
+		Variables: []*github.ActionsVariable{
+			{Name: "USERNAME", Value: "octocat"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectedVariables *github.ActionsVariables
+		expectedErrMsg    string
+	}{
+		{
+			name: "successful variables list",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsVariablesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockVariables),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:       false,
+			expectedVariables: mockVariables,
+		},
+		{
+			name: "variables list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsVariablesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list repository variables",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRepoVariables(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedVariables []*github.ActionsVariable
+			pagination := getListResult(t, textContent.Text, &returnedVariables)
+			assert.Equal(t, tc.expectedVariables.Variables, returnedVariables)
+			assert.Equal(t, &tc.expectedVariables.TotalCount, pagination.TotalCount)
+		})
+	}
+}
+
+func Test_GetRepoVariable(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repo_variable", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	mockVariable := &github.ActionsVariable{Name: "USERNAME", Value: "octocat"}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectToolError  bool
+		expectedVariable *github.ActionsVariable
+		expectedErrMsg   string
+	}{
+		{
+			name: "successful variable fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsVariablesByOwnerByRepoByName,
+					expectPath(t, "/repos/owner/repo/actions/variables/USERNAME").andThen(
+						mockResponse(t, http.StatusOK, mockVariable),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "USERNAME",
+			},
+			expectError:      false,
+			expectedVariable: mockVariable,
+		},
+		{
+			name:         "invalid variable name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "../secrets",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid variable name",
+		},
+		{
+			name: "variable not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsVariablesByOwnerByRepoByName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "MISSING",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get repository variable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepoVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedVariable github.ActionsVariable
+			err = json.Unmarshal([]byte(textContent.Text), &returnedVariable)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedVariable, returnedVariable)
+		})
+	}
+}
+
+func Test_SetRepoVariable(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepoVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_repo_variable", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "value")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "value"})
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedCreated bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "update existing variable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposActionsVariablesByOwnerByRepoByName,
+					expectRequestBody(t, map[string]interface{}{
+						"name":  "USERNAME",
+						"value": "hubot",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "USERNAME",
+				"value": "hubot",
+			},
+			expectedCreated: false,
+		},
+		{
+			name: "create missing variable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposActionsVariablesByOwnerByRepoByName,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsVariablesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":  "USERNAME",
+						"value": "hubot",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]any{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "USERNAME",
+				"value": "hubot",
+			},
+			expectedCreated: true,
+		},
+		{
+			name:         "reserved variable name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "github_token",
+				"value": "x",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "GITHUB_ prefix",
+		},
+		{
+			name: "create fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposActionsVariablesByOwnerByRepoByName,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposActionsVariablesByOwnerByRepo,
+					notFound,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+				"name":  "USERNAME",
+				"value": "hubot",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set repository variable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetRepoVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				Name    string `json:"name"`
+				Value   string `json:"value"`
+				Created bool   `json:"created"`
+			}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.requestArgs["name"], returned.Name)
+			assert.Equal(t, tc.requestArgs["value"], returned.Value)
+			assert.Equal(t, tc.expectedCreated, returned.Created)
+		})
+	}
+}
+
+func Test_DeleteRepoVariable(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteRepoVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_repo_variable", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful delete",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsVariablesByOwnerByRepoByName,
+					expectPath(t, "/repos/owner/repo/actions/variables/USERNAME").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "USERNAME",
+			},
+		},
+		{
+			name: "delete fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposActionsVariablesByOwnerByRepoByName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "MISSING",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to delete repository variable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteRepoVariable(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, "variable USERNAME deleted", textContent.Text)
+		})
+	}
+}
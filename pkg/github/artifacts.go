@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxInlineArtifactSize is the largest artifact download_artifact returns the contents of. Larger
+// artifacts are returned as a download URL instead.
+const maxInlineArtifactSize = 1 << 20
+
+// MinimalArtifact is the subset of an Actions artifact needed to find and download it.
+type MinimalArtifact struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	SizeInBytes   int64      `json:"size_in_bytes"`
+	Expired       bool       `json:"expired"`
+	WorkflowRunID int64      `json:"workflow_run_id,omitempty"`
+	HeadBranch    string     `json:"head_branch,omitempty"`
+	HeadSHA       string     `json:"head_sha,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+}
+
+func newMinimalArtifact(artifact *github.Artifact) MinimalArtifact {
+	minimal := MinimalArtifact{
+		ID:            artifact.GetID(),
+		Name:          artifact.GetName(),
+		SizeInBytes:   artifact.GetSizeInBytes(),
+		Expired:       artifact.GetExpired(),
+		WorkflowRunID: artifact.GetWorkflowRun().GetID(),
+		HeadBranch:    artifact.GetWorkflowRun().GetHeadBranch(),
+		HeadSHA:       artifact.GetWorkflowRun().GetHeadSHA(),
+	}
+	if artifact.CreatedAt != nil {
+		minimal.CreatedAt = &artifact.CreatedAt.Time
+	}
+	if artifact.ExpiresAt != nil {
+		minimal.ExpiresAt = &artifact.ExpiresAt.Time
+	}
+	return minimal
+}
+
+// artifactExpiredResult is the tool error for an artifact that is past its retention period
+func artifactExpiredResult(artifact MinimalArtifact) *mcp.CallToolResult {
+	if artifact.ExpiresAt != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("artifact %s (%d) expired on %s and can no longer be downloaded",
+			artifact.Name, artifact.ID, artifact.ExpiresAt.Format(time.RFC3339)))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("artifact %s (%d) has expired and can no longer be downloaded", artifact.Name, artifact.ID))
+}
+
+// ListArtifacts creates a tool to list the Actions artifacts of a repository or of one workflow run.
+func ListArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_artifacts",
+			mcp.WithDescription(t("TOOL_LIST_ARTIFACTS_DESCRIPTION", "List GitHub Actions artifacts of a repository, or of a single workflow run, most recent first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ARTIFACTS_USER_TITLE", "List artifacts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("run_id",
+				mcp.Description("Only list the artifacts of this workflow run"),
+			),
+			mcp.WithString("name",
+				mcp.Description("Only list artifacts with this exact name. Not supported together with run_id"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			runID, err := OptionalIntParam(request, "run_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := OptionalParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if runID != 0 && name != "" {
+				return mcp.NewToolResultError("name cannot be combined with run_id"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			listOptions := github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var artifacts *github.ArtifactList
+			var resp *github.Response
+			if runID != 0 {
+				artifacts, resp, err = client.Actions.ListWorkflowRunArtifacts(ctx, owner, repo, int64(runID), &listOptions)
+			} else {
+				opts := &github.ListArtifactsOptions{ListOptions: listOptions}
+				if name != "" {
+					opts.Name = github.Ptr(name)
+				}
+				artifacts, resp, err = client.Actions.ListArtifacts(ctx, owner, repo, opts)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list artifacts: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list artifacts: %s", string(body))), nil
+			}
+
+			minimalArtifacts := make([]MinimalArtifact, 0, len(artifacts.Artifacts))
+			for _, artifact := range artifacts.Artifacts {
+				minimalArtifacts = append(minimalArtifacts, newMinimalArtifact(artifact))
+			}
+
+			var totalCount *int
+			if artifacts.TotalCount != nil {
+				totalCount = github.Ptr(int(*artifacts.TotalCount))
+			}
+			return MarshalledListResult(minimalArtifacts, resp, totalCount), nil
+		}
+}
+
+// DownloadArtifact creates a tool to download an Actions artifact, returning small artifacts inline.
+func DownloadArtifact(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("download_artifact",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_ARTIFACT_DESCRIPTION", fmt.Sprintf("Download a GitHub Actions artifact. Artifacts up to %d bytes are returned as a base64 encoded zip archive, larger ones as a short-lived download URL", maxInlineArtifactSize))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_ARTIFACT_USER_TITLE", "Download artifact"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("artifact_id",
+				mcp.Required(),
+				mcp.Description("Artifact ID, as returned by list_artifacts"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			artifactID, err := RequiredInt(request, "artifact_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			found, resp, err := client.Actions.GetArtifact(ctx, owner, repo, int64(artifactID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get artifact: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			artifact := newMinimalArtifact(found)
+			if artifact.Expired {
+				return artifactExpiredResult(artifact), nil
+			}
+
+			// GitHub answers with a redirect to a signed URL that is valid for a minute
+			downloadURL, resp, err := client.Actions.DownloadArtifact(ctx, owner, repo, int64(artifactID), 1)
+			if resp != nil && resp.StatusCode == http.StatusGone {
+				return artifactExpiredResult(artifact), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get artifact download URL: %w", err)
+			}
+
+			if artifact.SizeInBytes > maxInlineArtifactSize {
+				return MarshalledTextResult(map[string]any{
+					"artifact":     artifact,
+					"download_url": downloadURL.String(),
+					"message":      fmt.Sprintf("The artifact is larger than %d bytes. Download it from download_url, which expires after about a minute", maxInlineArtifactSize),
+				}), nil
+			}
+
+			// Artifacts are zipped on upload, so their size is known and the cap only guards against surprises
+			content, err := downloadCapped(ctx, downloadURL.String(), maxInlineArtifactSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download artifact: %w", err)
+			}
+			if content == nil {
+				return nil, fmt.Errorf("failed to download artifact: archive is larger than %d bytes", maxInlineArtifactSize)
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"artifact":       artifact,
+				"content_base64": base64.StdEncoding.EncodeToString(content),
+			}), nil
+		}
+}
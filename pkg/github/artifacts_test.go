@@ -0,0 +1,338 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListArtifacts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListArtifacts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_artifacts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "run_id")
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	mockArtifacts := &github.ArtifactList{
+		TotalCount: github.Ptr(int64(1)),
+		Artifacts: []*github.Artifact{
+			{
+				ID:          github.Ptr(int64(11)),
+				Name:        github.Ptr("coverage"),
+				SizeInBytes: github.Ptr(int64(2048)),
+				Expired:     github.Ptr(false),
+				WorkflowRun: &github.ArtifactWorkflowRun{
+					ID:         github.Ptr(int64(42)),
+					HeadBranch: github.Ptr("main"),
+					HeadSHA:    github.Ptr("abc123"),
+				},
+			},
+		},
+	}
+	expectedArtifacts := []MinimalArtifact{
+		{
+			ID:            11,
+			Name:          "coverage",
+			SizeInBytes:   2048,
+			WorkflowRunID: 42,
+			HeadBranch:    "main",
+			HeadSHA:       "abc123",
+		},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectToolError   bool
+		expectedArtifacts []MinimalArtifact
+		expectedErrMsg    string
+	}{
+		{
+			name: "list repository artifacts by name",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsArtifactsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"name":     "coverage",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockArtifacts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"name":    "coverage",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedArtifacts: expectedArtifacts,
+		},
+		{
+			name: "list artifacts of a workflow run",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsRunsArtifactsByOwnerByRepoByRunId,
+					expectPath(t, "/repos/owner/repo/actions/runs/42/artifacts").andThen(
+						mockResponse(t, http.StatusOK, mockArtifacts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(42),
+			},
+			expectedArtifacts: expectedArtifacts,
+		},
+		{
+			name:         "name with run_id",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"run_id": float64(42),
+				"name":   "coverage",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "name cannot be combined with run_id",
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsArtifactsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list artifacts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListArtifacts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedArtifacts []MinimalArtifact
+			pagination := getListResult(t, textContent.Text, &returnedArtifacts)
+			assert.Equal(t, tc.expectedArtifacts, returnedArtifacts)
+			require.NotNil(t, pagination.TotalCount)
+			assert.Equal(t, 1, *pagination.TotalCount)
+		})
+	}
+}
+
+func Test_DownloadArtifact(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadArtifact(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "download_artifact", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "artifact_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "artifact_id"})
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+
+	archive := []byte("PK\x03\x04 zip archive")
+	blobStorage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The signed URL must not be sent the GitHub token
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write(archive)
+	}))
+	defer blobStorage.Close()
+
+	redirect := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Location", blobStorage.URL+"/artifact.zip?sig=signed")
+		w.WriteHeader(http.StatusFound)
+	}
+	artifact := func(size int64, expired bool) *github.Artifact {
+		return &github.Artifact{
+			ID:          github.Ptr(int64(11)),
+			Name:        github.Ptr("coverage"),
+			SizeInBytes: github.Ptr(size),
+			Expired:     github.Ptr(expired),
+			ExpiresAt:   &github.Timestamp{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedContent []byte
+		expectedURL     string
+	}{
+		{
+			name: "small artifact is returned inline",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactId,
+					artifact(int64(len(archive)), false),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactIdByArchiveFormat,
+					http.HandlerFunc(redirect),
+				),
+			),
+			expectedContent: archive,
+		},
+		{
+			name: "large artifact is returned as a URL",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactId,
+					artifact(maxInlineArtifactSize+1, false),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactIdByArchiveFormat,
+					http.HandlerFunc(redirect),
+				),
+			),
+			expectedURL: blobStorage.URL + "/artifact.zip?sig=signed",
+		},
+		{
+			name: "expired artifact",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactId,
+					artifact(int64(len(archive)), true),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "expired on 2024-05-01T00:00:00Z",
+		},
+		{
+			name: "archive gone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactId,
+					artifact(int64(len(archive)), false),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactIdByArchiveFormat,
+					mockResponse(t, http.StatusGone, `{"message": "Artifact has expired"}`),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "can no longer be downloaded",
+		},
+		{
+			name: "artifact not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposActionsArtifactsByOwnerByRepoByArtifactId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get artifact",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DownloadArtifact(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"artifact_id": float64(11),
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Artifact      MinimalArtifact `json:"artifact"`
+				ContentBase64 string          `json:"content_base64"`
+				DownloadURL   string          `json:"download_url"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, int64(11), response.Artifact.ID)
+			assert.Equal(t, tc.expectedURL, response.DownloadURL)
+			if tc.expectedContent != nil {
+				content, err := base64.StdEncoding.DecodeString(response.ContentBase64)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedContent, content)
+			} else {
+				assert.Empty(t, response.ContentBase64)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AutomatedSecurityFixes is the state of the Dependabot security updates of a repository.
+type AutomatedSecurityFixes struct {
+	Enabled bool `json:"enabled"`
+	// Paused is set when GitHub stopped opening security update pull requests, such as for an inactive repository
+	Paused bool `json:"paused"`
+}
+
+// automatedSecurityFixesErrorResult explains the 403 GitHub returns to tokens without admin access to a repository,
+// and the 404 it returns when Dependabot alerts, which security updates depend on, are disabled. It returns nil
+// for any other error.
+func automatedSecurityFixesErrorResult(err error, owner, repo string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return nil
+	}
+	switch ghErr.Response.StatusCode {
+	case http.StatusForbidden:
+		return mcp.NewToolResultError(fmt.Sprintf("Dependabot security updates of %s/%s can only be managed by a repository admin, the token in use lacks admin access: %s", owner, repo, ghErr.Message))
+	case http.StatusNotFound:
+		return mcp.NewToolResultError(fmt.Sprintf("Dependabot alerts are disabled for %s/%s, an admin has to enable them under Settings > Code security before security updates, or the repository does not exist: %s", owner, repo, ghErr.Message))
+	default:
+		return nil
+	}
+}
+
+// getAutomatedSecurityFixes gets the state of the Dependabot security updates of a repository, returning a tool
+// error result for the failures a caller can act on
+func getAutomatedSecurityFixes(ctx context.Context, client *github.Client, owner, repo string) (*AutomatedSecurityFixes, *mcp.CallToolResult, error) {
+	fixes, resp, err := client.Repositories.GetAutomatedSecurityFixes(ctx, owner, repo)
+	if result := automatedSecurityFixesErrorResult(err, owner, repo); result != nil {
+		return nil, result, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get automated security fixes: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	return &AutomatedSecurityFixes{
+		Enabled: fixes.GetEnabled(),
+		Paused:  fixes.GetPaused(),
+	}, nil, nil
+}
+
+// GetAutomatedSecurityFixes creates a tool to check whether Dependabot security updates are enabled for a repository.
+func GetAutomatedSecurityFixes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_automated_security_fixes",
+			mcp.WithDescription(t("TOOL_GET_AUTOMATED_SECURITY_FIXES_DESCRIPTION", "Check whether Dependabot security updates, the pull requests Dependabot opens to fix vulnerable dependencies, are enabled for a repository, and whether they are paused. Requires admin access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_AUTOMATED_SECURITY_FIXES_USER_TITLE", "Get Dependabot security updates"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			fixes, result, err := getAutomatedSecurityFixes(ctx, client, owner, repo)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			return MarshalledTextResult(fixes), nil
+		}
+}
+
+// toggleAutomatedSecurityFixes enables or disables the Dependabot security updates of the repository of a tool
+// call, and returns their resulting state
+func toggleAutomatedSecurityFixes(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, enable bool) (*mcp.CallToolResult, error) {
+	owner, err := requiredParam[string](request, "owner")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	repo, err := requiredParam[string](request, "repo")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	action := "disable"
+	toggle := client.Repositories.DisableAutomatedSecurityFixes
+	if enable {
+		action = "enable"
+		toggle = client.Repositories.EnableAutomatedSecurityFixes
+	}
+	resp, err := toggle(ctx, owner, repo)
+	if result := automatedSecurityFixesErrorResult(err, owner, repo); result != nil {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s automated security fixes: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s automated security fixes: %s", action, string(body))), nil
+	}
+
+	fixes, result, err := getAutomatedSecurityFixes(ctx, client, owner, repo)
+	if result != nil || err != nil {
+		return result, err
+	}
+
+	return MarshalledTextResult(fixes), nil
+}
+
+// EnableAutomatedSecurityFixes creates a tool to enable Dependabot security updates for a repository.
+func EnableAutomatedSecurityFixes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_automated_security_fixes",
+			mcp.WithDescription(t("TOOL_ENABLE_AUTOMATED_SECURITY_FIXES_DESCRIPTION", "Enable Dependabot security updates for a repository, so that Dependabot opens pull requests fixing vulnerable dependencies. Dependabot alerts must be enabled. Returns the resulting state")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ENABLE_AUTOMATED_SECURITY_FIXES_USER_TITLE", "Enable Dependabot security updates"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return toggleAutomatedSecurityFixes(ctx, getClient, request, true)
+		}
+}
+
+// DisableAutomatedSecurityFixes creates a tool to disable Dependabot security updates for a repository.
+func DisableAutomatedSecurityFixes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_automated_security_fixes",
+			mcp.WithDescription(t("TOOL_DISABLE_AUTOMATED_SECURITY_FIXES_DESCRIPTION", "Disable Dependabot security updates for a repository, Dependabot alerts stay enabled. Returns the resulting state")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_DISABLE_AUTOMATED_SECURITY_FIXES_USER_TITLE", "Disable Dependabot security updates"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return toggleAutomatedSecurityFixes(ctx, getClient, request, false)
+		}
+}
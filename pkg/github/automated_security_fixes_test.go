@@ -0,0 +1,175 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetAutomatedSecurityFixes(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetAutomatedSecurityFixes(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_automated_security_fixes", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+		expected        AutomatedSecurityFixes
+	}{
+		{
+			name: "security updates enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/automated-security-fixes").andThen(
+						mockResponse(t, http.StatusOK, &github.AutomatedSecurityFixes{
+							Enabled: github.Ptr(true),
+							Paused:  github.Ptr(false),
+						}),
+					),
+				),
+			),
+			expected: AutomatedSecurityFixes{Enabled: true},
+		},
+		{
+			name: "token without admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "can only be managed by a repository admin",
+		},
+		{
+			name: "Dependabot alerts disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "Dependabot alerts are disabled for owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetAutomatedSecurityFixes(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var fixes AutomatedSecurityFixes
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &fixes))
+			assert.Equal(t, tc.expected, fixes)
+		})
+	}
+}
+
+func Test_ToggleAutomatedSecurityFixes(t *testing.T) {
+	// Verify tool definitions once
+	mockClient := github.NewClient(nil)
+	enableTool, _ := EnableAutomatedSecurityFixes(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	disableTool, _ := DisableAutomatedSecurityFixes(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "enable_automated_security_fixes", enableTool.Name)
+	assert.NotEmpty(t, enableTool.Description)
+	assert.False(t, *enableTool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, enableTool.InputSchema.Required, []string{"owner", "repo"})
+	assert.Equal(t, "disable_automated_security_fixes", disableTool.Name)
+	assert.NotEmpty(t, disableTool.Description)
+	assert.False(t, *disableTool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, disableTool.InputSchema.Required, []string{"owner", "repo"})
+
+	// newClient mocks a repository whose security updates are toggled by the PUT and DELETE endpoints,
+	// which answer with toggleStatus
+	newClient := func(t *testing.T, toggleStatus int) *http.Client {
+		enabled := false
+		toggle := func(value bool) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if toggleStatus != http.StatusNoContent {
+					mockResponse(t, toggleStatus, map[string]string{"message": "Must have admin rights to Repository."})(w, r)
+					return
+				}
+				enabled = value
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposAutomatedSecurityFixesByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mockResponse(t, http.StatusOK, &github.AutomatedSecurityFixes{
+						Enabled: github.Ptr(enabled),
+						Paused:  github.Ptr(false),
+					})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(mock.PutReposAutomatedSecurityFixesByOwnerByRepo, toggle(true)),
+			mock.WithRequestMatchHandler(mock.DeleteReposAutomatedSecurityFixesByOwnerByRepo, toggle(false)),
+		)
+	}
+	args := map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}
+
+	t.Run("enable then disable", func(t *testing.T) {
+		client := github.NewClient(newClient(t, http.StatusNoContent))
+		_, enable := EnableAutomatedSecurityFixes(stubGetClientFn(client), translations.NullTranslationHelper)
+		_, disable := DisableAutomatedSecurityFixes(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		for _, step := range []struct {
+			handler  func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+			expected AutomatedSecurityFixes
+		}{
+			{enable, AutomatedSecurityFixes{Enabled: true}},
+			{disable, AutomatedSecurityFixes{Enabled: false}},
+		} {
+			result, err := step.handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var fixes AutomatedSecurityFixes
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &fixes))
+			assert.Equal(t, step.expected, fixes)
+		}
+	})
+
+	t.Run("token without admin access", func(t *testing.T) {
+		client := github.NewClient(newClient(t, http.StatusForbidden))
+		_, enable := EnableAutomatedSecurityFixes(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := enable(context.Background(), createMCPRequest(args))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "can only be managed by a repository admin")
+	})
+}
@@ -0,0 +1,231 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalCheckSuite is the subset of a check suite needed to gate on it.
+type MinimalCheckSuite struct {
+	ID         int64      `json:"id"`
+	App        string     `json:"app,omitempty"`
+	AppID      int64      `json:"app_id,omitempty"`
+	Status     string     `json:"status,omitempty"`
+	Conclusion string     `json:"conclusion,omitempty"`
+	HeadBranch string     `json:"head_branch,omitempty"`
+	HeadSHA    string     `json:"head_sha,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+func newMinimalCheckSuite(suite *github.CheckSuite) MinimalCheckSuite {
+	minimal := MinimalCheckSuite{
+		ID:         suite.GetID(),
+		App:        suite.GetApp().GetSlug(),
+		AppID:      suite.GetApp().GetID(),
+		Status:     suite.GetStatus(),
+		Conclusion: suite.GetConclusion(),
+		HeadBranch: suite.GetHeadBranch(),
+		HeadSHA:    suite.GetHeadSHA(),
+	}
+	if suite.CreatedAt != nil {
+		minimal.CreatedAt = &suite.CreatedAt.Time
+	}
+	if suite.UpdatedAt != nil {
+		minimal.UpdatedAt = &suite.UpdatedAt.Time
+	}
+	return minimal
+}
+
+// CheckSuitesResult lists the check suites of a commit along with their rollup.
+type CheckSuitesResult struct {
+	// State is failure when any suite concluded other than success, neutral or skipped, pending when
+	// any other suite has not completed, and success otherwise, including when there are no suites
+	State       string              `json:"state"`
+	TotalCount  int                 `json:"total_count"`
+	CheckSuites []MinimalCheckSuite `json:"check_suites"`
+}
+
+// checkSuitesState rolls the check suites of a commit up into a single state, see CheckSuitesResult
+func checkSuitesState(suites []MinimalCheckSuite) string {
+	state := "success"
+	for _, suite := range suites {
+		if suite.Status != "completed" {
+			state = "pending"
+			continue
+		}
+		switch suite.Conclusion {
+		case "success", "neutral", "skipped":
+		default:
+			return "failure"
+		}
+	}
+	return state
+}
+
+// ListCheckSuites creates a tool to list the check suites of a commit.
+func ListCheckSuites(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_check_suites",
+			mcp.WithDescription(t("TOOL_LIST_CHECK_SUITES_DESCRIPTION", "List all the check suites of a commit, one per GitHub App running checks on it, with their status and conclusion. The state rolls them up: failure when any suite failed, pending while any is still running, success otherwise")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHECK_SUITES_USER_TITLE", "List check suites"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Commit SHA, branch or tag name"),
+			),
+			mcp.WithNumber("app_id",
+				mcp.Description("Only return the check suite of this GitHub App"),
+			),
+			mcp.WithString("check_name",
+				mcp.Description("Only return check suites with a check run of this name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			appID, err := OptionalIntParam(request, "app_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkName, err := OptionalParam[string](request, "check_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListCheckSuiteOptions{
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			if appID != 0 {
+				opts.AppID = github.Ptr(appID)
+			}
+			if checkName != "" {
+				opts.CheckName = github.Ptr(checkName)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The rollup has to cover every suite, so all pages are read
+			minimalSuites, err := FetchAllPages(ctx, func(ctx context.Context, page int) ([]MinimalCheckSuite, *github.Response, error) {
+				pageOpts := *opts
+				pageOpts.Page = page
+				result, resp, err := client.Checks.ListCheckSuitesForRef(ctx, owner, repo, ref, &pageOpts)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to list check suites: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				suites := make([]MinimalCheckSuite, 0, len(result.CheckSuites))
+				for _, suite := range result.CheckSuites {
+					suites = append(suites, newMinimalCheckSuite(suite))
+				}
+				return suites, resp, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			if minimalSuites == nil {
+				minimalSuites = []MinimalCheckSuite{}
+			}
+
+			return MarshalledTextResult(CheckSuitesResult{
+				State:       checkSuitesState(minimalSuites),
+				TotalCount:  len(minimalSuites),
+				CheckSuites: minimalSuites,
+			}), nil
+		}
+}
+
+// RerequestCheckSuite creates a tool to rerun the checks of a check suite.
+func RerequestCheckSuite(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("rerequest_check_suite",
+			mcp.WithDescription(t("TOOL_REREQUEST_CHECK_SUITE_DESCRIPTION", "Ask the GitHub App of a check suite to run its checks again, and return the suite once the request was accepted. The suite is queued until the app picks it up")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REREQUEST_CHECK_SUITE_USER_TITLE", "Re-request check suite"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("check_suite_id",
+				mcp.Required(),
+				mcp.Description("ID of the check suite to run again"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			checkSuiteID, err := RequiredInt(request, "check_suite_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Checks.ReRequestCheckSuite(ctx, owner, repo, int64(checkSuiteID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to re-request check suite: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to re-request check suite: %s", string(body))), nil
+			}
+
+			suite, getResp, err := client.Checks.GetCheckSuite(ctx, owner, repo, int64(checkSuiteID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get check suite: %w", err)
+			}
+			defer func() { _ = getResp.Body.Close() }()
+
+			return MarshalledTextResult(newMinimalCheckSuite(suite)), nil
+		}
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCheckSuites(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCheckSuites(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_check_suites", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "app_id")
+	assert.Contains(t, tool.InputSchema.Properties, "check_name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	// Two pages, the second one has the failing suite
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/owner/repo/commits/main/check-suites", r.URL.Path)
+				assert.Equal(t, "42", r.URL.Query().Get("app_id"))
+				assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+				if r.URL.Query().Get("page") == "1" {
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits/main/check-suites?page=2>; rel="next"`)
+					mockResponse(t, http.StatusOK, &github.ListCheckSuiteResults{
+						Total: github.Ptr(2),
+						CheckSuites: []*github.CheckSuite{{
+							ID:         github.Ptr(int64(1)),
+							App:        &github.App{ID: github.Ptr(int64(42)), Slug: github.Ptr("github-actions")},
+							Status:     github.Ptr("completed"),
+							Conclusion: github.Ptr("success"),
+							HeadSHA:    github.Ptr("abc123"),
+						}},
+					})(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, &github.ListCheckSuiteResults{
+					Total: github.Ptr(2),
+					CheckSuites: []*github.CheckSuite{{
+						ID:         github.Ptr(int64(2)),
+						App:        &github.App{ID: github.Ptr(int64(42)), Slug: github.Ptr("github-actions")},
+						Status:     github.Ptr("completed"),
+						Conclusion: github.Ptr("failure"),
+						HeadSHA:    github.Ptr("abc123"),
+					}},
+				})(w, r)
+			}),
+		),
+	))
+	_, handler := ListCheckSuites(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"ref":    "main",
+		"app_id": float64(42),
+	}))
+	require.NoError(t, err)
+
+	var returned CheckSuitesResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "failure", returned.State)
+	assert.Equal(t, 2, returned.TotalCount)
+	require.Len(t, returned.CheckSuites, 2)
+	assert.Equal(t, MinimalCheckSuite{
+		ID:         2,
+		App:        "github-actions",
+		AppID:      42,
+		Status:     "completed",
+		Conclusion: "failure",
+		HeadSHA:    "abc123",
+	}, returned.CheckSuites[1])
+}
+
+func Test_CheckSuitesState(t *testing.T) {
+	completed := func(conclusion string) MinimalCheckSuite {
+		return MinimalCheckSuite{Status: "completed", Conclusion: conclusion}
+	}
+
+	tests := []struct {
+		name     string
+		suites   []MinimalCheckSuite
+		expected string
+	}{
+		{name: "no suites", expected: "success"},
+		{name: "passing conclusions", suites: []MinimalCheckSuite{completed("success"), completed("neutral"), completed("skipped")}, expected: "success"},
+		{name: "still running", suites: []MinimalCheckSuite{completed("success"), {Status: "in_progress"}}, expected: "pending"},
+		{name: "failure wins over pending", suites: []MinimalCheckSuite{{Status: "queued"}, completed("timed_out")}, expected: "failure"},
+		{name: "cancelled", suites: []MinimalCheckSuite{completed("cancelled")}, expected: "failure"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, checkSuitesState(tc.suites))
+		})
+	}
+}
+
+func Test_RerequestCheckSuite(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RerequestCheckSuite(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "rerequest_check_suite", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "check_suite_id"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "re-request check suite",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteId,
+					expectPath(t, "/repos/owner/repo/check-suites/7/rerequest").andThen(
+						mockResponse(t, http.StatusCreated, nil),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckSuitesByOwnerByRepoByCheckSuiteId,
+					mockResponse(t, http.StatusOK, &github.CheckSuite{
+						ID:     github.Ptr(int64(7)),
+						Status: github.Ptr("queued"),
+					}),
+				),
+			),
+		},
+		{
+			name: "check suite not rerequestable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteId,
+					mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Check suite is not rerequestable"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to re-request check suite",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := RerequestCheckSuite(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"check_suite_id": float64(7),
+			}))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			var suite MinimalCheckSuite
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &suite))
+			assert.Equal(t, MinimalCheckSuite{ID: 7, Status: "queued"}, suite)
+		})
+	}
+}
@@ -3,9 +3,11 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
@@ -13,6 +15,60 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// MinimalCodeScanningAlert is the part of a code scanning alert list_code_scanning_alerts returns
+type MinimalCodeScanningAlert struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	Rule   struct {
+		ID          string `json:"id,omitempty"`
+		Severity    string `json:"severity,omitempty"`
+		Description string `json:"description,omitempty"`
+		// SecuritySeverity is the CVSS based severity of security rules, such as critical or high
+		SecuritySeverity string `json:"security_severity,omitempty"`
+	} `json:"rule"`
+	Tool     string `json:"tool,omitempty"`
+	Location struct {
+		Path      string `json:"path,omitempty"`
+		StartLine int    `json:"start_line,omitempty"`
+		EndLine   int    `json:"end_line,omitempty"`
+	} `json:"location"`
+	HTMLURL         string     `json:"html_url"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	DismissedReason string     `json:"dismissed_reason,omitempty"`
+}
+
+func newMinimalCodeScanningAlert(alert *github.Alert) MinimalCodeScanningAlert {
+	minimal := MinimalCodeScanningAlert{
+		Number:          alert.GetNumber(),
+		State:           alert.GetState(),
+		Tool:            alert.GetTool().GetName(),
+		HTMLURL:         alert.GetHTMLURL(),
+		DismissedReason: alert.GetDismissedReason(),
+	}
+	minimal.Rule.ID = alert.GetRule().GetID()
+	minimal.Rule.Severity = alert.GetRule().GetSeverity()
+	minimal.Rule.Description = alert.GetRule().GetDescription()
+	minimal.Rule.SecuritySeverity = alert.GetRule().GetSecuritySeverityLevel()
+	location := alert.GetMostRecentInstance().GetLocation()
+	minimal.Location.Path = location.GetPath()
+	minimal.Location.StartLine = location.GetStartLine()
+	minimal.Location.EndLine = location.GetEndLine()
+	if alert.CreatedAt != nil {
+		minimal.CreatedAt = &alert.CreatedAt.Time
+	}
+	return minimal
+}
+
+// codeScanningForbiddenResult explains the 403 GitHub returns when code scanning is not available for a
+// repository, which for private repositories needs GitHub Advanced Security. It returns nil for any other error.
+func codeScanningForbiddenResult(err error, owner, repo string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("code scanning is not available for %s/%s, it needs GitHub Advanced Security to be enabled for private repositories, or the token in use lacks the security_events scope: %s", owner, repo, ghErr.Message))
+}
+
 func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_code_scanning_alert",
 			mcp.WithDescription(t("TOOL_GET_CODE_SCANNING_ALERT_DESCRIPTION", "Get details of a specific code scanning alert in a GitHub repository.")),
@@ -53,6 +109,9 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 			}
 
 			alert, resp, err := client.CodeScanning.GetAlert(ctx, owner, repo, int64(alertNumber))
+			if result := codeScanningForbiddenResult(err, owner, repo); result != nil {
+				return result, nil
+			}
 			if err != nil {
 				return nil, fmt.Errorf("failed to get alert: %w", err)
 			}
@@ -105,6 +164,7 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			mcp.WithString("tool_name",
 				mcp.Description("The name of the tool used for code scanning."),
 			),
+			WithPagination(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -131,12 +191,28 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			alerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, &github.AlertListOptions{Ref: ref, State: state, Severity: severity, ToolName: toolName})
+			alerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, &github.AlertListOptions{
+				Ref:      ref,
+				State:    state,
+				Severity: severity,
+				ToolName: toolName,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			})
+			if result := codeScanningForbiddenResult(err, owner, repo); result != nil {
+				return result, nil
+			}
 			if err != nil {
 				return nil, fmt.Errorf("failed to list alerts: %w", err)
 			}
@@ -150,11 +226,114 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(alerts)
+			minimalAlerts := make([]MinimalCodeScanningAlert, 0, len(alerts))
+			for _, alert := range alerts {
+				minimalAlerts = append(minimalAlerts, newMinimalCodeScanningAlert(alert))
+			}
+
+			return MarshalledListResult(minimalAlerts, resp, nil), nil
+		}
+}
+
+// UpdateCodeScanningAlert creates a tool to dismiss or reopen a code scanning alert.
+func UpdateCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_code_scanning_alert",
+			mcp.WithDescription(t("TOOL_UPDATE_CODE_SCANNING_ALERT_DESCRIPTION", "Dismiss a code scanning alert in a GitHub repository with a reason, or reopen a dismissed one.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_CODE_SCANNING_ALERT_USER_TITLE", "Dismiss or reopen code scanning alert"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("state",
+				mcp.Required(),
+				mcp.Description("The new state of the alert"),
+				mcp.Enum("open", "dismissed"),
+			),
+			mcp.WithString("dismissed_reason",
+				mcp.Description("Why the alert is dismissed, required when state is dismissed"),
+				mcp.Enum("false positive", "won't fix", "used in tests"),
+			),
+			mcp.WithString("dismissed_comment",
+				mcp.Description("A comment on why the alert is dismissed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := requiredParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dismissedReason, err := OptionalParam[string](request, "dismissed_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dismissedComment, err := OptionalParam[string](request, "dismissed_comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			update := &github.CodeScanningAlertState{State: state}
+			switch state {
+			case "dismissed":
+				if dismissedReason == "" {
+					return mcp.NewToolResultError("dismissed_reason is required to dismiss an alert"), nil
+				}
+				update.DismissedReason = github.Ptr(dismissedReason)
+				if dismissedComment != "" {
+					update.DismissedComment = github.Ptr(dismissedComment)
+				}
+			case "open":
+				if dismissedReason != "" || dismissedComment != "" {
+					return mcp.NewToolResultError("dismissed_reason and dismissed_comment only apply when dismissing an alert"), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid state %q, must be open or dismissed", state)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			alert, resp, err := client.CodeScanning.UpdateAlert(ctx, owner, repo, int64(alertNumber), update)
+			if result := codeScanningForbiddenResult(err, owner, repo); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to update alert: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update alert: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalCodeScanningAlert(alert)), nil
 		}
 }
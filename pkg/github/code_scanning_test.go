@@ -128,6 +128,8 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "state")
 	assert.Contains(t, tool.InputSchema.Properties, "severity")
 	assert.Contains(t, tool.InputSchema.Properties, "tool_name")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock alerts for success case
@@ -147,12 +149,13 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		mockedClient   *http.Client
-		requestArgs    map[string]interface{}
-		expectError    bool
-		expectedAlerts []*github.Alert
-		expectedErrMsg string
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedAlerts  []*github.Alert
+		expectedErrMsg  string
 	}{
 		{
 			name: "successful alerts listing",
@@ -164,6 +167,8 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 						"state":     "open",
 						"severity":  "high",
 						"tool_name": "codeql",
+						"page":      "1",
+						"per_page":  "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, mockAlerts),
 					),
@@ -180,6 +185,43 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 			expectError:    false,
 			expectedAlerts: mockAlerts,
 		},
+		{
+			name: "alerts listing with pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "1",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts[1:]),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(1),
+			},
+			expectError:    false,
+			expectedAlerts: mockAlerts[1:],
+		},
+		{
+			name: "code scanning not available",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodeScanningAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Advanced Security must be enabled for this repository to use code scanning."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "code scanning is not available for owner/repo",
+		},
 		{
 			name: "alerts listing fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -224,10 +266,15 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
 			// Unmarshal and verify the result
 			var returnedAlerts []*github.Alert
-			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
-			assert.NoError(t, err)
+			getListResult(t, textContent.Text, &returnedAlerts)
 			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
 			for i, alert := range returnedAlerts {
 				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
@@ -238,3 +285,227 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListCodeScanningAlerts_Location(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposCodeScanningAlertsByOwnerByRepo,
+			[]*github.Alert{
+				{
+					Number: github.Ptr(42),
+					State:  github.Ptr("open"),
+					Rule: &github.Rule{
+						ID:                    github.Ptr("js/sql-injection"),
+						Severity:              github.Ptr("error"),
+						Description:           github.Ptr("Database query built from user-controlled sources"),
+						SecuritySeverityLevel: github.Ptr("high"),
+					},
+					Tool: &github.Tool{Name: github.Ptr("CodeQL")},
+					MostRecentInstance: &github.MostRecentInstance{
+						Location: &github.Location{
+							Path:      github.Ptr("src/db.js"),
+							StartLine: github.Ptr(10),
+							EndLine:   github.Ptr(12),
+						},
+					},
+					Instances: []*github.MostRecentInstance{{Ref: github.Ptr("refs/heads/main")}},
+				},
+			},
+		),
+	))
+	_, handler := ListCodeScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+
+	// Only the rule, tool and location of each alert are returned
+	var returnedAlerts []MinimalCodeScanningAlert
+	getListResult(t, getTextResult(t, result).Text, &returnedAlerts)
+	require.Len(t, returnedAlerts, 1)
+	alert := returnedAlerts[0]
+	assert.Equal(t, 42, alert.Number)
+	assert.Equal(t, "js/sql-injection", alert.Rule.ID)
+	assert.Equal(t, "high", alert.Rule.SecuritySeverity)
+	assert.Equal(t, "CodeQL", alert.Tool)
+	assert.Equal(t, "src/db.js", alert.Location.Path)
+	assert.Equal(t, 10, alert.Location.StartLine)
+	assert.Equal(t, 12, alert.Location.EndLine)
+	assert.NotContains(t, getTextResult(t, result).Text, "instances")
+}
+
+func Test_UpdateCodeScanningAlert(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateCodeScanningAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_code_scanning_alert", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "dismissed_reason")
+	assert.Contains(t, tool.InputSchema.Properties, "dismissed_comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber", "state"})
+
+	dismissedAlert := &github.Alert{
+		Number:          github.Ptr(42),
+		State:           github.Ptr("dismissed"),
+		Rule:            &github.Rule{ID: github.Ptr("js/sql-injection")},
+		DismissedReason: github.Ptr("false positive"),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/security/code-scanning/42"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedState   string
+	}{
+		{
+			name: "dismiss alert",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					expectPath(t, "/repos/owner/repo/code-scanning/alerts/42").andThen(
+						expectRequestBody(t, map[string]interface{}{
+							"state":             "dismissed",
+							"dismissed_reason":  "false positive",
+							"dismissed_comment": "Input is sanitized upstream",
+						}).andThen(
+							mockResponse(t, http.StatusOK, dismissedAlert),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"alertNumber":       float64(42),
+				"state":             "dismissed",
+				"dismissed_reason":  "false positive",
+				"dismissed_comment": "Input is sanitized upstream",
+			},
+			expectedState: "dismissed",
+		},
+		{
+			name: "reopen alert",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "open",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Alert{Number: github.Ptr(42), State: github.Ptr("open")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "open",
+			},
+			expectedState: "open",
+		},
+		{
+			name:         "dismissing without a reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "dismissed",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "dismissed_reason is required",
+		},
+		{
+			name:         "reopening with a reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(42),
+				"state":            "open",
+				"dismissed_reason": "won't fix",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "only apply when dismissing",
+		},
+		{
+			name: "code scanning not available",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Advanced Security must be enabled for this repository to use code scanning."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(42),
+				"state":            "dismissed",
+				"dismissed_reason": "won't fix",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "code scanning is not available for owner/repo",
+		},
+		{
+			name: "alert not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(9999),
+				"state":       "open",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update alert",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateCodeScanningAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedAlert MinimalCodeScanningAlert
+			err = json.Unmarshal([]byte(textContent.Text), &returnedAlert)
+			require.NoError(t, err)
+			assert.Equal(t, 42, returnedAlert.Number)
+			assert.Equal(t, tc.expectedState, returnedAlert.State)
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalCodespace is the subset of a codespace needed to find and manage it.
+type MinimalCodespace struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	// State is Available, Shutdown, Starting, ShuttingDown, Queued, Provisioning, Deleted and so on
+	State      string     `json:"state"`
+	Repository string     `json:"repository,omitempty"`
+	Branch     string     `json:"branch,omitempty"`
+	Machine    string     `json:"machine,omitempty"`
+	WebURL     string     `json:"web_url,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+func newMinimalCodespace(codespace *github.Codespace) MinimalCodespace {
+	minimal := MinimalCodespace{
+		Name:        codespace.GetName(),
+		DisplayName: codespace.GetDisplayName(),
+		State:       codespace.GetState(),
+		Repository:  codespace.GetRepository().GetFullName(),
+		Branch:      codespace.GetGitStatus().GetRef(),
+		Machine:     codespace.GetMachine().GetName(),
+		WebURL:      codespace.GetWebURL(),
+	}
+	if codespace.CreatedAt != nil {
+		minimal.CreatedAt = &codespace.CreatedAt.Time
+	}
+	if codespace.LastUsedAt != nil {
+		minimal.LastUsedAt = &codespace.LastUsedAt.Time
+	}
+	return minimal
+}
+
+// codespaceErrorResult explains the billing and permission errors of the Codespaces API, which otherwise
+// surface as a bare status code. It returns nil for any other error.
+func codespaceErrorResult(err error, action string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return nil
+	}
+	switch ghErr.Response.StatusCode {
+	case http.StatusPaymentRequired:
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s: Codespaces billing is not set up or its spending limit has been reached for the account paying for the codespace: %s", action, ghErr.Message))
+	case http.StatusForbidden:
+		return mcp.NewToolResultError(fmt.Sprintf("failed to %s: the token in use lacks the codespace scope or Codespaces permission, or the organization does not allow this user to use Codespaces: %s", action, ghErr.Message))
+	}
+	return nil
+}
+
+// ListCodespaces creates a tool to list the codespaces of the authenticated user.
+func ListCodespaces(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_codespaces",
+			mcp.WithDescription(t("TOOL_LIST_CODESPACES_DESCRIPTION", "List the codespaces of the authenticated user with their name and state, optionally only those of one repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CODESPACES_USER_TITLE", "List codespaces"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Description("Repository owner, requires repo"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Only list the codespaces of this repository, requires owner"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := OptionalParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := OptionalParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (owner == "") != (repo == "") {
+				return mcp.NewToolResultError("owner and repo must be given together"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			listOpts := github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			var codespaces *github.ListCodespaces
+			var resp *github.Response
+			if repo != "" {
+				codespaces, resp, err = client.Codespaces.ListInRepo(ctx, owner, repo, &listOpts)
+			} else {
+				codespaces, resp, err = client.Codespaces.List(ctx, &github.ListCodespacesOptions{ListOptions: listOpts})
+			}
+			if result := codespaceErrorResult(err, "list codespaces"); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list codespaces: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list codespaces: %s", string(body))), nil
+			}
+
+			minimalCodespaces := make([]MinimalCodespace, 0, len(codespaces.Codespaces))
+			for _, codespace := range codespaces.Codespaces {
+				minimalCodespaces = append(minimalCodespaces, newMinimalCodespace(codespace))
+			}
+
+			return MarshalledListResult(minimalCodespaces, resp, codespaces.TotalCount), nil
+		}
+}
+
+// CreateCodespace creates a tool to create a codespace for a repository.
+func CreateCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_codespace",
+			mcp.WithDescription(t("TOOL_CREATE_CODESPACE_DESCRIPTION", "Create a codespace for a repository, billed to the authenticated user or their organization. The codespace may still be provisioning when this returns, check its state with list_codespaces.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_CODESPACE_USER_TITLE", "Create codespace"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to check out, defaults to the repository's default branch"),
+			),
+			mcp.WithString("machine",
+				mcp.Description("Machine type, such as basicLinux32gb or standardLinux32gb, defaults to the smallest available"),
+			),
+			mcp.WithString("display_name",
+				mcp.Description("Display name of the codespace"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			machine, err := OptionalParam[string](request, "machine")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			displayName, err := OptionalParam[string](request, "display_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.CreateCodespaceOptions{}
+			if branch != "" {
+				opts.Ref = github.Ptr(branch)
+			}
+			if machine != "" {
+				opts.Machine = github.Ptr(machine)
+			}
+			if displayName != "" {
+				opts.DisplayName = github.Ptr(displayName)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			codespace, resp, err := client.Codespaces.CreateInRepo(ctx, owner, repo, opts)
+			if result := codespaceErrorResult(err, "create codespace"); result != nil {
+				return result, nil
+			}
+			// GitHub answers 202 instead of 201 when the codespace creation is queued, which go-github
+			// reports as an AcceptedError carrying the codespace
+			var acceptedErr *github.AcceptedError
+			if errors.As(err, &acceptedErr) {
+				codespace = &github.Codespace{}
+				if err := json.Unmarshal(acceptedErr.Raw, codespace); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal queued codespace: %w", err)
+				}
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to create codespace: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create codespace: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalCodespace(codespace)), nil
+		}
+}
+
+// StopCodespace creates a tool to stop a running codespace.
+func StopCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("stop_codespace",
+			mcp.WithDescription(t("TOOL_STOP_CODESPACE_DESCRIPTION", "Stop a running codespace of the authenticated user, keeping its files")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_STOP_CODESPACE_USER_TITLE", "Stop codespace"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the codespace, as returned by list_codespaces"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			codespace, resp, err := client.Codespaces.Stop(ctx, name)
+			if result := codespaceErrorResult(err, "stop codespace"); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to stop codespace: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to stop codespace: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalCodespace(codespace)), nil
+		}
+}
+
+// DeleteCodespace creates a tool to delete a codespace.
+func DeleteCodespace(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_codespace",
+			mcp.WithDescription(t("TOOL_DELETE_CODESPACE_DESCRIPTION", "Delete a codespace of the authenticated user. Uncommitted and unpushed changes in it are lost.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_CODESPACE_USER_TITLE", "Delete codespace"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the codespace, as returned by list_codespaces"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// GitHub accepts the deletion with a 202, which go-github reports as an AcceptedError
+			resp, err := client.Codespaces.Delete(ctx, name)
+			if result := codespaceErrorResult(err, "delete codespace"); result != nil {
+				return result, nil
+			}
+			if err != nil && !isAcceptedError(err) {
+				return nil, fmt.Errorf("failed to delete codespace: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusAccepted {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete codespace: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("codespace %s is being deleted", name)), nil
+		}
+}
@@ -0,0 +1,393 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mockCodespace = &github.Codespace{
+	Name:       github.Ptr("octocat-hello-world-abc123"),
+	State:      github.Ptr("Available"),
+	Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+	GitStatus:  &github.CodespacesGitStatus{Ref: github.Ptr("main")},
+	Machine:    &github.CodespacesMachine{Name: github.Ptr("basicLinux32gb")},
+	WebURL:     github.Ptr("https://octocat-hello-world-abc123.github.dev"),
+}
+
+var expectedMockCodespace = MinimalCodespace{
+	Name:       "octocat-hello-world-abc123",
+	State:      "Available",
+	Repository: "owner/repo",
+	Branch:     "main",
+	Machine:    "basicLinux32gb",
+	WebURL:     "https://octocat-hello-world-abc123.github.dev",
+}
+
+func Test_ListCodespaces(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCodespaces(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_codespaces", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockList := &github.ListCodespaces{
+		TotalCount: github.Ptr(1),
+		Codespaces: []*github.Codespace{mockCodespace},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectToolError    bool
+		expectedErrMsg     string
+		expectedCodespaces []MinimalCodespace
+	}{
+		{
+			name: "codespaces of the user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserCodespaces,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, mockList),
+					),
+				),
+			),
+			requestArgs:        map[string]interface{}{},
+			expectedCodespaces: []MinimalCodespace{expectedMockCodespace},
+		},
+		{
+			name: "codespaces of a repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCodespacesByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/codespaces").andThen(
+						mockResponse(t, http.StatusOK, mockList),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedCodespaces: []MinimalCodespace{expectedMockCodespace},
+		},
+		{
+			name:         "repo without owner",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"repo": "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "owner and repo must be given together",
+		},
+		{
+			name: "token without codespace scope",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserCodespaces,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			requestArgs:     map[string]interface{}{},
+			expectToolError: true,
+			expectedErrMsg:  "lacks the codespace scope",
+		},
+		{
+			name: "list fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserCodespaces,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Server Error"}`),
+				),
+			),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "failed to list codespaces",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCodespaces(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedCodespaces []MinimalCodespace
+			getListResult(t, textContent.Text, &returnedCodespaces)
+			assert.Equal(t, tc.expectedCodespaces, returnedCodespaces)
+		})
+	}
+}
+
+func Test_CreateCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "branch")
+	assert.Contains(t, tool.InputSchema.Properties, "machine")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedCodespace MinimalCodespace
+	}{
+		{
+			name: "codespace created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodespacesByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/codespaces").andThen(
+						expectRequestBody(t, map[string]interface{}{
+							"ref":     "main",
+							"machine": "basicLinux32gb",
+						}).andThen(
+							mockResponse(t, http.StatusCreated, mockCodespace),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"branch":  "main",
+				"machine": "basicLinux32gb",
+			},
+			expectedCodespace: expectedMockCodespace,
+		},
+		{
+			name: "codespace creation queued",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodespacesByOwnerByRepo,
+					mockResponse(t, http.StatusAccepted, &github.Codespace{
+						Name:  github.Ptr("octocat-hello-world-abc123"),
+						State: github.Ptr("Queued"),
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedCodespace: MinimalCodespace{
+				Name:  "octocat-hello-world-abc123",
+				State: "Queued",
+			},
+		},
+		{
+			name: "spending limit reached",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodespacesByOwnerByRepo,
+					mockResponse(t, http.StatusPaymentRequired, `{"message": "You have reached your spending limit."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "spending limit",
+		},
+		{
+			name: "unknown machine type",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCodespacesByOwnerByRepo,
+					mockResponse(t, http.StatusBadRequest, `{"message": "Machine type is invalid"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"machine": "hugeLinux",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create codespace",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedCodespace MinimalCodespace
+			err = json.Unmarshal([]byte(textContent.Text), &returnedCodespace)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCodespace, returnedCodespace)
+		})
+	}
+}
+
+func Test_StopCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := StopCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "stop_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostUserCodespacesStopByCodespaceName,
+			expectPath(t, "/user/codespaces/octocat-hello-world-abc123/stop").andThen(
+				mockResponse(t, http.StatusOK, &github.Codespace{
+					Name:  github.Ptr("octocat-hello-world-abc123"),
+					State: github.Ptr("ShuttingDown"),
+				}),
+			),
+		),
+	))
+	_, handler := StopCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"name": "octocat-hello-world-abc123",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedCodespace MinimalCodespace
+	err = json.Unmarshal([]byte(textContent.Text), &returnedCodespace)
+	require.NoError(t, err)
+	assert.Equal(t, "ShuttingDown", returnedCodespace.State)
+}
+
+func Test_DeleteCodespace(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteCodespace(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_codespace", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"name"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedMsg     string
+	}{
+		{
+			name: "codespace deleted",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserCodespacesByCodespaceName,
+					expectPath(t, "/user/codespaces/octocat-hello-world-abc123").andThen(
+						mockResponse(t, http.StatusAccepted, map[string]any{}),
+					),
+				),
+			),
+			expectedMsg: "codespace octocat-hello-world-abc123 is being deleted",
+		},
+		{
+			name: "codespace not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteUserCodespacesByCodespaceName,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError: true,
+			expectedMsg: "failed to delete codespace",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteCodespace(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"name": "octocat-hello-world-abc123",
+			}))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedMsg, textContent.Text)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalCommitComment is a comment on a commit, anchored to a line of its diff when Path is set.
+type MinimalCommitComment struct {
+	ID        int64      `json:"id"`
+	URL       string     `json:"url"`
+	CommitID  string     `json:"commit_id"`
+	Author    string     `json:"author,omitempty"`
+	Body      string     `json:"body"`
+	Path      string     `json:"path,omitempty"`
+	Position  int        `json:"position,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+func newMinimalCommitComment(comment *github.RepositoryComment) MinimalCommitComment {
+	minimal := MinimalCommitComment{
+		ID:       comment.GetID(),
+		URL:      comment.GetHTMLURL(),
+		CommitID: comment.GetCommitID(),
+		Author:   comment.GetUser().GetLogin(),
+		Body:     comment.GetBody(),
+		Path:     comment.GetPath(),
+		Position: comment.GetPosition(),
+	}
+	if comment.CreatedAt != nil {
+		minimal.CreatedAt = &comment.CreatedAt.Time
+	}
+	return minimal
+}
+
+// ListCommitComments creates a tool to list the comments on a commit.
+func ListCommitComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_commit_comments",
+			mcp.WithDescription(t("TOOL_LIST_COMMIT_COMMENTS_DESCRIPTION", "List the comments on a commit of a GitHub repository, including those anchored to lines of its diff")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_COMMIT_COMMENTS_USER_TITLE", "List commit comments"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comments, resp, err := client.Repositories.ListCommitComments(ctx, owner, repo, sha, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list commit comments: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list commit comments: %s", string(body))), nil
+			}
+
+			minimalComments := make([]MinimalCommitComment, 0, len(comments))
+			for _, comment := range comments {
+				minimalComments = append(minimalComments, newMinimalCommitComment(comment))
+			}
+
+			return MarshalledListResult(minimalComments, resp, nil), nil
+		}
+}
+
+// CreateCommitComment creates a tool to comment on a commit, or on a line of its diff.
+func CreateCommitComment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_commit_comment",
+			mcp.WithDescription(t("TOOL_CREATE_COMMIT_COMMENT_DESCRIPTION", "Comment on a commit of a GitHub repository. Set path and position to anchor the comment to a line of the commit's diff. Returns the ID and URL of the comment")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_COMMIT_COMMENT_USER_TITLE", "Create commit comment"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Commit SHA"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("Text of the comment"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Path of the file to comment on, relative to the repository root"),
+			),
+			mcp.WithNumber("position",
+				mcp.Description("Line in the diff of path to comment on, counted from 1 at the line below the first @@ hunk header. Requires path"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			body, err := requiredParam[string](request, "body")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			path, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			position, err := OptionalIntParam(request, "position")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if position < 0 {
+				return mcp.NewToolResultError("position must be a positive number"), nil
+			}
+			if position > 0 && path == "" {
+				return mcp.NewToolResultError("position requires path"), nil
+			}
+
+			comment := &github.RepositoryComment{
+				Body: github.Ptr(body),
+			}
+			if path != "" {
+				comment.Path = github.Ptr(path)
+			}
+			if position > 0 {
+				comment.Position = github.Ptr(position)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Repositories.CreateComment(ctx, owner, repo, sha, comment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create commit comment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit comment: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalCommitComment(created)), nil
+		}
+}
@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCommitComments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListCommitComments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_commit_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockComments := []*github.RepositoryComment{
+		{
+			ID:        github.Ptr(int64(1)),
+			HTMLURL:   github.Ptr("https://github.com/owner/repo/commit/abc123#commitcomment-1"),
+			CommitID:  github.Ptr("abc123"),
+			User:      &github.User{Login: github.Ptr("octocat")},
+			Body:      github.Ptr("Nice"),
+			Path:      github.Ptr("main.go"),
+			Position:  github.Ptr(3),
+			CreatedAt: &github.Timestamp{Time: createdAt},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedComments []MinimalCommitComment
+	}{
+		{
+			name: "comments listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectPath(t, "/repos/owner/repo/commits/abc123/comments").andThen(
+						expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+							mockResponse(t, http.StatusOK, mockComments),
+						),
+					),
+				),
+			),
+			expectedComments: []MinimalCommitComment{
+				{
+					ID:        1,
+					URL:       "https://github.com/owner/repo/commit/abc123#commitcomment-1",
+					CommitID:  "abc123",
+					Author:    "octocat",
+					Body:      "Nice",
+					Path:      "main.go",
+					Position:  3,
+					CreatedAt: &createdAt,
+				},
+			},
+		},
+		{
+			name: "commit not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list commit comments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListCommitComments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedComments []MinimalCommitComment
+			getListResult(t, textContent.Text, &returnedComments)
+			assert.Equal(t, tc.expectedComments, returnedComments)
+		})
+	}
+}
+
+func Test_CreateCommitComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommitComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_commit_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "position")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha", "body"})
+
+	mockComment := &github.RepositoryComment{
+		ID:       github.Ptr(int64(99)),
+		HTMLURL:  github.Ptr("https://github.com/owner/repo/commit/abc123#commitcomment-99"),
+		CommitID: github.Ptr("abc123"),
+		User:     &github.User{Login: github.Ptr("octocat")},
+		Body:     github.Ptr("Off by one"),
+		Path:     github.Ptr("main.go"),
+		Position: github.Ptr(4),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedComment MinimalCommitComment
+	}{
+		{
+			name: "line comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectPath(t, "/repos/owner/repo/commits/abc123/comments").andThen(
+						expectRequestBody(t, map[string]interface{}{
+							"body":     "Off by one",
+							"path":     "main.go",
+							"position": float64(4),
+						}).andThen(
+							mockResponse(t, http.StatusCreated, mockComment),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"sha":      "abc123",
+				"body":     "Off by one",
+				"path":     "main.go",
+				"position": float64(4),
+			},
+			expectedComment: MinimalCommitComment{
+				ID:       99,
+				URL:      "https://github.com/owner/repo/commit/abc123#commitcomment-99",
+				CommitID: "abc123",
+				Author:   "octocat",
+				Body:     "Off by one",
+				Path:     "main.go",
+				Position: 4,
+			},
+		},
+		{
+			name: "comment on the whole commit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					expectRequestBody(t, map[string]interface{}{
+						"body": "LGTM",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.RepositoryComment{
+							ID:       github.Ptr(int64(100)),
+							HTMLURL:  github.Ptr("https://github.com/owner/repo/commit/abc123#commitcomment-100"),
+							CommitID: github.Ptr("abc123"),
+							Body:     github.Ptr("LGTM"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "abc123",
+				"body":  "LGTM",
+			},
+			expectedComment: MinimalCommitComment{
+				ID:       100,
+				URL:      "https://github.com/owner/repo/commit/abc123#commitcomment-100",
+				CommitID: "abc123",
+				Body:     "LGTM",
+			},
+		},
+		{
+			name:         "position without path",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"sha":      "abc123",
+				"body":     "Off by one",
+				"position": float64(4),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "position requires path",
+		},
+		{
+			name: "position outside the diff",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposCommitsCommentsByOwnerByRepoByCommitSha,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"sha":      "abc123",
+				"body":     "Off by one",
+				"path":     "main.go",
+				"position": float64(400),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create commit comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommitComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedComment MinimalCommitComment
+			err = json.Unmarshal([]byte(textContent.Text), &returnedComment)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedComment, returnedComment)
+		})
+	}
+}
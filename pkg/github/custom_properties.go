@@ -0,0 +1,266 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// customPropertyValues maps the custom properties of a repository to their values, a string, a list of strings
+// for multi_select properties, or nil when unset
+func customPropertyValues(values []*github.CustomPropertyValue) map[string]any {
+	properties := make(map[string]any, len(values))
+	for _, value := range values {
+		properties[value.PropertyName] = value.Value
+	}
+	return properties
+}
+
+// parseCustomPropertyValues converts the properties argument of set_repo_custom_properties into property
+// values. When the definitions of the organization are known, names and values are checked against them,
+// otherwise GitHub is left to reject what it does not accept.
+func parseCustomPropertyValues(properties map[string]any, definitions []*github.CustomProperty) ([]*github.CustomPropertyValue, error) {
+	if len(properties) == 0 {
+		return nil, fmt.Errorf("properties must set at least one property")
+	}
+
+	defined := make(map[string]*github.CustomProperty, len(definitions))
+	for _, definition := range definitions {
+		defined[definition.GetPropertyName()] = definition
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]*github.CustomPropertyValue, 0, len(names))
+	for _, name := range names {
+		definition, ok := defined[name]
+		if definitions != nil && !ok {
+			known := make([]string, 0, len(defined))
+			for definedName := range defined {
+				known = append(known, definedName)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("property %q is not defined by the organization, defined properties are: %s", name, strings.Join(known, ", "))
+		}
+
+		value, err := customPropertyValue(name, properties[name], definition)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, &github.CustomPropertyValue{PropertyName: name, Value: value})
+	}
+	return values, nil
+}
+
+// customPropertyValue checks the value of a single property, and its definition when known
+func customPropertyValue(name string, value any, definition *github.CustomProperty) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		if definition.GetRequired() {
+			return nil, fmt.Errorf("property %q is required and cannot be unset", name)
+		}
+		return nil, nil
+	case bool:
+		value = strconv.FormatBool(v)
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("property %q must be a list of strings", name)
+			}
+			list = append(list, s)
+		}
+		value = list
+	case string:
+	default:
+		return nil, fmt.Errorf("property %q must be a string, a list of strings, a boolean or null", name)
+	}
+	if definition == nil {
+		return value, nil
+	}
+
+	switch definition.ValueType {
+	case "multi_select":
+		list, ok := value.([]string)
+		if !ok {
+			list = []string{value.(string)}
+		}
+		for _, item := range list {
+			if !slices.Contains(definition.AllowedValues, item) {
+				return nil, fmt.Errorf("property %q does not allow %q, allowed values are: %s", name, item, strings.Join(definition.AllowedValues, ", "))
+			}
+		}
+		return list, nil
+	case "single_select":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("property %q takes a single value", name)
+		}
+		if !slices.Contains(definition.AllowedValues, s) {
+			return nil, fmt.Errorf("property %q does not allow %q, allowed values are: %s", name, s, strings.Join(definition.AllowedValues, ", "))
+		}
+		return s, nil
+	case "true_false":
+		if s, _ := value.(string); s != "true" && s != "false" {
+			return nil, fmt.Errorf("property %q must be true or false", name)
+		}
+		return value, nil
+	default:
+		if _, ok := value.(string); !ok {
+			return nil, fmt.Errorf("property %q takes a single value", name)
+		}
+		return value, nil
+	}
+}
+
+// GetRepoCustomProperties creates a tool to get the custom property values of a repository.
+func GetRepoCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_custom_properties",
+			mcp.WithDescription(t("TOOL_GET_REPO_CUSTOM_PROPERTIES_DESCRIPTION", "Get the custom property values of a GitHub repository, as a map of property name to value. Custom properties are defined by the organization that owns the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_CUSTOM_PROPERTIES_USER_TITLE", "Get repository custom properties"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, an organization"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			values, resp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository custom properties: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository custom properties: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(customPropertyValues(values)), nil
+		}
+}
+
+// SetRepoCustomProperties creates a tool to set custom property values of a repository.
+func SetRepoCustomProperties(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_repo_custom_properties",
+			mcp.WithDescription(t("TOOL_SET_REPO_CUSTOM_PROPERTIES_DESCRIPTION", "Set custom property values of a GitHub repository and return all its values. Properties left out keep their value. Names and values are checked against the properties the organization defines when they can be read")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_REPO_CUSTOM_PROPERTIES_USER_TITLE", "Set repository custom properties"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner, an organization"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithObject("properties",
+				mcp.Required(),
+				mcp.Description("Map of property name to value: a string, a list of strings for multi_select properties, true or false for true_false properties, or null to unset the property"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			properties, ok := request.GetArguments()["properties"].(map[string]any)
+			if !ok {
+				return mcp.NewToolResultError("properties must be an object mapping property names to values"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The definitions can only be read with access to the organization, without them GitHub validates alone
+			definitions, resp, err := client.Organizations.GetAllCustomProperties(ctx, owner)
+			var ghErr *github.ErrorResponse
+			switch {
+			case errors.As(err, &ghErr) && ghErr.Response != nil &&
+				(ghErr.Response.StatusCode == http.StatusForbidden || ghErr.Response.StatusCode == http.StatusNotFound):
+				definitions = nil
+			case err != nil:
+				return nil, fmt.Errorf("failed to get organization custom properties: %w", err)
+			default:
+				if definitions == nil {
+					definitions = []*github.CustomProperty{}
+				}
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			values, err := parseCustomPropertyValues(properties, definitions)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			setResp, err := client.Repositories.CreateOrUpdateCustomProperties(ctx, owner, repo, values)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set repository custom properties: %w", err)
+			}
+			defer func() { _ = setResp.Body.Close() }()
+
+			if setResp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(setResp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set repository custom properties: %s", string(body))), nil
+			}
+
+			current, getResp, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository custom properties: %w", err)
+			}
+			defer func() { _ = getResp.Body.Close() }()
+
+			return MarshalledTextResult(customPropertyValues(current)), nil
+		}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCustomPropertyDefinitions are the custom properties an organization defines, one of each value type
+var mockCustomPropertyDefinitions = []*github.CustomProperty{
+	{PropertyName: github.Ptr("team"), ValueType: "string", Required: github.Ptr(true)},
+	{PropertyName: github.Ptr("tier"), ValueType: "single_select", AllowedValues: []string{"gold", "silver"}},
+	{PropertyName: github.Ptr("regions"), ValueType: "multi_select", AllowedValues: []string{"eu", "us"}},
+	{PropertyName: github.Ptr("pci"), ValueType: "true_false"},
+}
+
+var mockCustomPropertyValues = []map[string]any{
+	{"property_name": "team", "value": "platform"},
+	{"property_name": "tier", "value": "gold"},
+	{"property_name": "regions", "value": []string{"eu", "us"}},
+	{"property_name": "pci", "value": nil},
+}
+
+var expectedCustomPropertyValues = map[string]any{
+	"team":    "platform",
+	"tier":    "gold",
+	"regions": []any{"eu", "us"},
+	"pci":     nil,
+}
+
+func Test_GetRepoCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepoCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_repo_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposPropertiesValuesByOwnerByRepo,
+			expectPath(t, "/repos/org/repo/properties/values").andThen(
+				mockResponse(t, http.StatusOK, mockCustomPropertyValues),
+			),
+		),
+	))
+	_, handler := GetRepoCustomProperties(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "org",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+
+	var properties map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &properties))
+	assert.Equal(t, expectedCustomPropertyValues, properties)
+}
+
+func Test_SetRepoCustomProperties(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetRepoCustomProperties(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_repo_custom_properties", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "properties")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "properties"})
+
+	definitions := mock.WithRequestMatchHandler(
+		mock.GetOrgsPropertiesSchemaByOrg,
+		mockResponse(t, http.StatusOK, mockCustomPropertyDefinitions),
+	)
+	currentValues := mock.WithRequestMatchHandler(
+		mock.GetReposPropertiesValuesByOwnerByRepo,
+		mockResponse(t, http.StatusOK, mockCustomPropertyValues),
+	)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		properties      map[string]any
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "values checked against the definitions",
+			mockedClient: mock.NewMockedHTTPClient(
+				definitions,
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"properties": []any{
+							map[string]any{"property_name": "pci", "value": nil},
+							map[string]any{"property_name": "regions", "value": []any{"eu"}},
+							map[string]any{"property_name": "tier", "value": "gold"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+				currentValues,
+			),
+			properties: map[string]any{
+				"tier":    "gold",
+				"regions": "eu",
+				"pci":     nil,
+			},
+		},
+		{
+			name: "true_false property given as a boolean",
+			mockedClient: mock.NewMockedHTTPClient(
+				definitions,
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"properties": []any{
+							map[string]any{"property_name": "pci", "value": "true"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+				currentValues,
+			),
+			properties: map[string]any{"pci": true},
+		},
+		{
+			name: "definitions that cannot be read are not checked",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsPropertiesSchemaByOrg,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposPropertiesValuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"properties": []any{
+							map[string]any{"property_name": "anything", "value": "goes"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+				currentValues,
+			),
+			properties: map[string]any{"anything": "goes"},
+		},
+		{
+			name:            "undefined property",
+			mockedClient:    mock.NewMockedHTTPClient(definitions),
+			properties:      map[string]any{"owner_team": "platform"},
+			expectToolError: true,
+			expectedErrMsg:  `property "owner_team" is not defined by the organization, defined properties are: pci, regions, team, tier`,
+		},
+		{
+			name:            "value not allowed",
+			mockedClient:    mock.NewMockedHTTPClient(definitions),
+			properties:      map[string]any{"tier": "bronze"},
+			expectToolError: true,
+			expectedErrMsg:  `property "tier" does not allow "bronze", allowed values are: gold, silver`,
+		},
+		{
+			name:            "required property unset",
+			mockedClient:    mock.NewMockedHTTPClient(definitions),
+			properties:      map[string]any{"team": nil},
+			expectToolError: true,
+			expectedErrMsg:  `property "team" is required and cannot be unset`,
+		},
+		{
+			name:            "single value for a list",
+			mockedClient:    mock.NewMockedHTTPClient(definitions),
+			properties:      map[string]any{"tier": []any{"gold", "silver"}},
+			expectToolError: true,
+			expectedErrMsg:  `property "tier" takes a single value`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SetRepoCustomProperties(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":      "org",
+				"repo":       "repo",
+				"properties": tc.properties,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var properties map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &properties))
+			assert.Equal(t, expectedCustomPropertyValues, properties)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalDependabotAlert is the part of a Dependabot alert the Dependabot tools return
+type MinimalDependabotAlert struct {
+	Number  int    `json:"number"`
+	State   string `json:"state"`
+	Package struct {
+		Ecosystem    string `json:"ecosystem,omitempty"`
+		Name         string `json:"name,omitempty"`
+		ManifestPath string `json:"manifest_path,omitempty"`
+		// Scope is either runtime or development
+		Scope string `json:"scope,omitempty"`
+	} `json:"package"`
+	Advisory struct {
+		GHSAID    string  `json:"ghsa_id,omitempty"`
+		CVEID     string  `json:"cve_id,omitempty"`
+		Summary   string  `json:"summary,omitempty"`
+		Severity  string  `json:"severity,omitempty"`
+		CVSSScore float64 `json:"cvss_score,omitempty"`
+	} `json:"advisory"`
+	VulnerableVersionRange string     `json:"vulnerable_version_range,omitempty"`
+	FirstPatchedVersion    string     `json:"first_patched_version,omitempty"`
+	HTMLURL                string     `json:"html_url"`
+	CreatedAt              *time.Time `json:"created_at,omitempty"`
+	DismissedReason        string     `json:"dismissed_reason,omitempty"`
+}
+
+func newMinimalDependabotAlert(alert *github.DependabotAlert) MinimalDependabotAlert {
+	minimal := MinimalDependabotAlert{
+		Number:                 alert.GetNumber(),
+		State:                  alert.GetState(),
+		VulnerableVersionRange: alert.GetSecurityVulnerability().GetVulnerableVersionRange(),
+		FirstPatchedVersion:    alert.GetSecurityVulnerability().GetFirstPatchedVersion().GetIdentifier(),
+		HTMLURL:                alert.GetHTMLURL(),
+		DismissedReason:        alert.GetDismissedReason(),
+	}
+	dependency := alert.GetDependency()
+	minimal.Package.Ecosystem = dependency.GetPackage().GetEcosystem()
+	minimal.Package.Name = dependency.GetPackage().GetName()
+	minimal.Package.ManifestPath = dependency.GetManifestPath()
+	minimal.Package.Scope = dependency.GetScope()
+	advisory := alert.GetSecurityAdvisory()
+	minimal.Advisory.GHSAID = advisory.GetGHSAID()
+	minimal.Advisory.CVEID = advisory.GetCVEID()
+	minimal.Advisory.Summary = advisory.GetSummary()
+	minimal.Advisory.Severity = advisory.GetSeverity()
+	if score := advisory.GetCVSS().GetScore(); score != nil {
+		minimal.Advisory.CVSSScore = *score
+	}
+	if alert.CreatedAt != nil {
+		minimal.CreatedAt = &alert.CreatedAt.Time
+	}
+	return minimal
+}
+
+// dependabotForbiddenResult explains the 403 GitHub returns when Dependabot alerts are disabled for a repository.
+// It returns nil for any other error.
+func dependabotForbiddenResult(err error, owner, repo string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Dependabot alerts are disabled for %s/%s, an admin can enable them under Settings > Code security, or the token in use cannot read them: %s", owner, repo, ghErr.Message))
+}
+
+// ListDependabotAlerts creates a tool to list the Dependabot alerts of a repository.
+func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_dependabot_alerts",
+			mcp.WithDescription(t("TOOL_LIST_DEPENDABOT_ALERTS_DESCRIPTION", "List Dependabot alerts in a GitHub repository, with the advisory and the affected package of each")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPENDABOT_ALERTS_USER_TITLE", "List Dependabot alerts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter Dependabot alerts by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "fixed", "dismissed", "auto_dismissed"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Filter Dependabot alerts by the severity of their advisory"),
+				mcp.Enum("critical", "high", "medium", "low"),
+			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Filter Dependabot alerts by package ecosystem, such as npm, pip, maven or go"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			severity, err := OptionalParam[string](request, "severity")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListAlertsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if state != "" {
+				opts.State = github.Ptr(state)
+			}
+			if severity != "" {
+				opts.Severity = github.Ptr(severity)
+			}
+			if ecosystem != "" {
+				opts.Ecosystem = github.Ptr(ecosystem)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, opts)
+			if result := dependabotForbiddenResult(err, owner, repo); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list alerts: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
+			}
+
+			minimalAlerts := make([]MinimalDependabotAlert, 0, len(alerts))
+			for _, alert := range alerts {
+				minimalAlerts = append(minimalAlerts, newMinimalDependabotAlert(alert))
+			}
+
+			return MarshalledListResult(minimalAlerts, resp, nil), nil
+		}
+}
+
+// DismissDependabotAlert creates a tool to dismiss a Dependabot alert.
+func DismissDependabotAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("dismiss_dependabot_alert",
+			mcp.WithDescription(t("TOOL_DISMISS_DEPENDABOT_ALERT_DESCRIPTION", "Dismiss a Dependabot alert in a GitHub repository with a reason.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DISMISS_DEPENDABOT_ALERT_USER_TITLE", "Dismiss Dependabot alert"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The owner of the repository."),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("The name of the repository."),
+			),
+			mcp.WithNumber("alertNumber",
+				mcp.Required(),
+				mcp.Description("The number of the alert."),
+			),
+			mcp.WithString("dismissed_reason",
+				mcp.Required(),
+				mcp.Description("Why the alert is dismissed"),
+				mcp.Enum("fix_started", "inaccurate", "no_bandwidth", "not_used", "tolerable_risk"),
+			),
+			mcp.WithString("dismissed_comment",
+				mcp.Description("A comment on why the alert is dismissed"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertNumber, err := RequiredInt(request, "alertNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dismissedReason, err := requiredParam[string](request, "dismissed_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			dismissedComment, err := OptionalParam[string](request, "dismissed_comment")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			update := &github.DependabotAlertState{
+				State:           "dismissed",
+				DismissedReason: github.Ptr(dismissedReason),
+			}
+			if dismissedComment != "" {
+				update.DismissedComment = github.Ptr(dismissedComment)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alert, resp, err := client.Dependabot.UpdateAlert(ctx, owner, repo, alertNumber, update)
+			if result := dependabotForbiddenResult(err, owner, repo); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to dismiss alert: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to dismiss alert: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalDependabotAlert(alert)), nil
+		}
+}
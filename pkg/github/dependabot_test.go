@@ -0,0 +1,297 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListDependabotAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDependabotAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_dependabot_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockAlerts := []*github.DependabotAlert{
+		{
+			Number: github.Ptr(7),
+			State:  github.Ptr("open"),
+			Dependency: &github.Dependency{
+				Package:      &github.VulnerabilityPackage{Ecosystem: github.Ptr("npm"), Name: github.Ptr("lodash")},
+				ManifestPath: github.Ptr("package-lock.json"),
+				Scope:        github.Ptr("runtime"),
+			},
+			SecurityAdvisory: &github.DependabotSecurityAdvisory{
+				GHSAID:      github.Ptr("GHSA-35jh-r3h4-6jhm"),
+				CVEID:       github.Ptr("CVE-2021-23337"),
+				Summary:     github.Ptr("Command Injection in lodash"),
+				Description: github.Ptr("A long advisory description"),
+				Severity:    github.Ptr("high"),
+				CVSS:        &github.AdvisoryCVSS{Score: github.Ptr(7.2)},
+			},
+			SecurityVulnerability: &github.AdvisoryVulnerability{
+				VulnerableVersionRange: github.Ptr("< 4.17.21"),
+				FirstPatchedVersion:    &github.FirstPatchedVersion{Identifier: github.Ptr("4.17.21")},
+			},
+			HTMLURL: github.Ptr("https://github.com/owner/repo/security/dependabot/7"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "alerts listed with filters",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "open",
+						"severity":  "high",
+						"ecosystem": "npm",
+						"page":      "2",
+						"per_page":  "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"state":     "open",
+				"severity":  "high",
+				"ecosystem": "npm",
+				"page":      float64(2),
+				"perPage":   float64(10),
+			},
+		},
+		{
+			name: "Dependabot alerts disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Dependabot alerts are disabled for this repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "Dependabot alerts are disabled for owner/repo",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependabotAlertsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListDependabotAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedAlerts []MinimalDependabotAlert
+			getListResult(t, textContent.Text, &returnedAlerts)
+			require.Len(t, returnedAlerts, 1)
+			alert := returnedAlerts[0]
+			assert.Equal(t, 7, alert.Number)
+			assert.Equal(t, "lodash", alert.Package.Name)
+			assert.Equal(t, "package-lock.json", alert.Package.ManifestPath)
+			assert.Equal(t, "GHSA-35jh-r3h4-6jhm", alert.Advisory.GHSAID)
+			assert.Equal(t, "high", alert.Advisory.Severity)
+			assert.Equal(t, 7.2, alert.Advisory.CVSSScore)
+			assert.Equal(t, "4.17.21", alert.FirstPatchedVersion)
+			// The long advisory description is left out
+			assert.NotContains(t, textContent.Text, "A long advisory description")
+		})
+	}
+}
+
+func Test_DismissDependabotAlert(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DismissDependabotAlert(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "dismiss_dependabot_alert", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "dismissed_comment")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "alertNumber", "dismissed_reason"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "alert dismissed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					expectPath(t, "/repos/owner/repo/dependabot/alerts/7").andThen(
+						expectRequestBody(t, map[string]interface{}{
+							"state":             "dismissed",
+							"dismissed_reason":  "not_used",
+							"dismissed_comment": "Only used by a removed script",
+						}).andThen(
+							mockResponse(t, http.StatusOK, &github.DependabotAlert{
+								Number:          github.Ptr(7),
+								State:           github.Ptr("dismissed"),
+								DismissedReason: github.Ptr("not_used"),
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":             "owner",
+				"repo":              "repo",
+				"alertNumber":       float64(7),
+				"dismissed_reason":  "not_used",
+				"dismissed_comment": "Only used by a removed script",
+			},
+		},
+		{
+			name:         "missing reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(7),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: dismissed_reason",
+		},
+		{
+			name: "Dependabot alerts disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusForbidden, `{"message": "Dependabot alerts are disabled for this repository."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(7),
+				"dismissed_reason": "tolerable_risk",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "Dependabot alerts are disabled for owner/repo",
+		},
+		{
+			name: "alert not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposDependabotAlertsByOwnerByRepoByAlertNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(9999),
+				"dismissed_reason": "inaccurate",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to dismiss alert",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DismissDependabotAlert(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedAlert MinimalDependabotAlert
+			err = json.Unmarshal([]byte(textContent.Text), &returnedAlert)
+			require.NoError(t, err)
+			assert.Equal(t, 7, returnedAlert.Number)
+			assert.Equal(t, "dismissed", returnedAlert.State)
+			assert.Equal(t, "not_used", returnedAlert.DismissedReason)
+		})
+	}
+}
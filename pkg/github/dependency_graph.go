@@ -0,0 +1,220 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// spdxDocument is the part of an SPDX SBOM list_dependencies reads
+type spdxDocument struct {
+	DocumentDescribes []string      `json:"documentDescribes"`
+	Packages          []spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	SPDXID           string `json:"SPDXID"`
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	ExternalRefs     []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+// Dependency is a package a repository depends on, as listed by list_dependencies.
+type Dependency struct {
+	// Ecosystem is the package manager, such as npm, pip, maven, go or actions
+	Ecosystem string `json:"ecosystem,omitempty"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	License   string `json:"license,omitempty"`
+	// PURL is the package URL identifying the package across tools, such as pkg:npm/lodash@4.17.21
+	PURL string `json:"purl,omitempty"`
+}
+
+func newDependency(pkg spdxPackage) Dependency {
+	dependency := Dependency{
+		Name:    pkg.Name,
+		Version: pkg.VersionInfo,
+	}
+	// GitHub names packages <ecosystem>:<name>
+	if ecosystem, name, ok := strings.Cut(pkg.Name, ":"); ok {
+		dependency.Ecosystem = ecosystem
+		dependency.Name = name
+	}
+	for _, license := range []string{pkg.LicenseConcluded, pkg.LicenseDeclared} {
+		if license != "" && license != "NOASSERTION" {
+			dependency.License = license
+			break
+		}
+	}
+	for _, ref := range pkg.ExternalRefs {
+		if ref.ReferenceType == "purl" {
+			dependency.PURL = ref.ReferenceLocator
+			break
+		}
+	}
+	return dependency
+}
+
+// getSBOM fetches the SPDX SBOM of a repository as is. go-github's SBOM type leaves out fields such as the
+// package URLs and relationships, which supply chain tooling relies on.
+func getSBOM(ctx context.Context, client *github.Client, owner, repo string) (json.RawMessage, *github.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%v/%v/dependency-graph/sbom", owner, repo), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sbom struct {
+		SBOM json.RawMessage `json:"sbom"`
+	}
+	resp, err := client.Do(ctx, req, &sbom)
+	if err != nil {
+		return nil, resp, err
+	}
+	return sbom.SBOM, resp, nil
+}
+
+// dependencyGraphForbiddenResult explains the 403 GitHub returns when the dependency graph of a repository
+// is disabled. It returns nil for any other error.
+func dependencyGraphForbiddenResult(err error, owner, repo string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("the dependency graph of %s/%s is disabled, an admin can enable it under Settings > Code security, or the token in use cannot read it: %s", owner, repo, ghErr.Message))
+}
+
+// fetchSBOM gets the SBOM of a repository, returning a tool error result for the failures a caller can act on
+func fetchSBOM(ctx context.Context, getClient GetClientFn, owner, repo string) (json.RawMessage, *mcp.CallToolResult, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	sbom, resp, err := getSBOM(ctx, client, owner, repo)
+	if result := dependencyGraphForbiddenResult(err, owner, repo); result != nil {
+		return nil, result, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get SBOM: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return nil, mcp.NewToolResultError(fmt.Sprintf("failed to get SBOM: %s", string(body))), nil
+	}
+	return sbom, nil, nil
+}
+
+// GetSBOM creates a tool to export the software bill of materials of a repository.
+func GetSBOM(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_sbom",
+			mcp.WithDescription(t("TOOL_GET_SBOM_DESCRIPTION", "Export the software bill of materials (SBOM) of a repository from its dependency graph, as an SPDX JSON document. Use list_dependencies for a summary of the packages instead.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_SBOM_USER_TITLE", "Get repository SBOM"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			sbom, result, err := fetchSBOM(ctx, getClient, owner, repo)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			return mcp.NewToolResultText(string(sbom)), nil
+		}
+}
+
+// ListDependencies creates a tool to list the packages a repository depends on.
+func ListDependencies(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_dependencies",
+			mcp.WithDescription(t("TOOL_LIST_DEPENDENCIES_DESCRIPTION", "List the packages a repository depends on according to its dependency graph, with their ecosystem, version, license and package URL")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPENDENCIES_USER_TITLE", "List repository dependencies"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ecosystem",
+				mcp.Description("Only list packages of this ecosystem, such as npm, pip, maven, go or actions"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ecosystem, err := OptionalParam[string](request, "ecosystem")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			sbom, result, err := fetchSBOM(ctx, getClient, owner, repo)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			var document spdxDocument
+			if err := json.Unmarshal(sbom, &document); err != nil {
+				return nil, fmt.Errorf("failed to parse SBOM: %w", err)
+			}
+
+			dependencies := make([]Dependency, 0, len(document.Packages))
+			for _, pkg := range document.Packages {
+				// The packages the document describes are the repository itself
+				if slices.Contains(document.DocumentDescribes, pkg.SPDXID) {
+					continue
+				}
+				dependency := newDependency(pkg)
+				if ecosystem != "" && !strings.EqualFold(dependency.Ecosystem, ecosystem) {
+					continue
+				}
+				dependencies = append(dependencies, dependency)
+			}
+
+			return MarshalledTextResult(dependencies), nil
+		}
+}
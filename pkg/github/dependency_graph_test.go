@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSBOM is an SBOM as GitHub exports it, trimmed down to a few packages
+var mockSBOM = map[string]any{
+	"sbom": map[string]any{
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"spdxVersion":       "SPDX-2.3",
+		"name":              "com.github.owner/repo",
+		"documentDescribes": []string{"SPDXRef-com.github.owner-repo"},
+		"packages": []map[string]any{
+			{
+				"SPDXID":      "SPDXRef-com.github.owner-repo",
+				"name":        "com.github.owner/repo",
+				"versionInfo": "",
+			},
+			{
+				"SPDXID":           "SPDXRef-npm-lodash-4.17.21",
+				"name":             "npm:lodash",
+				"versionInfo":      "4.17.21",
+				"licenseConcluded": "MIT",
+				"externalRefs": []map[string]any{
+					{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/lodash@4.17.21"},
+				},
+			},
+			{
+				"SPDXID":           "SPDXRef-actions-actions-checkout-4",
+				"name":             "actions:actions/checkout",
+				"versionInfo":      "4",
+				"licenseConcluded": "NOASSERTION",
+				"externalRefs": []map[string]any{
+					{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:githubactions/actions/checkout@4"},
+				},
+			},
+		},
+		"relationships": []map[string]any{
+			{"relationshipType": "DEPENDS_ON", "spdxElementId": "SPDXRef-com.github.owner-repo", "relatedSpdxElement": "SPDXRef-npm-lodash-4.17.21"},
+		},
+	},
+}
+
+func Test_GetSBOM(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetSBOM(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_sbom", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "SBOM exported",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo/dependency-graph/sbom").andThen(
+						mockResponse(t, http.StatusOK, mockSBOM),
+					),
+				),
+			),
+		},
+		{
+			name: "dependency graph disabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					mockResponse(t, http.StatusForbidden, `{"message": "Dependency graph is disabled for this repository."}`),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "the dependency graph of owner/repo is disabled",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get SBOM",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetSBOM(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// The SPDX document is returned whole, including what go-github leaves out
+			var document map[string]any
+			err = json.Unmarshal([]byte(textContent.Text), &document)
+			require.NoError(t, err)
+			assert.Equal(t, "SPDX-2.3", document["spdxVersion"])
+			assert.Contains(t, document, "relationships")
+			assert.Len(t, document["packages"], 3)
+		})
+	}
+}
+
+func Test_ListDependencies(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListDependencies(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_dependencies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "ecosystem")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	lodash := Dependency{
+		Ecosystem: "npm",
+		Name:      "lodash",
+		Version:   "4.17.21",
+		License:   "MIT",
+		PURL:      "pkg:npm/lodash@4.17.21",
+	}
+	checkout := Dependency{
+		Ecosystem: "actions",
+		Name:      "actions/checkout",
+		Version:   "4",
+		PURL:      "pkg:githubactions/actions/checkout@4",
+	}
+
+	tests := []struct {
+		name                 string
+		requestArgs          map[string]interface{}
+		expectedDependencies []Dependency
+	}{
+		{
+			name: "all dependencies",
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedDependencies: []Dependency{lodash, checkout},
+		},
+		{
+			name: "dependencies of one ecosystem",
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ecosystem": "NPM",
+			},
+			expectedDependencies: []Dependency{lodash},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposDependencyGraphSbomByOwnerByRepo,
+					mockSBOM,
+				),
+			))
+			_, handler := ListDependencies(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedDependencies []Dependency
+			err = json.Unmarshal([]byte(textContent.Text), &returnedDependencies)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDependencies, returnedDependencies)
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// deployKeyTypes are the SSH public key algorithms GitHub accepts for deploy keys
+var deployKeyTypes = map[string]bool{
+	"ssh-rsa":                            true,
+	"ssh-ed25519":                        true,
+	"ecdsa-sha2-nistp256":                true,
+	"ecdsa-sha2-nistp384":                true,
+	"ecdsa-sha2-nistp521":                true,
+	"sk-ssh-ed25519@openssh.com":         true,
+	"sk-ecdsa-sha2-nistp256@openssh.com": true,
+}
+
+// validateDeployPublicKey checks that key is a single line OpenSSH public key, as found in a .pub file.
+// Errors never quote the key, so that a private key pasted by mistake does not end up in a tool result.
+func validateDeployPublicKey(key string) error {
+	if strings.Contains(key, "PRIVATE KEY") {
+		return fmt.Errorf("key looks like a private key, deploy keys take the public key only (the contents of the .pub file)")
+	}
+
+	fields := strings.Fields(key)
+	if len(fields) < 2 || strings.ContainsAny(strings.TrimSpace(key), "\r\n") {
+		return fmt.Errorf("key must be an OpenSSH public key of the form '<type> <base64 data> [comment]'")
+	}
+
+	keyType := fields[0]
+	if !deployKeyTypes[keyType] {
+		return fmt.Errorf("unsupported key type %q", keyType)
+	}
+
+	// The key data starts with the key type again, as a length prefixed string
+	data, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return fmt.Errorf("key data is not valid base64")
+	}
+	if len(data) < 4 {
+		return fmt.Errorf("key data is truncated")
+	}
+	length := binary.BigEndian.Uint32(data)
+	if uint64(len(data)-4) < uint64(length) || string(data[4:4+length]) != keyType {
+		return fmt.Errorf("key data does not match key type %q", keyType)
+	}
+	return nil
+}
+
+// MinimalDeployKey describes a deploy key without its key material.
+type MinimalDeployKey struct {
+	ID        int64      `json:"id"`
+	Title     string     `json:"title"`
+	ReadOnly  bool       `json:"read_only"`
+	Verified  bool       `json:"verified"`
+	AddedBy   string     `json:"added_by,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+}
+
+func newMinimalDeployKey(key *github.Key) MinimalDeployKey {
+	minimal := MinimalDeployKey{
+		ID:       key.GetID(),
+		Title:    key.GetTitle(),
+		ReadOnly: key.GetReadOnly(),
+		Verified: key.GetVerified(),
+		AddedBy:  key.GetAddedBy(),
+	}
+	if key.CreatedAt != nil {
+		minimal.CreatedAt = &key.CreatedAt.Time
+	}
+	if key.LastUsed != nil {
+		minimal.LastUsed = &key.LastUsed.Time
+	}
+	return minimal
+}
+
+// ListDeployKeys creates a tool to list the deploy keys of a repository.
+func ListDeployKeys(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_deploy_keys",
+			mcp.WithDescription(t("TOOL_LIST_DEPLOY_KEYS_DESCRIPTION", "List the deploy keys of a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DEPLOY_KEYS_USER_TITLE", "List deploy keys"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			keys, resp, err := client.Repositories.ListKeys(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list deploy keys: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list deploy keys: %s", string(body))), nil
+			}
+
+			minimalKeys := make([]MinimalDeployKey, 0, len(keys))
+			for _, key := range keys {
+				minimalKeys = append(minimalKeys, newMinimalDeployKey(key))
+			}
+
+			return MarshalledListResult(minimalKeys, resp, nil), nil
+		}
+}
+
+// CreateDeployKey creates a tool to add a deploy key to a repository.
+func CreateDeployKey(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_deploy_key",
+			mcp.WithDescription(t("TOOL_CREATE_DEPLOY_KEY_DESCRIPTION", "Add an SSH deploy key to a GitHub repository. Takes the public key only, never the private key")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_DEPLOY_KEY_USER_TITLE", "Create deploy key"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Name of the key"),
+			),
+			mcp.WithString("key",
+				mcp.Required(),
+				mcp.Description("OpenSSH public key, e.g. the contents of id_ed25519.pub"),
+			),
+			mcp.WithBoolean("read_only",
+				mcp.Description("Only allow the key to read the repository, defaults to true"),
+				mcp.DefaultBool(true),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			title, err := requiredParam[string](request, "title")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			key, err := requiredParam[string](request, "key")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateDeployPublicKey(key); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid deploy key: %s", err)), nil
+			}
+			readOnly := true
+			if _, ok := request.GetArguments()["read_only"]; ok {
+				readOnly, err = OptionalParam[bool](request, "read_only")
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Repositories.CreateKey(ctx, owner, repo, &github.Key{
+				Title:    github.Ptr(title),
+				Key:      github.Ptr(strings.TrimSpace(key)),
+				ReadOnly: github.Ptr(readOnly),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create deploy key: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create deploy key: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalDeployKey(created)), nil
+		}
+}
+
+// DeleteDeployKey creates a tool to remove a deploy key from a repository.
+func DeleteDeployKey(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_deploy_key",
+			mcp.WithDescription(t("TOOL_DELETE_DEPLOY_KEY_DESCRIPTION", "Remove a deploy key from a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_DEPLOY_KEY_USER_TITLE", "Delete deploy key"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("key_id",
+				mcp.Required(),
+				mcp.Description("ID of the deploy key, as returned by list_deploy_keys"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			keyID, err := RequiredInt(request, "key_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Repositories.DeleteKey(ctx, owner, repo, int64(keyID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete deploy key: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to delete deploy key: %s", string(body))), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("deploy key %d deleted", keyID)), nil
+		}
+}
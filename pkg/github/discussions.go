@@ -0,0 +1,210 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// discussionReactionGroup counts the reactions of one kind on a discussion comment.
+type discussionReactionGroup struct {
+	Content  string
+	Reactors struct {
+		TotalCount int
+	}
+}
+
+// discussionReplyNode is a reply to a discussion comment. Replies cannot be replied to themselves.
+type discussionReplyNode struct {
+	ID     githubv4.ID
+	Author struct {
+		Login string
+	}
+	Body           string
+	URL            string
+	CreatedAt      githubv4.DateTime
+	UpvoteCount    int
+	ReactionGroups []discussionReactionGroup
+}
+
+type discussionCommentNode struct {
+	ID     githubv4.ID
+	Author struct {
+		Login string
+	}
+	Body           string
+	URL            string
+	CreatedAt      githubv4.DateTime
+	IsAnswer       bool
+	UpvoteCount    int
+	ReactionGroups []discussionReactionGroup
+	Replies        struct {
+		TotalCount int
+		Nodes      []discussionReplyNode
+	} `graphql:"replies(first: 50)"`
+}
+
+type discussionCommentsQuery struct {
+	Repository struct {
+		Discussion struct {
+			Title    string
+			Comments struct {
+				TotalCount int
+				Nodes      []discussionCommentNode
+				PageInfo   struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			} `graphql:"comments(first: $first, after: $after)"`
+		} `graphql:"discussion(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// DiscussionComment is a comment on a discussion, or a reply to one.
+type DiscussionComment struct {
+	ID        string         `json:"id"`
+	Author    string         `json:"author,omitempty"`
+	Body      string         `json:"body"`
+	URL       string         `json:"url"`
+	CreatedAt time.Time      `json:"created_at"`
+	IsAnswer  bool           `json:"is_answer,omitempty"`
+	Upvotes   int            `json:"upvotes"`
+	Reactions map[string]int `json:"reactions,omitempty"`
+	// ReplyCount is the total number of replies, which may be more than the replies included
+	ReplyCount int                 `json:"reply_count,omitempty"`
+	Replies    []DiscussionComment `json:"replies,omitempty"`
+}
+
+// discussionReactions maps reaction contents such as THUMBS_UP to their counts, leaving out reactions nobody used
+func discussionReactions(groups []discussionReactionGroup) map[string]int {
+	reactions := make(map[string]int)
+	for _, group := range groups {
+		if group.Reactors.TotalCount > 0 {
+			reactions[strings.ToLower(group.Content)] = group.Reactors.TotalCount
+		}
+	}
+	if len(reactions) == 0 {
+		return nil
+	}
+	return reactions
+}
+
+func newDiscussionComment(node discussionCommentNode) DiscussionComment {
+	comment := DiscussionComment{
+		ID:         fmt.Sprint(node.ID),
+		Author:     node.Author.Login,
+		Body:       node.Body,
+		URL:        node.URL,
+		CreatedAt:  node.CreatedAt.Time,
+		IsAnswer:   node.IsAnswer,
+		Upvotes:    node.UpvoteCount,
+		Reactions:  discussionReactions(node.ReactionGroups),
+		ReplyCount: node.Replies.TotalCount,
+	}
+	for _, reply := range node.Replies.Nodes {
+		comment.Replies = append(comment.Replies, DiscussionComment{
+			ID:        fmt.Sprint(reply.ID),
+			Author:    reply.Author.Login,
+			Body:      reply.Body,
+			URL:       reply.URL,
+			CreatedAt: reply.CreatedAt.Time,
+			Upvotes:   reply.UpvoteCount,
+			Reactions: discussionReactions(reply.ReactionGroups),
+		})
+	}
+	return comment
+}
+
+// ListDiscussionComments creates a tool to list the comments of a discussion along with their replies.
+func ListDiscussionComments(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_discussion_comments",
+			mcp.WithDescription(t("TOOL_LIST_DISCUSSION_COMMENTS_DESCRIPTION", "List the comments of a GitHub Discussion, oldest first, with their replies and reaction counts. Up to 50 replies are included per comment")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_DISCUSSION_COMMENTS_USER_TITLE", "List discussion comments"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("discussion_number",
+				mcp.Required(),
+				mcp.Description("Discussion number"),
+			),
+			mcp.WithNumber("first",
+				mcp.Description("Number of comments to return (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor from a previous call to get the next page of comments"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner            string
+				Repo             string
+				DiscussionNumber int32 `mapstructure:"discussion_number"`
+				First            int32
+				After            string
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Owner == "" || params.Repo == "" {
+				return mcp.NewToolResultError("missing required parameter: owner and repo"), nil
+			}
+			if params.DiscussionNumber <= 0 {
+				return mcp.NewToolResultError("missing required parameter: discussion_number"), nil
+			}
+			if params.First == 0 {
+				params.First = 30
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			variables := map[string]any{
+				"owner":  githubv4.String(params.Owner),
+				"repo":   githubv4.String(params.Repo),
+				"number": githubv4.Int(params.DiscussionNumber),
+				"first":  githubv4.Int(params.First),
+				"after":  (*githubv4.String)(nil),
+			}
+			if params.After != "" {
+				variables["after"] = githubv4.String(params.After)
+			}
+
+			var query discussionCommentsQuery
+			if err := client.Query(ctx, &query, variables); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list discussion comments: %v", err)), nil
+			}
+
+			discussion := query.Repository.Discussion
+			comments := make([]DiscussionComment, 0, len(discussion.Comments.Nodes))
+			for _, node := range discussion.Comments.Nodes {
+				comments = append(comments, newDiscussionComment(node))
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"title":         discussion.Title,
+				"comments":      comments,
+				"total_count":   discussion.Comments.TotalCount,
+				"has_next_page": discussion.Comments.PageInfo.HasNextPage,
+				"end_cursor":    discussion.Comments.PageInfo.EndCursor,
+			}), nil
+		}
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListDiscussionComments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListDiscussionComments(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_discussion_comments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "discussion_number")
+	assert.Contains(t, tool.InputSchema.Properties, "first")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "discussion_number"})
+
+	discussionResponse := map[string]any{
+		"title": "How do I configure toolsets?",
+		"comments": map[string]any{
+			"totalCount": 2,
+			"nodes": []any{
+				map[string]any{
+					"id":          "DC_answer",
+					"author":      map[string]any{"login": "octocat"},
+					"body":        "Use the --toolsets flag",
+					"url":         "https://github.com/owner/repo/discussions/5#discussioncomment-1",
+					"createdAt":   "2024-05-01T10:00:00Z",
+					"isAnswer":    true,
+					"upvoteCount": 4,
+					"reactionGroups": []any{
+						map[string]any{"content": "THUMBS_UP", "reactors": map[string]any{"totalCount": 3}},
+						map[string]any{"content": "CONFUSED", "reactors": map[string]any{"totalCount": 0}},
+					},
+					"replies": map[string]any{
+						"totalCount": 1,
+						"nodes": []any{
+							map[string]any{
+								"id":             "DC_reply",
+								"author":         map[string]any{"login": "hubot"},
+								"body":           "Thanks!",
+								"url":            "https://github.com/owner/repo/discussions/5#discussioncomment-2",
+								"createdAt":      "2024-05-01T11:00:00Z",
+								"upvoteCount":    0,
+								"reactionGroups": []any{},
+							},
+						},
+					},
+				},
+			},
+			"pageInfo": map[string]any{
+				"hasNextPage": true,
+				"endCursor":   "cursor-1",
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "comments with replies",
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"discussion_number": float64(5),
+				"after":             "cursor-0",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					discussionCommentsQuery{},
+					map[string]any{
+						"owner":  githubv4.String("owner"),
+						"repo":   githubv4.String("repo"),
+						"number": githubv4.Int(5),
+						"first":  githubv4.Int(30),
+						"after":  githubv4.String("cursor-0"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{"discussion": discussionResponse},
+					}),
+				),
+			),
+		},
+		{
+			name: "missing discussion number",
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			mockedClient:    githubv4mock.NewMockedHTTPClient(),
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: discussion_number",
+		},
+		{
+			name: "discussion not found",
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"discussion_number": float64(99),
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					discussionCommentsQuery{},
+					map[string]any{
+						"owner":  githubv4.String("owner"),
+						"repo":   githubv4.String("repo"),
+						"number": githubv4.Int(99),
+						"first":  githubv4.Int(30),
+						"after":  (*githubv4.String)(nil),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to a Discussion with the number of 99."),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "failed to list discussion comments",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ListDiscussionComments(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				Title       string              `json:"title"`
+				Comments    []DiscussionComment `json:"comments"`
+				TotalCount  int                 `json:"total_count"`
+				HasNextPage bool                `json:"has_next_page"`
+				EndCursor   string              `json:"end_cursor"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "How do I configure toolsets?", returned.Title)
+			assert.Equal(t, 2, returned.TotalCount)
+			assert.True(t, returned.HasNextPage)
+			assert.Equal(t, "cursor-1", returned.EndCursor)
+			require.Len(t, returned.Comments, 1)
+			comment := returned.Comments[0]
+			assert.Equal(t, "octocat", comment.Author)
+			assert.True(t, comment.IsAnswer)
+			assert.Equal(t, 4, comment.Upvotes)
+			assert.Equal(t, map[string]int{"thumbs_up": 3}, comment.Reactions)
+			assert.Equal(t, 1, comment.ReplyCount)
+			require.Len(t, comment.Replies, 1)
+			assert.Equal(t, "hubot", comment.Replies[0].Author)
+			assert.Equal(t, "Thanks!", comment.Replies[0].Body)
+			assert.Nil(t, comment.Replies[0].Reactions)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type downloadClientContextKey struct{}
+
+// ContextWithDownloadClient returns a context making the tools download artifacts and archives with client.
+func ContextWithDownloadClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, downloadClientContextKey{}, client)
+}
+
+// downloadClientFromContext returns the client to download artifacts and archives with, http.DefaultClient
+// unless configured.
+func downloadClientFromContext(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(downloadClientContextKey{}).(*http.Client); ok && client != nil {
+		return client
+	}
+	return http.DefaultClient
+}
+
+// DownloadClientMiddleware returns a tool handler middleware making the tools download artifacts and archives
+// with client, so that these downloads go through the same transport as the API calls. Download URLs carry
+// their own credentials and are not GitHub API URLs, so client must not send the GitHub token.
+func DownloadClientMiddleware(client *http.Client) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(ContextWithDownloadClient(ctx, client), request)
+		}
+	}
+}
+
+// downloadCapped fetches downloadURL with the download client of ctx, returning nil content when it is
+// larger than limit bytes. GitHub only knows the size of a generated archive once it is generated, so the
+// download stops as soon as it goes over.
+func downloadCapped(ctx context.Context, downloadURL string, limit int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := downloadClientFromContext(ctx).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if resp.ContentLength > int64(limit) {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read download: %w", err)
+	}
+	if len(content) > limit {
+		return nil, nil
+	}
+	return content, nil
+}
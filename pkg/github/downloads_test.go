@@ -0,0 +1,67 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type downloadRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f downloadRoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_DownloadCapped(t *testing.T) {
+	content := []byte("archive")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			// Without a length, like codeload streams archives
+			w.Header().Set("Transfer-Encoding", "chunked")
+			_, _ = w.Write(bytes.Repeat([]byte("a"), 11))
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		default:
+			_, _ = w.Write(content)
+		}
+	}))
+	defer ts.Close()
+
+	// Downloads go through the client the middleware configures
+	var requested []string
+	client := &http.Client{Transport: downloadRoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+	var ctx context.Context
+	handler := DownloadClientMiddleware(client)(func(c context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx = c
+		return nil, nil
+	})
+	_, _ = handler(context.Background(), mcp.CallToolRequest{})
+
+	got, err := downloadCapped(ctx, ts.URL+"/small", 10)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+
+	got, err = downloadCapped(ctx, ts.URL+"/large", 10)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = downloadCapped(ctx, ts.URL+"/gone", 10)
+	assert.ErrorContains(t, err, "unexpected status 410 Gone")
+
+	assert.Equal(t, []string{"/small", "/large", "/gone"}, requested)
+
+	// Without the middleware, the default client is used
+	got, err = downloadCapped(context.Background(), ts.URL+"/small", 10)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+}
@@ -0,0 +1,426 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Deployment branch policies of an environment, as accepted by create_or_update_environment
+const (
+	environmentBranchPolicyAll       = "all"
+	environmentBranchPolicyProtected = "protected_branches"
+	environmentBranchPolicyCustom    = "custom_branch_policies"
+)
+
+// environmentReviewerTypes are the kinds of required reviewers of an environment
+var environmentReviewerTypes = []string{"User", "Team"}
+
+// maxEnvironmentWaitTimer is the longest wait timer GitHub allows, in minutes
+const maxEnvironmentWaitTimer = 43200
+
+// maxEnvironmentReviewers is the number of required reviewers GitHub allows per environment
+const maxEnvironmentReviewers = 6
+
+// MinimalEnvironment is the configuration of a deployment environment and its protection rules.
+type MinimalEnvironment struct {
+	Name                   string                       `json:"name"`
+	HTMLURL                string                       `json:"html_url,omitempty"`
+	WaitTimer              int                          `json:"wait_timer,omitempty"`
+	Reviewers              []MinimalEnvironmentReviewer `json:"reviewers,omitempty"`
+	PreventSelfReview      bool                         `json:"prevent_self_review,omitempty"`
+	CanAdminsBypass        bool                         `json:"can_admins_bypass"`
+	DeploymentBranchPolicy string                       `json:"deployment_branch_policy"`
+	CreatedAt              *time.Time                   `json:"created_at,omitempty"`
+	UpdatedAt              *time.Time                   `json:"updated_at,omitempty"`
+}
+
+// MinimalEnvironmentReviewer is a user or team required to approve deployments to an environment.
+type MinimalEnvironmentReviewer struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+	// Name is the login of a user, or the slug of a team
+	Name string `json:"name,omitempty"`
+}
+
+func newMinimalEnvironment(env *github.Environment) MinimalEnvironment {
+	minimal := MinimalEnvironment{
+		Name:                   env.GetName(),
+		HTMLURL:                env.GetHTMLURL(),
+		CanAdminsBypass:        env.GetCanAdminsBypass(),
+		DeploymentBranchPolicy: environmentBranchPolicy(env.DeploymentBranchPolicy),
+	}
+	for _, rule := range env.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			minimal.WaitTimer = rule.GetWaitTimer()
+		case "required_reviewers":
+			minimal.PreventSelfReview = rule.GetPreventSelfReview()
+			for _, reviewer := range rule.Reviewers {
+				minimal.Reviewers = append(minimal.Reviewers, newMinimalEnvironmentReviewer(reviewer))
+			}
+		}
+	}
+	if env.CreatedAt != nil {
+		minimal.CreatedAt = &env.CreatedAt.Time
+	}
+	if env.UpdatedAt != nil {
+		minimal.UpdatedAt = &env.UpdatedAt.Time
+	}
+	return minimal
+}
+
+func newMinimalEnvironmentReviewer(reviewer *github.RequiredReviewer) MinimalEnvironmentReviewer {
+	minimal := MinimalEnvironmentReviewer{Type: reviewer.GetType()}
+	switch r := reviewer.Reviewer.(type) {
+	case *github.User:
+		minimal.ID = r.GetID()
+		minimal.Name = r.GetLogin()
+	case *github.Team:
+		minimal.ID = r.GetID()
+		minimal.Name = r.GetSlug()
+	}
+	return minimal
+}
+
+// environmentBranchPolicy names the deployment branch policy of an environment
+func environmentBranchPolicy(policy *github.BranchPolicy) string {
+	switch {
+	case policy.GetProtectedBranches():
+		return environmentBranchPolicyProtected
+	case policy.GetCustomBranchPolicies():
+		return environmentBranchPolicyCustom
+	default:
+		return environmentBranchPolicyAll
+	}
+}
+
+// parseEnvironmentReviewers decodes the reviewers argument of create_or_update_environment
+func parseEnvironmentReviewers(value any) ([]*github.EnvReviewers, error) {
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("reviewers must be an array of objects with a type and an id")
+	}
+	if len(list) > maxEnvironmentReviewers {
+		return nil, fmt.Errorf("an environment can have at most %d reviewers", maxEnvironmentReviewers)
+	}
+
+	reviewers := make([]*github.EnvReviewers, 0, len(list))
+	for i, item := range list {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("reviewers[%d] must be an object with a type and an id", i)
+		}
+		reviewerType, _ := obj["type"].(string)
+		if !slices.Contains(environmentReviewerTypes, reviewerType) {
+			return nil, fmt.Errorf("reviewers[%d].type must be one of %v", i, environmentReviewerTypes)
+		}
+		id, ok := obj["id"].(float64)
+		if !ok || id <= 0 || id != float64(int64(id)) {
+			return nil, fmt.Errorf("reviewers[%d].id must be the ID of a user or team", i)
+		}
+		reviewers = append(reviewers, &github.EnvReviewers{
+			Type: github.Ptr(reviewerType),
+			ID:   github.Ptr(int64(id)),
+		})
+	}
+	return reviewers, nil
+}
+
+// ListEnvironments creates a tool to list the deployment environments of a repository.
+func ListEnvironments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_environments",
+			mcp.WithDescription(t("TOOL_LIST_ENVIRONMENTS_DESCRIPTION", "List the deployment environments of a GitHub repository, with their protection rules")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENVIRONMENTS_USER_TITLE", "List environments"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			environments, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, &github.EnvironmentListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list environments: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list environments: %s", string(body))), nil
+			}
+
+			minimal := make([]MinimalEnvironment, 0, len(environments.Environments))
+			for _, env := range environments.Environments {
+				minimal = append(minimal, newMinimalEnvironment(env))
+			}
+			return MarshalledListResult(minimal, resp, environments.TotalCount), nil
+		}
+}
+
+// GetEnvironment creates a tool to get a deployment environment of a repository.
+func GetEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_environment",
+			mcp.WithDescription(t("TOOL_GET_ENVIRONMENT_DESCRIPTION", "Get a deployment environment of a GitHub repository, with its required reviewers, wait timer and deployment branch policy")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ENVIRONMENT_USER_TITLE", "Get environment"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the environment"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get environment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get environment: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalEnvironment(env)), nil
+		}
+}
+
+// CreateOrUpdateEnvironment creates a tool to create a deployment environment of a repository, or update
+// its protection rules.
+func CreateOrUpdateEnvironment(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_or_update_environment",
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_DESCRIPTION", "Create a deployment environment in a GitHub repository, or update the protection rules of an existing one. Settings left out keep their current value, or GitHub's default for a new environment")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_OR_UPDATE_ENVIRONMENT_USER_TITLE", "Create or update environment"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the environment"),
+			),
+			mcp.WithNumber("wait_timer",
+				mcp.Description(fmt.Sprintf("Minutes to wait before deployments to the environment proceed, 0 to %d. 0 removes the wait timer", maxEnvironmentWaitTimer)),
+				mcp.Min(0),
+				mcp.Max(maxEnvironmentWaitTimer),
+			),
+			mcp.WithArray("reviewers",
+				mcp.Description(fmt.Sprintf("Users or teams that must approve deployments to the environment, at most %d, e.g. [{\"type\": \"User\", \"id\": 1}, {\"type\": \"Team\", \"id\": 2}]. An empty array removes all reviewers", maxEnvironmentReviewers)),
+				mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"type": map[string]any{
+							"type": "string",
+							"enum": environmentReviewerTypes,
+						},
+						"id": map[string]any{
+							"type":        "number",
+							"description": "ID of the user or team",
+						},
+					},
+					"required": []string{"type", "id"},
+				}),
+			),
+			mcp.WithBoolean("prevent_self_review",
+				mcp.Description("Prevent the user who triggered a deployment from approving it"),
+			),
+			mcp.WithBoolean("can_admins_bypass",
+				mcp.Description("Allow repository administrators to bypass the protection rules"),
+			),
+			mcp.WithString("deployment_branch_policy",
+				mcp.Description("Branches that can deploy to the environment: all branches, protected_branches only, or the custom_branch_policies name patterns configured in the repository settings"),
+				mcp.Enum(environmentBranchPolicyAll, environmentBranchPolicyProtected, environmentBranchPolicyCustom),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			waitTimer, hasWaitTimer, err := OptionalParamOK[float64](request, "wait_timer")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if hasWaitTimer && (waitTimer < 0 || waitTimer > maxEnvironmentWaitTimer || waitTimer != float64(int(waitTimer))) {
+				return mcp.NewToolResultError(fmt.Sprintf("wait_timer must be a whole number of minutes between 0 and %d", maxEnvironmentWaitTimer)), nil
+			}
+			var reviewers []*github.EnvReviewers
+			reviewersArg, hasReviewers := request.GetArguments()["reviewers"]
+			if hasReviewers {
+				if reviewers, err = parseEnvironmentReviewers(reviewersArg); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+			preventSelfReview, hasPreventSelfReview, err := OptionalParamOK[bool](request, "prevent_self_review")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			canAdminsBypass, hasCanAdminsBypass, err := OptionalParamOK[bool](request, "can_admins_bypass")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branchPolicy, err := OptionalParam[string](request, "deployment_branch_policy")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if branchPolicy != "" && !slices.Contains([]string{environmentBranchPolicyAll, environmentBranchPolicyProtected, environmentBranchPolicyCustom}, branchPolicy) {
+				return mcp.NewToolResultError(fmt.Sprintf("unknown deployment_branch_policy %q", branchPolicy)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The API replaces the whole configuration, so start from the current one to keep what was left out
+			update := &github.CreateUpdateEnvironment{}
+			existing, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, name)
+			switch {
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+				_ = resp.Body.Close()
+			case err != nil:
+				return nil, fmt.Errorf("failed to get environment: %w", err)
+			default:
+				_ = resp.Body.Close()
+				current := newMinimalEnvironment(existing)
+				update.WaitTimer = github.Ptr(current.WaitTimer)
+				update.CanAdminsBypass = github.Ptr(current.CanAdminsBypass)
+				update.PreventSelfReview = github.Ptr(current.PreventSelfReview)
+				update.DeploymentBranchPolicy = existing.DeploymentBranchPolicy
+				for _, reviewer := range current.Reviewers {
+					update.Reviewers = append(update.Reviewers, &github.EnvReviewers{
+						Type: github.Ptr(reviewer.Type),
+						ID:   github.Ptr(reviewer.ID),
+					})
+				}
+			}
+
+			if hasWaitTimer {
+				update.WaitTimer = github.Ptr(int(waitTimer))
+			}
+			if hasReviewers {
+				update.Reviewers = reviewers
+			}
+			if hasPreventSelfReview {
+				update.PreventSelfReview = github.Ptr(preventSelfReview)
+			}
+			if hasCanAdminsBypass {
+				update.CanAdminsBypass = github.Ptr(canAdminsBypass)
+			}
+			switch branchPolicy {
+			case environmentBranchPolicyAll:
+				update.DeploymentBranchPolicy = nil
+			case environmentBranchPolicyProtected:
+				update.DeploymentBranchPolicy = &github.BranchPolicy{
+					ProtectedBranches:    github.Ptr(true),
+					CustomBranchPolicies: github.Ptr(false),
+				}
+			case environmentBranchPolicyCustom:
+				update.DeploymentBranchPolicy = &github.BranchPolicy{
+					ProtectedBranches:    github.Ptr(false),
+					CustomBranchPolicies: github.Ptr(true),
+				}
+			}
+
+			env, resp, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repo, name, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create or update environment: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create or update environment: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalEnvironment(env)), nil
+		}
+}
@@ -0,0 +1,317 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockProductionEnvironment is an environment with every kind of protection rule, as returned by the API
+var mockProductionEnvironment = map[string]any{
+	"id":                123,
+	"name":              "production",
+	"html_url":          "https://github.com/owner/repo/deployments/activity_log?environments_filter=production",
+	"can_admins_bypass": false,
+	"protection_rules": []map[string]any{
+		{"id": 1, "type": "wait_timer", "wait_timer": 30},
+		{
+			"id":                  2,
+			"type":                "required_reviewers",
+			"prevent_self_review": true,
+			"reviewers": []map[string]any{
+				{"type": "User", "reviewer": map[string]any{"id": 1, "login": "octocat"}},
+				{"type": "Team", "reviewer": map[string]any{"id": 2, "slug": "deployers"}},
+			},
+		},
+		{"id": 3, "type": "branch_policy"},
+	},
+	"deployment_branch_policy": map[string]any{
+		"protected_branches":     true,
+		"custom_branch_policies": false,
+	},
+}
+
+var expectedProductionEnvironment = MinimalEnvironment{
+	Name:      "production",
+	HTMLURL:   "https://github.com/owner/repo/deployments/activity_log?environments_filter=production",
+	WaitTimer: 30,
+	Reviewers: []MinimalEnvironmentReviewer{
+		{Type: "User", ID: 1, Name: "octocat"},
+		{Type: "Team", ID: 2, Name: "deployers"},
+	},
+	PreventSelfReview:      true,
+	DeploymentBranchPolicy: "protected_branches",
+}
+
+func Test_ListEnvironments(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnvironments(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_environments", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposEnvironmentsByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, map[string]any{
+					"total_count": 2,
+					"environments": []map[string]any{
+						mockProductionEnvironment,
+						{"id": 124, "name": "staging", "can_admins_bypass": true},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListEnvironments(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+
+	var environments []MinimalEnvironment
+	getListResult(t, getTextResult(t, result).Text, &environments)
+	assert.Equal(t, []MinimalEnvironment{
+		expectedProductionEnvironment,
+		{Name: "staging", CanAdminsBypass: true, DeploymentBranchPolicy: "all"},
+	}, environments)
+}
+
+func Test_GetEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_environment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "environment found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectPath(t, "/repos/owner/repo/environments/production").andThen(
+						mockResponse(t, http.StatusOK, mockProductionEnvironment),
+					),
+				),
+			),
+		},
+		{
+			name: "environment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get environment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetEnvironment(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "production",
+			}))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			var environment MinimalEnvironment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &environment))
+			assert.Equal(t, expectedProductionEnvironment, environment)
+		})
+	}
+}
+
+func Test_CreateOrUpdateEnvironment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateOrUpdateEnvironment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_or_update_environment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "wait_timer")
+	assert.Contains(t, tool.InputSchema.Properties, "reviewers")
+	assert.Contains(t, tool.InputSchema.Properties, "deployment_branch_policy")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	notFound := mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "create environment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer": float64(30),
+						"reviewers": []any{
+							map[string]any{"type": "User", "id": float64(1)},
+							map[string]any{"type": "Team", "id": float64(2)},
+						},
+						"can_admins_bypass":   true,
+						"prevent_self_review": true,
+						"deployment_branch_policy": map[string]any{
+							"protected_branches":     true,
+							"custom_branch_policies": false,
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockProductionEnvironment),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"name":       "production",
+				"wait_timer": float64(30),
+				"reviewers": []any{
+					map[string]any{"type": "User", "id": float64(1)},
+					map[string]any{"type": "Team", "id": float64(2)},
+				},
+				"prevent_self_review":      true,
+				"deployment_branch_policy": "protected_branches",
+			},
+		},
+		{
+			name: "update keeps the settings left out",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					mockResponse(t, http.StatusOK, mockProductionEnvironment),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposEnvironmentsByOwnerByRepoByEnvironmentName,
+					expectRequestBody(t, map[string]any{
+						"wait_timer": float64(0),
+						"reviewers": []any{
+							map[string]any{"type": "User", "id": float64(1)},
+							map[string]any{"type": "Team", "id": float64(2)},
+						},
+						"can_admins_bypass":        false,
+						"prevent_self_review":      true,
+						"deployment_branch_policy": nil,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockProductionEnvironment),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                    "owner",
+				"repo":                     "repo",
+				"name":                     "production",
+				"wait_timer":               float64(0),
+				"deployment_branch_policy": "all",
+			},
+		},
+		{
+			name:         "too many reviewers",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "production",
+				"reviewers": []any{
+					map[string]any{"type": "User", "id": float64(1)},
+					map[string]any{"type": "User", "id": float64(2)},
+					map[string]any{"type": "User", "id": float64(3)},
+					map[string]any{"type": "User", "id": float64(4)},
+					map[string]any{"type": "User", "id": float64(5)},
+					map[string]any{"type": "User", "id": float64(6)},
+					map[string]any{"type": "User", "id": float64(7)},
+				},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "at most 6 reviewers",
+		},
+		{
+			name:         "unknown reviewer type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"name":      "production",
+				"reviewers": []any{map[string]any{"type": "Bot", "id": float64(1)}},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "reviewers[0].type must be one of",
+		},
+		{
+			name:         "wait timer out of range",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"name":       "production",
+				"wait_timer": float64(50000),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "wait_timer must be a whole number of minutes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := CreateOrUpdateEnvironment(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var environment MinimalEnvironment
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &environment))
+			assert.Equal(t, expectedProductionEnvironment, environment)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrorCodeRepositoryUnavailableLegal is returned for repositories GitHub blocks for legal reasons,
+// such as a DMCA takedown.
+const ErrorCodeRepositoryUnavailableLegal = "repository_unavailable_legal"
+
+// GitHubErrorMiddleware turns GitHub API errors that a caller can act on into structured tool errors,
+// instead of the generic failure the tool would otherwise report. Other errors pass through unchanged.
+func GitHubErrorMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err == nil {
+			return result, nil
+		}
+		if legal := unavailableForLegalReasonsResult(err); legal != nil {
+			return legal, nil
+		}
+		return result, err
+	}
+}
+
+// unavailableForLegalReasonsResult maps a 451 response to a tool error carrying the GitHub request id,
+// which GitHub support needs to look into a takedown. It returns nil for any other error.
+func unavailableForLegalReasonsResult(err error) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusUnavailableForLegalReasons {
+		return nil
+	}
+
+	details := map[string]any{
+		"error":   ErrorCodeRepositoryUnavailableLegal,
+		"message": "This repository is unavailable for legal reasons, for example a DMCA takedown, and its contents cannot be accessed. Contact GitHub support with the request id if you believe this is a mistake.",
+	}
+	if requestID := ghErr.Response.Header.Get("X-GitHub-Request-Id"); requestID != "" {
+		details["request_id"] = requestID
+	}
+	if ghErr.Block != nil && ghErr.Block.Reason != "" {
+		details["reason"] = ghErr.Block.Reason
+	}
+
+	r, err := json.Marshal(details)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %s", ErrorCodeRepositoryUnavailableLegal, ghErr.Message))
+	}
+	return mcp.NewToolResultError(string(r))
+}
+
+// Codes of the recoverable errors SoftErrorsMiddleware returns as tool results.
+const (
+	SoftErrorNotFound         = "not_found"
+	SoftErrorValidationFailed = "validation_failed"
+	SoftErrorRateLimited      = "rate_limited"
+)
+
+// SoftError describes a recoverable GitHub error returned as the error field of a tool result.
+type SoftError struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status,omitempty"`
+	Message string `json:"message"`
+	// Errors are the field level details of a validation failure
+	Errors           []github.Error `json:"errors,omitempty"`
+	DocumentationURL string         `json:"documentation_url,omitempty"`
+	// RetryAfterSeconds is how long to wait before retrying a rate limited call
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"`
+}
+
+// SoftErrorsMiddleware returns recoverable GitHub errors, that is resources that were not found,
+// validation failures and rate limits, as a successful tool result with an error field. Some clients
+// abort on any failed tool call, this lets the model react to the error instead. Other errors pass
+// through unchanged.
+func SoftErrorsMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err == nil {
+			return result, nil
+		}
+		if softErr := newSoftError(err); softErr != nil {
+			return MarshalledTextResult(map[string]any{"error": softErr}), nil
+		}
+		return result, err
+	}
+}
+
+// newSoftError describes err when it is a recoverable GitHub error, and returns nil otherwise
+func newSoftError(err error) *SoftError {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		softErr := &SoftError{
+			Code:    SoftErrorRateLimited,
+			Message: err.Error(),
+		}
+		if rateLimitErr.Response != nil {
+			softErr.Status = rateLimitErr.Response.StatusCode
+		}
+		if wait := time.Until(rateLimitErr.Rate.Reset.Time); wait > 0 {
+			softErr.RetryAfterSeconds = int((wait + time.Second - 1) / time.Second)
+		}
+		return softErr
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		softErr := &SoftError{
+			Code:    SoftErrorRateLimited,
+			Message: err.Error(),
+		}
+		if abuseErr.Response != nil {
+			softErr.Status = abuseErr.Response.StatusCode
+		}
+		if retryAfter := abuseErr.GetRetryAfter(); retryAfter > 0 {
+			softErr.RetryAfterSeconds = int((retryAfter + time.Second - 1) / time.Second)
+		}
+		return softErr
+	}
+
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil {
+		return nil
+	}
+	var code string
+	switch ghErr.Response.StatusCode {
+	case http.StatusNotFound:
+		code = SoftErrorNotFound
+	case http.StatusUnprocessableEntity:
+		code = SoftErrorValidationFailed
+	default:
+		return nil
+	}
+	return &SoftError{
+		Code:             code,
+		Status:           ghErr.Response.StatusCode,
+		Message:          err.Error(),
+		Errors:           ghErr.Errors,
+		DocumentationURL: ghErr.DocumentationURL,
+	}
+}
@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GitHubErrorMiddleware(t *testing.T) {
+	unavailable := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-GitHub-Request-Id", "ABCD:1234:5678")
+		w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		_, _ = w.Write([]byte(`{"message": "Repository access blocked", "block": {"reason": "dmca", "created_at": "2024-01-01T00:00:00Z"}}`))
+	})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		expectError      bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "451 becomes a structured tool error",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					unavailable,
+				),
+			),
+			expectedResponse: map[string]any{
+				"error":      ErrorCodeRepositoryUnavailableLegal,
+				"request_id": "ABCD:1234:5678",
+				"reason":     "dmca",
+			},
+		},
+		{
+			name: "other errors pass through",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			})
+
+			result, err := GitHubErrorMiddleware(handler)(context.Background(), request)
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			for key, value := range tc.expectedResponse {
+				assert.Equal(t, value, response[key])
+			}
+			assert.Contains(t, response["message"], "legal reasons")
+		})
+	}
+}
+
+func Test_SoftErrorsMiddleware(t *testing.T) {
+	reset := time.Now().Add(90 * time.Second)
+	rateLimited := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded for user ID 1."}`))
+	})
+	secondaryRateLimited := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit.", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`))
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expectedError  SoftError
+	}{
+		{
+			name: "404 becomes a result with an error field",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found", "documentation_url": "https://docs.github.com/rest/issues/issues#get-an-issue"}`),
+				),
+			),
+			expectedError: SoftError{
+				Code:             SoftErrorNotFound,
+				Status:           http.StatusNotFound,
+				DocumentationURL: "https://docs.github.com/rest/issues/issues#get-an-issue",
+			},
+		},
+		{
+			name: "422 keeps the validation details",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed", "errors": [{"resource": "Issue", "field": "title", "code": "missing_field"}]}`),
+				),
+			),
+			expectedError: SoftError{
+				Code:   SoftErrorValidationFailed,
+				Status: http.StatusUnprocessableEntity,
+				Errors: []github.Error{{Resource: "Issue", Field: "title", Code: "missing_field"}},
+			},
+		},
+		{
+			name: "primary rate limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					rateLimited,
+				),
+			),
+			expectedError: SoftError{
+				Code:   SoftErrorRateLimited,
+				Status: http.StatusForbidden,
+			},
+		},
+		{
+			name: "secondary rate limit",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					secondaryRateLimited,
+				),
+			),
+			expectedError: SoftError{
+				Code:              SoftErrorRateLimited,
+				Status:            http.StatusForbidden,
+				RetryAfterSeconds: 30,
+			},
+		},
+		{
+			name: "other errors pass through",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Server Error"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			request := createMCPRequest(map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			})
+
+			result, err := SoftErrorsMiddleware(handler)(context.Background(), request)
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+
+			var response struct {
+				Error SoftError `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Contains(t, response.Error.Message, "failed to get issue")
+
+			if tc.expectedError.Code == SoftErrorRateLimited && tc.expectedError.RetryAfterSeconds == 0 {
+				// Counted down from the reset time, so only roughly known
+				assert.InDelta(t, 90, response.Error.RetryAfterSeconds, 5)
+				response.Error.RetryAfterSeconds = 0
+			}
+			response.Error.Message = ""
+			assert.Equal(t, tc.expectedError, response.Error)
+		})
+	}
+}
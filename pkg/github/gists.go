@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// GetGist creates a tool to get a gist and the content of its files.
+func GetGist(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_gist",
+			mcp.WithDescription(t("TOOL_GET_GIST_DESCRIPTION", "Get a gist, including the content of its files")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIST_USER_TITLE", "Get gist"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("gist_id",
+				mcp.Required(),
+				mcp.Description("The ID of the gist"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			gistID, err := requiredParam[string](request, "gist_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gist, resp, err := client.Gists.Get(ctx, gistID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get gist: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get gist: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(gist)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// ListGists creates a tool to list the gists of a user, or of the authenticated user.
+func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_gists",
+			mcp.WithDescription(t("TOOL_LIST_GISTS_DESCRIPTION", "List gists of a user, or of the authenticated user when no username is given")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_GISTS_USER_TITLE", "List gists"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("GitHub username, defaults to the authenticated user"),
+			),
+			mcp.WithString("since",
+				mcp.Description("Only show gists updated after the given time (ISO 8601 format)"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			since, err := OptionalParam[string](request, "since")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.GistListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			if since != "" {
+				sinceTime, err := time.Parse(time.RFC3339, since)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid since time format, should be RFC3339/ISO8601: %v", err)), nil
+				}
+				opts.Since = sinceTime
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gists, resp, err := client.Gists.List(ctx, username, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list gists: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list gists: %s", string(body))), nil
+			}
+
+			return MarshalledListResult(gists, resp, nil), nil
+		}
+}
+
+// CreateGist creates a tool to create a new gist.
+func CreateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_gist",
+			mcp.WithDescription(t("TOOL_CREATE_GIST_DESCRIPTION", "Create a new gist")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_GIST_USER_TITLE", "Create gist"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithObject("files",
+				mcp.Required(),
+				mcp.Description("Map of filename to file content"),
+				mcp.AdditionalProperties(map[string]any{"type": "string"}),
+			),
+			mcp.WithString("description",
+				mcp.Description("Description of the gist"),
+			),
+			mcp.WithBoolean("public",
+				mcp.Description("Whether the gist is public, defaults to secret"),
+			),
+			WithAllowSecrets(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			filesObj, ok := request.GetArguments()["files"].(map[string]any)
+			if !ok || len(filesObj) == 0 {
+				return mcp.NewToolResultError("files parameter must be a non-empty map of filename to content"), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			public, err := OptionalParam[bool](request, "public")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			files := make(map[github.GistFilename]github.GistFile, len(filesObj))
+			scanFiles := make([]secretScanFile, 0, len(filesObj))
+			for filename, content := range filesObj {
+				contentStr, ok := content.(string)
+				if !ok {
+					return mcp.NewToolResultError(fmt.Sprintf("content of file %s must be a string", filename)), nil
+				}
+				files[github.GistFilename(filename)] = github.GistFile{
+					Filename: github.Ptr(filename),
+					Content:  github.Ptr(contentStr),
+				}
+				scanFiles = append(scanFiles, secretScanFile{path: filename, content: contentStr})
+			}
+			if result, err := blockSecrets(ctx, request, scanFiles...); result != nil || err != nil {
+				return result, err
+			}
+
+			gist := &github.Gist{
+				Files:  files,
+				Public: github.Ptr(public),
+			}
+			if description != "" {
+				gist.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			createdGist, resp, err := client.Gists.Create(ctx, gist)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create gist: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create gist: %s", string(body))), nil
+			}
+
+			minimalResponse := map[string]any{
+				"id":       createdGist.GetID(),
+				"html_url": createdGist.GetHTMLURL(),
+				"public":   createdGist.GetPublic(),
+			}
+
+			r, err := json.Marshal(minimalResponse)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,342 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetGist(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGist(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_gist", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "gist_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"gist_id"})
+
+	mockGist := &github.Gist{
+		ID:      github.Ptr("aa5a315d61ae9438b18d"),
+		HTMLURL: github.Ptr("https://gist.github.com/aa5a315d61ae9438b18d"),
+		Files: map[github.GistFilename]github.GistFile{
+			"hello.md": {
+				Filename: github.Ptr("hello.md"),
+				Content:  github.Ptr("# Hello"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedGist   *github.Gist
+		expectedErrMsg string
+	}{
+		{
+			name: "successful gist fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGistsByGistId,
+					expectPath(t, "/gists/aa5a315d61ae9438b18d").andThen(
+						mockResponse(t, http.StatusOK, mockGist),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"gist_id": "aa5a315d61ae9438b18d",
+			},
+			expectError:  false,
+			expectedGist: mockGist,
+		},
+		{
+			name: "gist fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGistsByGistId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"gist_id": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get gist",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGist(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedGist github.Gist
+			err = json.Unmarshal([]byte(textContent.Text), &returnedGist)
+			require.NoError(t, err)
+			assert.Equal(t, *tc.expectedGist.ID, *returnedGist.ID)
+			assert.Equal(t, *tc.expectedGist.Files["hello.md"].Content, *returnedGist.Files["hello.md"].Content)
+		})
+	}
+}
+
+func Test_ListGists(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListGists(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_gists", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "since")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockGists := []*github.Gist{
+		{
+			ID:      github.Ptr("gist1"),
+			HTMLURL: github.Ptr("https://gist.github.com/gist1"),
+		},
+		{
+			ID:      github.Ptr("gist2"),
+			HTMLURL: github.Ptr("https://gist.github.com/gist2"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedGists  []*github.Gist
+		expectedErrMsg string
+	}{
+		{
+			name: "list authenticated user gists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetGists,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockGists),
+					),
+				),
+			),
+			requestArgs:   map[string]interface{}{},
+			expectError:   false,
+			expectedGists: mockGists,
+		},
+		{
+			name: "list gists of a user since a time",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersGistsByUsername,
+					expectQueryParams(t, map[string]string{
+						"since":    "2024-01-01T00:00:00Z",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockGists),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+				"since":    "2024-01-01T00:00:00Z",
+				"page":     float64(2),
+				"perPage":  float64(10),
+			},
+			expectError:   false,
+			expectedGists: mockGists,
+		},
+		{
+			name: "list gists fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersGistsByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "nonexistent",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list gists",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListGists(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedGists []*github.Gist
+			getListResult(t, textContent.Text, &returnedGists)
+			assert.Len(t, returnedGists, len(tc.expectedGists))
+			for i, gist := range returnedGists {
+				assert.Equal(t, *tc.expectedGists[i].ID, *gist.ID)
+				assert.Equal(t, *tc.expectedGists[i].HTMLURL, *gist.HTMLURL)
+			}
+		})
+	}
+}
+
+func Test_CreateGist(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateGist(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_gist", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "files")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "public")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"files"})
+
+	mockGist := &github.Gist{
+		ID:      github.Ptr("new-gist"),
+		HTMLURL: github.Ptr("https://gist.github.com/new-gist"),
+		Public:  github.Ptr(true),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful gist creation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostGists,
+					expectRequestBody(t, map[string]interface{}{
+						"description": "A snippet",
+						"public":      true,
+						"files": map[string]interface{}{
+							"hello.md": map[string]interface{}{
+								"filename": "hello.md",
+								"content":  "# Hello",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockGist),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"files": map[string]interface{}{
+					"hello.md": "# Hello",
+				},
+				"description": "A snippet",
+				"public":      true,
+			},
+			expectError: false,
+		},
+		{
+			name:         "missing files",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"description": "A snippet",
+			},
+			expectError:    true,
+			expectedErrMsg: "files parameter must be a non-empty map of filename to content",
+		},
+		{
+			name:         "file content is not a string",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"files": map[string]interface{}{
+					"hello.md": float64(1),
+				},
+			},
+			expectError:    true,
+			expectedErrMsg: "content of file hello.md must be a string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateGist(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned map[string]interface{}
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, "new-gist", returned["id"])
+			assert.Equal(t, "https://gist.github.com/new-gist", returned["html_url"])
+			assert.Equal(t, true, returned["public"])
+		})
+	}
+}
@@ -243,3 +243,15 @@ func TestOptionalParamOK(t *testing.T) {
 		})
 	}
 }
+
+// getListResult unmarshals the items of a ListResult into items, and returns its pagination
+func getListResult(t *testing.T, text string, items any) PageInfo {
+	t.Helper()
+	var envelope struct {
+		Items      json.RawMessage `json:"items"`
+		Pagination PageInfo        `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &envelope))
+	require.NoError(t, json.Unmarshal(envelope.Items, items))
+	return envelope.Pagination
+}
@@ -0,0 +1,398 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// Issue templates are markdown files with a YAML front matter, or YAML issue forms, in .github/ISSUE_TEMPLATE.
+// Repositories from before templates could be chosen have a single markdown ISSUE_TEMPLATE.md instead, in
+// .github, the root or docs.
+
+const (
+	issueTemplateFormatMarkdown = "markdown"
+	issueTemplateFormatForm     = "form"
+)
+
+// IssueTemplateField is an element of the body of an issue form. Value is the text of markdown elements and the
+// default value of inputs.
+type IssueTemplateField struct {
+	Type        string   `json:"type"`
+	ID          string   `json:"id,omitempty"`
+	Label       string   `json:"label,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Placeholder string   `json:"placeholder,omitempty"`
+	Value       string   `json:"value,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+}
+
+// IssueTemplate is an issue template of a repository. Body is the body of markdown templates, and for forms the
+// markdown GitHub submits with the default values, one section per input, so that either can be passed to
+// create_issue. Error is set instead for templates that cannot be parsed.
+type IssueTemplate struct {
+	Name      string               `json:"name"`
+	About     string               `json:"about,omitempty"`
+	Path      string               `json:"path"`
+	Format    string               `json:"format"`
+	Legacy    bool                 `json:"legacy,omitempty"`
+	Title     string               `json:"title,omitempty"`
+	Labels    []string             `json:"labels,omitempty"`
+	Assignees []string             `json:"assignees,omitempty"`
+	Body      string               `json:"body,omitempty"`
+	Fields    []IssueTemplateField `json:"fields,omitempty"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// issueTemplateList is a list that templates may also write as a comma-separated string
+type issueTemplateList []string
+
+func (l *issueTemplateList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, item := range strings.Split(node.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*l = append(*l, item)
+			}
+		}
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// issueTemplateOption is an option of a dropdown, a string, or of checkboxes, a mapping with a label
+type issueTemplateOption struct {
+	Label    string
+	Required bool
+}
+
+func (o *issueTemplateOption) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Label = node.Value
+		return nil
+	}
+	var option struct {
+		Label    string `yaml:"label"`
+		Required bool   `yaml:"required"`
+	}
+	if err := node.Decode(&option); err != nil {
+		return err
+	}
+	o.Label, o.Required = option.Label, option.Required
+	return nil
+}
+
+type issueTemplateHeader struct {
+	Name        string            `yaml:"name"`
+	About       string            `yaml:"about"`
+	Description string            `yaml:"description"`
+	Title       string            `yaml:"title"`
+	Labels      issueTemplateList `yaml:"labels"`
+	Assignees   issueTemplateList `yaml:"assignees"`
+}
+
+type issueForm struct {
+	issueTemplateHeader `yaml:",inline"`
+	Body                []struct {
+		Type       string `yaml:"type"`
+		ID         string `yaml:"id"`
+		Attributes struct {
+			Label       string                `yaml:"label"`
+			Description string                `yaml:"description"`
+			Placeholder string                `yaml:"placeholder"`
+			Value       string                `yaml:"value"`
+			Options     []issueTemplateOption `yaml:"options"`
+		} `yaml:"attributes"`
+		Validations struct {
+			Required bool `yaml:"required"`
+		} `yaml:"validations"`
+	} `yaml:"body"`
+}
+
+// parseMarkdownIssueTemplate parses a markdown template, whose front matter is optional
+func parseMarkdownIssueTemplate(template *IssueTemplate, content string) error {
+	template.Format = issueTemplateFormatMarkdown
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(content, "---\n")
+	if !ok {
+		template.Body = content
+		return nil
+	}
+	frontMatter, body, ok := strings.Cut(rest, "\n---")
+	if !ok {
+		return fmt.Errorf("the front matter is not closed")
+	}
+	var header issueTemplateHeader
+	if err := yaml.Unmarshal([]byte(frontMatter), &header); err != nil {
+		return fmt.Errorf("invalid front matter: %w", err)
+	}
+	if header.Name != "" {
+		template.Name = header.Name
+	}
+	template.About = header.About
+	template.Title = header.Title
+	template.Labels = header.Labels
+	template.Assignees = header.Assignees
+	// The body starts on the line after the closing ---
+	_, template.Body, _ = strings.Cut(body, "\n")
+	return nil
+}
+
+// parseIssueForm parses an issue form and renders the markdown GitHub submits for it
+func parseIssueForm(template *IssueTemplate, content string) error {
+	template.Format = issueTemplateFormatForm
+	var form issueForm
+	if err := yaml.Unmarshal([]byte(content), &form); err != nil {
+		return fmt.Errorf("invalid issue form: %w", err)
+	}
+	if form.Name != "" {
+		template.Name = form.Name
+	}
+	template.About = form.Description
+	template.Title = form.Title
+	template.Labels = form.Labels
+	template.Assignees = form.Assignees
+
+	var body bytes.Buffer
+	for _, element := range form.Body {
+		field := IssueTemplateField{
+			Type:        element.Type,
+			ID:          element.ID,
+			Label:       element.Attributes.Label,
+			Description: element.Attributes.Description,
+			Placeholder: element.Attributes.Placeholder,
+			Value:       element.Attributes.Value,
+			Required:    element.Validations.Required,
+		}
+		for _, option := range element.Attributes.Options {
+			field.Options = append(field.Options, option.Label)
+			field.Required = field.Required || option.Required
+		}
+		template.Fields = append(template.Fields, field)
+
+		// Markdown elements only guide whoever fills the form in, they are not submitted
+		if field.Type == "markdown" {
+			continue
+		}
+		if body.Len() > 0 {
+			body.WriteString("\n\n")
+		}
+		fmt.Fprintf(&body, "### %s\n\n", field.Label)
+		switch {
+		case field.Type == "checkboxes":
+			for i, option := range field.Options {
+				if i > 0 {
+					body.WriteString("\n")
+				}
+				fmt.Fprintf(&body, "- [ ] %s", option)
+			}
+		case field.Value != "":
+			body.WriteString(field.Value)
+		default:
+			body.WriteString("_No response_")
+		}
+	}
+	template.Body = body.String()
+	return nil
+}
+
+// listIssueTemplateDir lists a directory of a repository, which is empty when the directory does not exist
+func listIssueTemplateDir(ctx context.Context, client *github.Client, owner, repo, dir string) ([]*github.RepositoryContent, error) {
+	_, entries, resp, err := client.Repositories.GetContents(ctx, owner, repo, dir, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	_ = resp.Body.Close()
+	return entries, nil
+}
+
+// readIssueTemplate fetches and parses the template at filePath, a parse failure is set as the Error of the
+// template
+func readIssueTemplate(ctx context.Context, client *github.Client, owner, repo, filePath string, legacy bool) (IssueTemplate, error) {
+	file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, filePath, nil)
+	if err != nil {
+		return IssueTemplate{}, fmt.Errorf("failed to get issue template %s: %w", filePath, err)
+	}
+	_ = resp.Body.Close()
+	content, err := file.GetContent()
+	if err != nil {
+		return IssueTemplate{}, fmt.Errorf("failed to decode issue template %s: %w", filePath, err)
+	}
+
+	ext := path.Ext(filePath)
+	template := IssueTemplate{
+		Name:   strings.TrimSuffix(path.Base(filePath), ext),
+		Path:   filePath,
+		Legacy: legacy,
+	}
+	if strings.EqualFold(ext, ".md") {
+		err = parseMarkdownIssueTemplate(&template, content)
+	} else {
+		err = parseIssueForm(&template, content)
+	}
+	if err != nil {
+		template.Error = err.Error()
+	}
+	return template, nil
+}
+
+// getIssueTemplates reads the issue templates of a repository from its default branch, those of
+// .github/ISSUE_TEMPLATE in file name order and then the legacy template if there is one
+func getIssueTemplates(ctx context.Context, getClient GetClientFn, owner, repo string) ([]IssueTemplate, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	githubDir, err := listIssueTemplateDir(ctx, client, owner, repo, ".github")
+	if err != nil {
+		return nil, err
+	}
+	templates := []IssueTemplate{}
+	for _, entry := range githubDir {
+		if entry.GetType() != "dir" || !strings.EqualFold(entry.GetName(), "ISSUE_TEMPLATE") {
+			continue
+		}
+		entries, err := listIssueTemplateDir(ctx, client, owner, repo, entry.GetPath())
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range entries {
+			name := strings.ToLower(file.GetName())
+			ext := path.Ext(name)
+			// config.yml configures the template chooser, it is not a template
+			if file.GetType() != "file" || (ext != ".md" && ext != ".yml" && ext != ".yaml") || strings.TrimSuffix(name, ext) == "config" {
+				continue
+			}
+			template, err := readIssueTemplate(ctx, client, owner, repo, file.GetPath(), false)
+			if err != nil {
+				return nil, err
+			}
+			templates = append(templates, template)
+		}
+	}
+
+	// GitHub looks for the legacy template in .github, then the root, then docs
+	for _, dir := range []string{".github", "", "docs"} {
+		entries := githubDir
+		if dir != ".github" {
+			if entries, err = listIssueTemplateDir(ctx, client, owner, repo, dir); err != nil {
+				return nil, err
+			}
+		}
+		for _, entry := range entries {
+			if entry.GetType() == "file" && strings.EqualFold(entry.GetName(), "ISSUE_TEMPLATE.md") {
+				template, err := readIssueTemplate(ctx, client, owner, repo, entry.GetPath(), true)
+				if err != nil {
+					return nil, err
+				}
+				return append(templates, template), nil
+			}
+		}
+	}
+	return templates, nil
+}
+
+// ListIssueTemplates creates a tool to list the issue templates of a repository.
+func ListIssueTemplates(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_templates",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_TEMPLATES_DESCRIPTION", "List the issue templates of a repository, both markdown templates and issue forms, with the name, description, default title, labels and assignees of each and its body, ready to pass to create_issue. The fields of issue forms are listed as well")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ISSUE_TEMPLATES_USER_TITLE", "List issue templates"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			templates, err := getIssueTemplates(ctx, getClient, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			return MarshalledTextResult(templates), nil
+		}
+}
+
+// GetIssueTemplate creates a tool to get an issue template of a repository.
+func GetIssueTemplate(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_template",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TEMPLATE_DESCRIPTION", "Get an issue template of a repository, a markdown template or an issue form, with its default title, labels, assignees and body, ready to pass to create_issue")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TEMPLATE_USER_TITLE", "Get issue template"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("template",
+				mcp.Required(),
+				mcp.Description("Template name as shown when opening an issue, such as 'Bug report', its file name, such as 'bug_report', or its path from list_issue_templates"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "template")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			templates, err := getIssueTemplates(ctx, getClient, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, template := range templates {
+				fileName := strings.TrimSuffix(path.Base(template.Path), path.Ext(template.Path))
+				if template.Path == name || strings.EqualFold(template.Name, name) || strings.EqualFold(fileName, name) {
+					return MarshalledTextResult(template), nil
+				}
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("issue template %q not found in %s/%s, list_issue_templates lists its templates", name, owner, repo)), nil
+		}
+}
@@ -0,0 +1,285 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBugReportTemplate = `---
+name: Bug report
+about: Report something that does not work
+title: "[Bug] "
+labels: bug, triage
+assignees:
+  - octocat
+---
+## Steps to reproduce
+
+1.
+`
+
+const testFeatureRequestForm = `name: Feature request
+description: Suggest an idea
+labels: [enhancement]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for the idea!
+  - type: textarea
+    id: problem
+    attributes:
+      label: Problem
+      description: What is the problem?
+      value: "As a user, "
+    validations:
+      required: true
+  - type: dropdown
+    id: area
+    attributes:
+      label: Area
+      options:
+        - API
+        - UI
+  - type: checkboxes
+    attributes:
+      label: Checks
+      options:
+        - label: I searched the existing issues
+          required: true
+`
+
+// newIssueTemplateServer serves the contents API of a repository with files, keyed by path
+func newIssueTemplateServer(t *testing.T, files map[string]string) *github.Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		filePath, ok := strings.CutPrefix(r.URL.Path, "/repos/owner/repo/contents/")
+		require.True(t, ok, r.URL.Path)
+
+		if content, ok := files[filePath]; ok {
+			_ = json.NewEncoder(w).Encode(&github.RepositoryContent{
+				Type:    github.Ptr("file"),
+				Name:    github.Ptr(path.Base(filePath)),
+				Path:    github.Ptr(filePath),
+				Content: github.Ptr(content),
+			})
+			return
+		}
+
+		entries := []*github.RepositoryContent{}
+		seen := map[string]bool{}
+		for file := range files {
+			rest := file
+			if filePath != "" {
+				if rest, ok = strings.CutPrefix(file, filePath+"/"); !ok {
+					continue
+				}
+			}
+			name, _, isDir := strings.Cut(rest, "/")
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			entryType := "file"
+			if isDir {
+				entryType = "dir"
+			}
+			entries = append(entries, &github.RepositoryContent{
+				Type: github.Ptr(entryType),
+				Name: github.Ptr(name),
+				Path: github.Ptr(strings.TrimPrefix(filePath+"/"+name, "/")),
+			})
+		}
+		if len(entries) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		// The contents API lists directories in name order
+		sort.Slice(entries, func(i, j int) bool { return entries[i].GetName() < entries[j].GetName() })
+		_ = json.NewEncoder(w).Encode(entries)
+	}))
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return client
+}
+
+func Test_ListIssueTemplates(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueTemplates(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_issue_templates", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []IssueTemplate
+	}{
+		{
+			name: "markdown templates and issue forms",
+			files: map[string]string{
+				".github/ISSUE_TEMPLATE/bug_report.md":       testBugReportTemplate,
+				".github/ISSUE_TEMPLATE/feature_request.yml": testFeatureRequestForm,
+				".github/ISSUE_TEMPLATE/config.yml":          "blank_issues_enabled: false\n",
+				".github/ISSUE_TEMPLATE/broken.yaml":         "name: [\n",
+				".github/workflows/ci.yml":                   "on: push\n",
+				"README.md":                                  "# Repo\n",
+			},
+			expected: []IssueTemplate{
+				{
+					Name:   "broken",
+					Path:   ".github/ISSUE_TEMPLATE/broken.yaml",
+					Format: "form",
+					Error:  "invalid issue form: yaml: line 1: did not find expected node content",
+				},
+				{
+					Name:      "Bug report",
+					About:     "Report something that does not work",
+					Path:      ".github/ISSUE_TEMPLATE/bug_report.md",
+					Format:    "markdown",
+					Title:     "[Bug] ",
+					Labels:    []string{"bug", "triage"},
+					Assignees: []string{"octocat"},
+					Body:      "## Steps to reproduce\n\n1.\n",
+				},
+				{
+					Name:   "Feature request",
+					About:  "Suggest an idea",
+					Path:   ".github/ISSUE_TEMPLATE/feature_request.yml",
+					Format: "form",
+					Labels: []string{"enhancement"},
+					Body:   "### Problem\n\nAs a user, \n\n### Area\n\n_No response_\n\n### Checks\n\n- [ ] I searched the existing issues",
+					Fields: []IssueTemplateField{
+						{Type: "markdown", Value: "Thanks for the idea!"},
+						{Type: "textarea", ID: "problem", Label: "Problem", Description: "What is the problem?", Value: "As a user, ", Required: true},
+						{Type: "dropdown", ID: "area", Label: "Area", Options: []string{"API", "UI"}},
+						{Type: "checkboxes", Label: "Checks", Options: []string{"I searched the existing issues"}, Required: true},
+					},
+				},
+			},
+		},
+		{
+			name: "legacy template",
+			files: map[string]string{
+				"docs/ISSUE_TEMPLATE.md": "Describe the issue\n",
+				"README.md":              "# Repo\n",
+			},
+			expected: []IssueTemplate{
+				{
+					Name:   "ISSUE_TEMPLATE",
+					Path:   "docs/ISSUE_TEMPLATE.md",
+					Format: "markdown",
+					Legacy: true,
+					Body:   "Describe the issue\n",
+				},
+			},
+		},
+		{
+			name:     "no templates",
+			files:    map[string]string{"README.md": "# Repo\n"},
+			expected: []IssueTemplate{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListIssueTemplates(stubGetClientFn(newIssueTemplateServer(t, tc.files)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var templates []IssueTemplate
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &templates))
+			assert.Equal(t, tc.expected, templates)
+		})
+	}
+}
+
+func Test_GetIssueTemplate(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTemplate(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_template", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "template"})
+
+	files := map[string]string{
+		".github/ISSUE_TEMPLATE/bug_report.md":       testBugReportTemplate,
+		".github/ISSUE_TEMPLATE/feature_request.yml": testFeatureRequestForm,
+	}
+
+	tests := []struct {
+		name            string
+		template        string
+		expectToolError bool
+		expectedPath    string
+	}{
+		{
+			name:         "template by name",
+			template:     "bug REPORT",
+			expectedPath: ".github/ISSUE_TEMPLATE/bug_report.md",
+		},
+		{
+			name:         "template by file name",
+			template:     "feature_request",
+			expectedPath: ".github/ISSUE_TEMPLATE/feature_request.yml",
+		},
+		{
+			name:         "template by path",
+			template:     ".github/ISSUE_TEMPLATE/bug_report.md",
+			expectedPath: ".github/ISSUE_TEMPLATE/bug_report.md",
+		},
+		{
+			name:            "unknown template",
+			template:        "Question",
+			expectToolError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetIssueTemplate(stubGetClientFn(newIssueTemplateServer(t, files)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"template": tc.template,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, `issue template "Question" not found in owner/repo`)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var template IssueTemplate
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &template))
+			assert.Equal(t, tc.expectedPath, template.Path)
+		})
+	}
+}
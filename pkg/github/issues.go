@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -37,6 +38,7 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Required(),
 				mcp.Description("The number of the issue"),
 			),
+			WithRenderMode(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -52,6 +54,11 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			renderMode, err := renderModeParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -70,6 +77,8 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
 			}
 
+			renderIssues(renderMode, issue)
+
 			r, err := json.Marshal(issue)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal issue: %w", err)
@@ -403,6 +412,7 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Filter by date (ISO 8601 timestamp)"),
 			),
 			WithPagination(),
+			WithRenderMode(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -458,6 +468,11 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				opts.ListOptions.PerPage = int(perPage)
 			}
 
+			renderMode, err := renderModeParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -476,15 +491,30 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list issues: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(issues)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issues: %w", err)
-			}
+			renderIssues(renderMode, issues...)
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledListResult(issues, resp, nil), nil
 		}
 }
 
+// issueStateReasons are the reasons an issue can be in its state for
+var issueStateReasons = []string{"completed", "not_planned", "reopened"}
+
+// validateIssueStateReason checks that reason fits the state an issue is moved to. An empty state keeps
+// the current one, which GitHub checks the reason against.
+func validateIssueStateReason(state, reason string) error {
+	if !slices.Contains(issueStateReasons, reason) {
+		return fmt.Errorf("invalid state_reason %q, must be one of %v", reason, issueStateReasons)
+	}
+	switch {
+	case state == "closed" && reason == "reopened":
+		return fmt.Errorf("state_reason reopened cannot be used to close an issue, use completed or not_planned")
+	case state == "open" && reason != "reopened":
+		return fmt.Errorf("state_reason %s cannot be used to reopen an issue, use reopened", reason)
+	}
+	return nil
+}
+
 // UpdateIssue creates a tool to update an existing issue in a GitHub repository.
 func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("update_issue",
@@ -515,6 +545,10 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("New state"),
 				mcp.Enum("open", "closed"),
 			),
+			mcp.WithString("state_reason",
+				mcp.Description("Reason for the state: completed or not_planned when closing, reopened when reopening. Without state, changes the reason of an already closed issue"),
+				mcp.Enum(issueStateReasons...),
+			),
 			mcp.WithArray("labels",
 				mcp.Description("New labels"),
 				mcp.Items(
@@ -577,6 +611,17 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				issueRequest.State = github.Ptr(state)
 			}
 
+			stateReason, err := OptionalParam[string](request, "state_reason")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if stateReason != "" {
+				if err := validateIssueStateReason(state, stateReason); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				issueRequest.StateReason = github.Ptr(stateReason)
+			}
+
 			// Get labels
 			labels, err := OptionalStringArrayParam(request, "labels")
 			if err != nil {
@@ -631,6 +676,187 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 		}
 }
 
+// AssigneesResult is the outcome of adding or removing issue assignees.
+type AssigneesResult struct {
+	Assignees []string `json:"assignees"`
+	Rejected  []string `json:"rejected,omitempty"`
+}
+
+func newAssigneesResult(issue *github.Issue, rejected []string) AssigneesResult {
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.GetLogin())
+	}
+	return AssigneesResult{Assignees: assignees, Rejected: rejected}
+}
+
+// withAssigneesParams adds the parameters shared by the assignee tools
+func withAssigneesParams(description string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		for _, opt := range []mcp.ToolOption{
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithArray("assignees",
+				mcp.Required(),
+				mcp.Description(description),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+		} {
+			opt(tool)
+		}
+	}
+}
+
+// AddAssignees creates a tool to assign users to an issue or pull request.
+func AddAssignees(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_assignees",
+			mcp.WithDescription(t("TOOL_ADD_ASSIGNEES_DESCRIPTION", "Assign users to an issue or pull request. Logins that cannot be assigned in the repository are skipped and reported as rejected. Returns the resulting assignees.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_ASSIGNEES_USER_TITLE", "Add assignees"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withAssigneesParams("Logins of the users to assign"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("missing required parameter: assignees"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Check every login first, GitHub silently ignores the ones that cannot be assigned
+			var assignable, rejected []string
+			for _, login := range assignees {
+				ok, resp, err := client.Issues.IsAssignee(ctx, owner, repo, login)
+				if err != nil {
+					return nil, fmt.Errorf("failed to check assignee %s: %w", login, err)
+				}
+				_ = resp.Body.Close()
+
+				if ok {
+					assignable = append(assignable, login)
+				} else {
+					rejected = append(rejected, login)
+				}
+			}
+			if len(assignable) == 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("none of the users can be assigned in %s/%s: %s", owner, repo, strings.Join(rejected, ", "))), nil
+			}
+
+			issue, resp, err := client.Issues.AddAssignees(ctx, owner, repo, issueNumber, assignable)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add assignees: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add assignees: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(newAssigneesResult(issue, rejected))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// RemoveAssignees creates a tool to unassign users from an issue or pull request.
+func RemoveAssignees(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_assignees",
+			mcp.WithDescription(t("TOOL_REMOVE_ASSIGNEES_DESCRIPTION", "Unassign users from an issue or pull request. Returns the resulting assignees.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_ASSIGNEES_USER_TITLE", "Remove assignees"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withAssigneesParams("Logins of the users to unassign"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			assignees, err := OptionalStringArrayParam(request, "assignees")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(assignees) == 0 {
+				return mcp.NewToolResultError("missing required parameter: assignees"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issue, resp, err := client.Issues.RemoveAssignees(ctx, owner, repo, issueNumber, assignees)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove assignees: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove assignees: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(newAssigneesResult(issue, nil))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
 // GetIssueComments creates a tool to get comments for a GitHub issue.
 func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_issue_comments",
@@ -657,6 +883,7 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			mcp.WithNumber("per_page",
 				mcp.Description("Number of records per page"),
 			),
+			WithRenderMode(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -687,6 +914,11 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			}
 
+			renderMode, err := renderModeParam(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -705,12 +937,9 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(comments)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+			renderIssueComments(renderMode, comments...)
 
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledListResult(comments, resp, nil), nil
 		}
 }
 
@@ -27,6 +27,7 @@ func Test_GetIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "render_mode")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
 
 	// Setup mock issue for success case
@@ -38,6 +39,12 @@ func Test_GetIssue(t *testing.T) {
 		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
 	}
 
+	mockMarkdownIssue := &github.Issue{
+		Number: github.Ptr(43),
+		Title:  github.Ptr("Markdown Issue"),
+		Body:   github.Ptr("## Steps\n\nSee **the** [docs](https://example.com) <br/>and `run`"),
+	}
+
 	tests := []struct {
 		name           string
 		mockedClient   *http.Client
@@ -62,6 +69,27 @@ func Test_GetIssue(t *testing.T) {
 			expectError:   false,
 			expectedIssue: mockIssue,
 		},
+		{
+			name: "issue body rendered as text",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					mockMarkdownIssue,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(43),
+				"render_mode":  "text",
+			},
+			expectError: false,
+			expectedIssue: &github.Issue{
+				Number: github.Ptr(43),
+				Title:  github.Ptr("Markdown Issue"),
+				Body:   github.Ptr("Steps\n\nSee the docs \nand run"),
+			},
+		},
 		{
 			name: "issue not found",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -718,8 +746,7 @@ func Test_ListIssues(t *testing.T) {
 
 			// Unmarshal and verify the result
 			var returnedIssues []*github.Issue
-			err = json.Unmarshal([]byte(textContent.Text), &returnedIssues)
-			require.NoError(t, err)
+			getListResult(t, textContent.Text, &returnedIssues)
 
 			assert.Len(t, returnedIssues, len(tc.expectedIssues))
 			for i, issue := range returnedIssues {
@@ -745,6 +772,7 @@ func Test_UpdateIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "title")
 	assert.Contains(t, tool.InputSchema.Properties, "body")
 	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "state_reason")
 	assert.Contains(t, tool.InputSchema.Properties, "labels")
 	assert.Contains(t, tool.InputSchema.Properties, "assignees")
 	assert.Contains(t, tool.InputSchema.Properties, "milestone")
@@ -828,6 +856,79 @@ func Test_UpdateIssue(t *testing.T) {
 				State:   github.Ptr("open"),
 			},
 		},
+		{
+			name: "close issue as not planned",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"state":        "closed",
+						"state_reason": "not_planned",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{
+							Number:      github.Ptr(123),
+							Title:       github.Ptr("Won't do"),
+							HTMLURL:     github.Ptr("https://github.com/owner/repo/issues/123"),
+							State:       github.Ptr("closed"),
+							StateReason: github.Ptr("not_planned"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"state":        "closed",
+				"state_reason": "not_planned",
+			},
+			expectError: false,
+			expectedIssue: &github.Issue{
+				Number:      github.Ptr(123),
+				Title:       github.Ptr("Won't do"),
+				HTMLURL:     github.Ptr("https://github.com/owner/repo/issues/123"),
+				State:       github.Ptr("closed"),
+				StateReason: github.Ptr("not_planned"),
+			},
+		},
+		{
+			name:         "reopen with a close reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"state":        "open",
+				"state_reason": "completed",
+			},
+			expectError:    true,
+			expectedErrMsg: "state_reason completed cannot be used to reopen an issue",
+		},
+		{
+			name:         "close with the reopened reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"state":        "closed",
+				"state_reason": "reopened",
+			},
+			expectError:    true,
+			expectedErrMsg: "state_reason reopened cannot be used to close an issue",
+		},
+		{
+			name:         "unknown state reason",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(123),
+				"state_reason": "duplicate",
+			},
+			expectError:    true,
+			expectedErrMsg: `invalid state_reason "duplicate"`,
+		},
 		{
 			name: "update issue fails with not found",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -914,6 +1015,10 @@ func Test_UpdateIssue(t *testing.T) {
 				assert.Equal(t, *tc.expectedIssue.Body, *returnedIssue.Body)
 			}
 
+			if tc.expectedIssue.StateReason != nil {
+				assert.Equal(t, *tc.expectedIssue.StateReason, returnedIssue.GetStateReason())
+			}
+
 			// Check assignees if expected
 			if len(tc.expectedIssue.Assignees) > 0 {
 				assert.Len(t, returnedIssue.Assignees, len(tc.expectedIssue.Assignees))
@@ -989,6 +1094,264 @@ func Test_ParseISOTimestamp(t *testing.T) {
 	}
 }
 
+func Test_AddAssignees(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddAssignees(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_assignees", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "assignees")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "assignees"})
+
+	// Only octocat and hubot can be assigned in the repository
+	isAssignee := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/assignees/octocat", "/repos/owner/repo/assignees/hubot":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	})
+
+	mockIssue := &github.Issue{
+		Number: github.Ptr(42),
+		Assignees: []*github.User{
+			{Login: github.Ptr("monalisa")},
+			{Login: github.Ptr("octocat")},
+			{Login: github.Ptr("hubot")},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedResult  AssigneesResult
+		expectedErrMsg  string
+	}{
+		{
+			name: "assign users and report rejected logins",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAssigneesByOwnerByRepoByAssignee,
+					isAssignee,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"assignees": []interface{}{"octocat", "hubot"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockIssue),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []interface{}{"octocat", "ghost", "hubot"},
+			},
+			expectedResult: AssigneesResult{
+				Assignees: []string{"monalisa", "octocat", "hubot"},
+				Rejected:  []string{"ghost"},
+			},
+		},
+		{
+			name: "no assignable users",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAssigneesByOwnerByRepoByAssignee,
+					isAssignee,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []interface{}{"ghost"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "none of the users can be assigned in owner/repo: ghost",
+		},
+		{
+			name:         "empty assignees",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []interface{}{},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: assignees",
+		},
+		{
+			name: "add assignees fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposAssigneesByOwnerByRepoByAssignee,
+					isAssignee,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+				"assignees":    []interface{}{"octocat"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add assignees",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddAssignees(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedResult AssigneesResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
+
+func Test_RemoveAssignees(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveAssignees(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_assignees", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "assignees"})
+
+	mockIssue := &github.Issue{
+		Number: github.Ptr(42),
+		Assignees: []*github.User{
+			{Login: github.Ptr("monalisa")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult AssigneesResult
+		expectedErrMsg string
+	}{
+		{
+			name: "unassign users",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"assignees": []interface{}{"octocat"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockIssue),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"assignees":    []interface{}{"octocat"},
+			},
+			expectedResult: AssigneesResult{Assignees: []string{"monalisa"}},
+		},
+		{
+			name: "remove assignees fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesAssigneesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+				"assignees":    []interface{}{"octocat"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to remove assignees",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveAssignees(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedResult AssigneesResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
+
 func Test_GetIssueComments(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1112,8 +1475,7 @@ func Test_GetIssueComments(t *testing.T) {
 
 			// Unmarshal and verify the result
 			var returnedComments []*github.IssueComment
-			err = json.Unmarshal([]byte(textContent.Text), &returnedComments)
-			require.NoError(t, err)
+			getListResult(t, textContent.Text, &returnedComments)
 			assert.Equal(t, len(tc.expectedComments), len(returnedComments))
 			if len(returnedComments) > 0 {
 				assert.Equal(t, *tc.expectedComments[0].Body, *returnedComments[0].Body)
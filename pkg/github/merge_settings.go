@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MergeSettings are the pull request merge settings of a repository.
+type MergeSettings struct {
+	AllowMergeCommit    bool `json:"allow_merge_commit"`
+	AllowSquashMerge    bool `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool `json:"allow_rebase_merge"`
+	DeleteBranchOnMerge bool `json:"delete_branch_on_merge"`
+	// The templates are the default title and message of merge and squash commits
+	MergeCommitTitle         string `json:"merge_commit_title,omitempty"`
+	MergeCommitMessage       string `json:"merge_commit_message,omitempty"`
+	SquashMergeCommitTitle   string `json:"squash_merge_commit_title,omitempty"`
+	SquashMergeCommitMessage string `json:"squash_merge_commit_message,omitempty"`
+}
+
+func newMergeSettings(repo *github.Repository) MergeSettings {
+	return MergeSettings{
+		AllowMergeCommit:         repo.GetAllowMergeCommit(),
+		AllowSquashMerge:         repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:         repo.GetAllowRebaseMerge(),
+		DeleteBranchOnMerge:      repo.GetDeleteBranchOnMerge(),
+		MergeCommitTitle:         repo.GetMergeCommitTitle(),
+		MergeCommitMessage:       repo.GetMergeCommitMessage(),
+		SquashMergeCommitTitle:   repo.GetSquashMergeCommitTitle(),
+		SquashMergeCommitMessage: repo.GetSquashMergeCommitMessage(),
+	}
+}
+
+// mergeSettingFlags are the boolean settings of set_merge_settings, out of repoSettingFlags
+var mergeSettingFlags = []string{"allow_merge_commit", "allow_squash_merge", "allow_rebase_merge", "delete_branch_on_merge"}
+
+// mergeCommitTemplates are the commit message template parameters of set_merge_settings, the repository fields
+// they set and the values GitHub accepts for them.
+var mergeCommitTemplates = []struct {
+	name        string
+	description string
+	values      []string
+	field       func(*github.Repository) **string
+}{
+	{"merge_commit_title", "Default title of merge commits: PR_TITLE, or MERGE_MESSAGE for the classic 'Merge pull request #123 from branch'",
+		[]string{"PR_TITLE", "MERGE_MESSAGE"}, func(r *github.Repository) **string { return &r.MergeCommitTitle }},
+	{"merge_commit_message", "Default message of merge commits: the pull request body, its title, or blank",
+		[]string{"PR_BODY", "PR_TITLE", "BLANK"}, func(r *github.Repository) **string { return &r.MergeCommitMessage }},
+	{"squash_merge_commit_title", "Default title of squash commits: PR_TITLE, or COMMIT_OR_PR_TITLE for the commit title when the pull request has a single commit",
+		[]string{"PR_TITLE", "COMMIT_OR_PR_TITLE"}, func(r *github.Repository) **string { return &r.SquashMergeCommitTitle }},
+	{"squash_merge_commit_message", "Default message of squash commits: the pull request body, the messages of its commits, or blank",
+		[]string{"PR_BODY", "COMMIT_MESSAGES", "BLANK"}, func(r *github.Repository) **string { return &r.SquashMergeCommitMessage }},
+}
+
+// getMergeSettingsRepo gets a repository, returning a tool error result when the token in use cannot see its merge
+// settings. GitHub only includes them for users who can push to the repository.
+func getMergeSettingsRepo(ctx context.Context, client *github.Client, owner, repo string) (*github.Repository, *mcp.CallToolResult, error) {
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	if repository.AllowMergeCommit == nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("the merge settings of %s/%s are only visible to users with push access to the repository", owner, repo)), nil
+	}
+	return repository, nil, nil
+}
+
+// GetMergeSettings creates a tool to get the pull request merge settings of a repository.
+func GetMergeSettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_merge_settings",
+			mcp.WithDescription(t("TOOL_GET_MERGE_SETTINGS_DESCRIPTION", "Get the pull request merge settings of a repository: the allowed merge methods, whether head branches are deleted on merge, and the default title and message templates of merge and squash commits")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MERGE_SETTINGS_USER_TITLE", "Get merge settings"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			repository, result, err := getMergeSettingsRepo(ctx, client, owner, repo)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			return MarshalledTextResult(newMergeSettings(repository)), nil
+		}
+}
+
+// SetMergeSettings creates a tool to change the pull request merge settings of a repository.
+func SetMergeSettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_SET_MERGE_SETTINGS_DESCRIPTION", "Change the pull request merge settings of a repository. Only the settings provided are changed, at least one merge method must stay allowed. Returns the resulting settings")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:          t("TOOL_SET_MERGE_SETTINGS_USER_TITLE", "Set merge settings"),
+			ReadOnlyHint:   toBoolPtr(false),
+			IdempotentHint: toBoolPtr(true),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+	}
+	for _, flag := range repoSettingFlags {
+		if slices.Contains(mergeSettingFlags, flag.name) {
+			options = append(options, mcp.WithBoolean(flag.name, mcp.Description(flag.description)))
+		}
+	}
+	for _, template := range mergeCommitTemplates {
+		options = append(options, mcp.WithString(template.name,
+			mcp.Description(template.description),
+			mcp.Enum(template.values...),
+		))
+	}
+
+	return mcp.NewTool("set_merge_settings", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Build the update only with the provided settings
+			update := &github.Repository{}
+			updateNeeded := false
+			for _, flag := range repoSettingFlags {
+				if !slices.Contains(mergeSettingFlags, flag.name) {
+					continue
+				}
+				if value, ok, err := OptionalParamOK[bool](request, flag.name); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				} else if ok {
+					*flag.field(update) = github.Ptr(value)
+					updateNeeded = true
+				}
+			}
+			for _, template := range mergeCommitTemplates {
+				if value, ok, err := OptionalParamOK[string](request, template.name); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				} else if ok {
+					if !slices.Contains(template.values, value) {
+						return mcp.NewToolResultError(fmt.Sprintf("invalid %s %q, must be one of %v", template.name, value, template.values)), nil
+					}
+					*template.field(update) = github.Ptr(value)
+					updateNeeded = true
+				}
+			}
+			if !updateNeeded {
+				return mcp.NewToolResultError("No settings provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			current, result, err := getMergeSettingsRepo(ctx, client, owner, repo)
+			if result != nil || err != nil {
+				return result, err
+			}
+			if !keepsMergeMethod(update, current) {
+				return mcp.NewToolResultError("at least one of allow_merge_commit, allow_squash_merge and allow_rebase_merge must stay enabled"), nil
+			}
+
+			updatedRepo, resp, err := client.Repositories.Edit(ctx, owner, repo, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update merge settings: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update merge settings: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMergeSettings(updatedRepo)), nil
+		}
+}
@@ -0,0 +1,216 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetMergeSettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMergeSettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_merge_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+		expected        MergeSettings
+	}{
+		{
+			name: "merge settings",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					expectPath(t, "/repos/owner/repo").andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							AllowMergeCommit:         github.Ptr(false),
+							AllowSquashMerge:         github.Ptr(true),
+							AllowRebaseMerge:         github.Ptr(true),
+							DeleteBranchOnMerge:      github.Ptr(true),
+							SquashMergeCommitTitle:   github.Ptr("PR_TITLE"),
+							SquashMergeCommitMessage: github.Ptr("PR_BODY"),
+						}),
+					),
+				),
+			),
+			expected: MergeSettings{
+				AllowSquashMerge:         true,
+				AllowRebaseMerge:         true,
+				DeleteBranchOnMerge:      true,
+				SquashMergeCommitTitle:   "PR_TITLE",
+				SquashMergeCommitMessage: "PR_BODY",
+			},
+		},
+		{
+			name: "token without push access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusOK, &github.Repository{FullName: github.Ptr("owner/repo")}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "only visible to users with push access",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetMergeSettings(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var settings MergeSettings
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &settings))
+			assert.Equal(t, tc.expected, settings)
+		})
+	}
+}
+
+func Test_SetMergeSettings(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetMergeSettings(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_merge_settings", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "allow_squash_merge")
+	assert.Contains(t, tool.InputSchema.Properties, "delete_branch_on_merge")
+	assert.Contains(t, tool.InputSchema.Properties, "squash_merge_commit_message")
+	assert.NotContains(t, tool.InputSchema.Properties, "has_wiki")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	currentRepo := &github.Repository{
+		AllowMergeCommit: github.Ptr(true),
+		AllowSquashMerge: github.Ptr(false),
+		AllowRebaseMerge: github.Ptr(false),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+		expected        MergeSettings
+	}{
+		{
+			name: "only squash merges",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusOK, currentRepo),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"allow_merge_commit":          false,
+						"allow_squash_merge":          true,
+						"squash_merge_commit_title":   "PR_TITLE",
+						"squash_merge_commit_message": "BLANK",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Repository{
+							AllowMergeCommit:         github.Ptr(false),
+							AllowSquashMerge:         github.Ptr(true),
+							AllowRebaseMerge:         github.Ptr(false),
+							SquashMergeCommitTitle:   github.Ptr("PR_TITLE"),
+							SquashMergeCommitMessage: github.Ptr("BLANK"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":                       "owner",
+				"repo":                        "repo",
+				"allow_merge_commit":          false,
+				"allow_squash_merge":          true,
+				"squash_merge_commit_title":   "PR_TITLE",
+				"squash_merge_commit_message": "BLANK",
+			},
+			expected: MergeSettings{
+				AllowSquashMerge:         true,
+				SquashMergeCommitTitle:   "PR_TITLE",
+				SquashMergeCommitMessage: "BLANK",
+			},
+		},
+		{
+			name: "disabling every merge method",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusOK, currentRepo),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"allow_merge_commit": false,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "at least one of allow_merge_commit, allow_squash_merge and allow_rebase_merge must stay enabled",
+		},
+		{
+			name:         "invalid template",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":              "owner",
+				"repo":               "repo",
+				"merge_commit_title": "COMMIT_MESSAGES",
+			},
+			expectToolError: true,
+			expectedErrMsg:  `invalid merge_commit_title "COMMIT_MESSAGES"`,
+		},
+		{
+			name:            "nothing to change",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			requestArgs:     map[string]interface{}{"owner": "owner", "repo": "repo"},
+			expectToolError: true,
+			expectedErrMsg:  "No settings provided.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SetMergeSettings(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var settings MergeSettings
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &settings))
+			assert.Equal(t, tc.expected, settings)
+		})
+	}
+}
@@ -135,8 +135,7 @@ func Test_ListNotifications(t *testing.T) {
 			textContent := getTextResult(t, result)
 			t.Logf("textContent: %s", textContent.Text)
 			var returned []*github.Notification
-			err = json.Unmarshal([]byte(textContent.Text), &returned)
-			require.NoError(t, err)
+			getListResult(t, textContent.Text, &returned)
 			require.NotEmpty(t, returned)
 			assert.Equal(t, *tc.expectedResult[0].ID, *returned[0].ID)
 		})
@@ -0,0 +1,277 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OrgActionsPermissions is the GitHub Actions policy of an organization.
+type OrgActionsPermissions struct {
+	// EnabledRepositories is all, none or selected
+	EnabledRepositories string `json:"enabled_repositories"`
+	// AllowedActions is all, local_only or selected, and left out when Actions is disabled for all repositories
+	AllowedActions string `json:"allowed_actions,omitempty"`
+	// SelectedActions is only set when AllowedActions is selected
+	SelectedActions *SelectedActions `json:"selected_actions,omitempty"`
+}
+
+// SelectedActions are the actions and reusable workflows an organization allows when it allows selected ones.
+type SelectedActions struct {
+	GithubOwnedAllowed bool     `json:"github_owned_allowed"`
+	VerifiedAllowed    bool     `json:"verified_allowed"`
+	PatternsAllowed    []string `json:"patterns_allowed"`
+}
+
+func newSelectedActions(allowed *github.ActionsAllowed) *SelectedActions {
+	selected := &SelectedActions{
+		GithubOwnedAllowed: allowed.GetGithubOwnedAllowed(),
+		VerifiedAllowed:    allowed.GetVerifiedAllowed(),
+		PatternsAllowed:    allowed.PatternsAllowed,
+	}
+	if selected.PatternsAllowed == nil {
+		selected.PatternsAllowed = []string{}
+	}
+	return selected
+}
+
+// orgActionsAdminForbiddenResult explains the 403 GitHub returns when the token cannot manage the Actions
+// policy of an organization. It returns nil for any other error.
+func orgActionsAdminForbiddenResult(err error, org string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("the GitHub Actions permissions of %s can only be managed by an organization owner with a token with the admin:org scope, the token in use is not allowed to: %s", org, ghErr.Message))
+}
+
+// getOrgActionsPermissions reads the Actions policy of an organization, along with the selected actions
+// when it only allows selected ones
+func getOrgActionsPermissions(ctx context.Context, client *github.Client, org string) (*OrgActionsPermissions, error) {
+	permissions, resp, err := client.Actions.GetActionsPermissions(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	result := &OrgActionsPermissions{
+		EnabledRepositories: permissions.GetEnabledRepositories(),
+		AllowedActions:      permissions.GetAllowedActions(),
+	}
+	if result.AllowedActions != "selected" {
+		return result, nil
+	}
+
+	allowed, resp, err := client.Actions.GetActionsAllowed(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	result.SelectedActions = newSelectedActions(allowed)
+	return result, nil
+}
+
+// GetOrgActionsPermissions creates a tool to get the GitHub Actions policy of an organization.
+func GetOrgActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org_actions_permissions",
+			mcp.WithDescription(t("TOOL_GET_ORG_ACTIONS_PERMISSIONS_DESCRIPTION", "Get the GitHub Actions policy of an organization: which repositories may use Actions, and which actions and reusable workflows they may run. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_ACTIONS_PERMISSIONS_USER_TITLE", "Get organization Actions permissions"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			permissions, err := getOrgActionsPermissions(ctx, client, org)
+			if result := orgActionsAdminForbiddenResult(err, org); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization Actions permissions: %w", err)
+			}
+
+			return MarshalledTextResult(permissions), nil
+		}
+}
+
+// SetOrgActionsPermissions creates a tool to update the GitHub Actions policy of an organization.
+func SetOrgActionsPermissions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_org_actions_permissions",
+			mcp.WithDescription(t("TOOL_SET_ORG_ACTIONS_PERMISSIONS_DESCRIPTION", "Update the GitHub Actions policy of an organization and return it. Settings left out keep their current value. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_ORG_ACTIONS_PERMISSIONS_USER_TITLE", "Set organization Actions permissions"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("enabled_repositories",
+				mcp.Description("Repositories that may use GitHub Actions, selected ones are chosen in the organization settings"),
+				mcp.Enum("all", "none", "selected"),
+			),
+			mcp.WithString("allowed_actions",
+				mcp.Description("Actions and reusable workflows repositories may run: all of them, only those of the organization, or selected ones"),
+				mcp.Enum("all", "local_only", "selected"),
+			),
+			mcp.WithBoolean("github_owned_allowed",
+				mcp.Description("Allow the actions created by GitHub, when allowed_actions is selected"),
+			),
+			mcp.WithBoolean("verified_allowed",
+				mcp.Description("Allow the actions of verified Marketplace creators, when allowed_actions is selected"),
+			),
+			mcp.WithArray("patterns_allowed",
+				mcp.Description("Actions and reusable workflows to allow, e.g. octo-org/*, when allowed_actions is selected. Replaces the current patterns"),
+				mcp.Items(map[string]any{
+					"type": "string",
+				}),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			enabledRepositories, err := OptionalParam[string](request, "enabled_repositories")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			allowedActions, err := OptionalParam[string](request, "allowed_actions")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			githubOwnedAllowed, hasGithubOwnedAllowed, err := OptionalParamOK[bool](request, "github_owned_allowed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			verifiedAllowed, hasVerifiedAllowed, err := OptionalParamOK[bool](request, "verified_allowed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			patternsAllowed, err := OptionalStringArrayParam(request, "patterns_allowed")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			_, hasPatternsAllowed := request.GetArguments()["patterns_allowed"]
+
+			setsPermissions := enabledRepositories != "" || allowedActions != ""
+			setsSelectedActions := hasGithubOwnedAllowed || hasVerifiedAllowed || hasPatternsAllowed
+			if !setsPermissions && !setsSelectedActions {
+				return mcp.NewToolResultError("at least one setting to update is required"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub replaces the whole policy, so settings left out are filled in with their current value
+			current, err := getOrgActionsPermissions(ctx, client, org)
+			if result := orgActionsAdminForbiddenResult(err, org); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization Actions permissions: %w", err)
+			}
+			if enabledRepositories == "" {
+				enabledRepositories = current.EnabledRepositories
+			}
+			if allowedActions == "" {
+				allowedActions = current.AllowedActions
+			}
+			if setsSelectedActions && (enabledRepositories == "none" || allowedActions != "selected") {
+				return mcp.NewToolResultError("github_owned_allowed, verified_allowed and patterns_allowed only apply when allowed_actions is selected"), nil
+			}
+
+			if setsPermissions {
+				permissions := github.ActionsPermissions{EnabledRepositories: github.Ptr(enabledRepositories)}
+				if enabledRepositories != "none" && allowedActions != "" {
+					permissions.AllowedActions = github.Ptr(allowedActions)
+				}
+				_, resp, err := client.Actions.EditActionsPermissions(ctx, org, permissions)
+				if result := orgActionsAdminForbiddenResult(err, org); result != nil {
+					return result, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to set organization Actions permissions: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if resp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(resp.Body)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to set organization Actions permissions: %s", string(body))), nil
+				}
+			}
+
+			if setsSelectedActions {
+				selected := current.SelectedActions
+				if selected == nil {
+					// Actions only just became limited to selected ones, GitHub keeps the selection made before
+					allowed, getResp, err := client.Actions.GetActionsAllowed(ctx, org)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get organization selected actions: %w", err)
+					}
+					_ = getResp.Body.Close()
+					selected = newSelectedActions(allowed)
+				}
+				if hasGithubOwnedAllowed {
+					selected.GithubOwnedAllowed = githubOwnedAllowed
+				}
+				if hasVerifiedAllowed {
+					selected.VerifiedAllowed = verifiedAllowed
+				}
+				if hasPatternsAllowed {
+					selected.PatternsAllowed = patternsAllowed
+				}
+
+				_, setResp, err := client.Actions.EditActionsAllowed(ctx, org, github.ActionsAllowed{
+					GithubOwnedAllowed: github.Ptr(selected.GithubOwnedAllowed),
+					VerifiedAllowed:    github.Ptr(selected.VerifiedAllowed),
+					PatternsAllowed:    selected.PatternsAllowed,
+				})
+				if result := orgActionsAdminForbiddenResult(err, org); result != nil {
+					return result, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to set organization selected actions: %w", err)
+				}
+				defer func() { _ = setResp.Body.Close() }()
+
+				if setResp.StatusCode != http.StatusNoContent {
+					body, err := io.ReadAll(setResp.Body)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("failed to set organization selected actions: %s", string(body))), nil
+				}
+			}
+
+			permissions, err := getOrgActionsPermissions(ctx, client, org)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization Actions permissions: %w", err)
+			}
+			return MarshalledTextResult(permissions), nil
+		}
+}
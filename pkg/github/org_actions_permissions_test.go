@@ -0,0 +1,230 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrgActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrgActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org_actions_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+		expected        OrgActionsPermissions
+	}{
+		{
+			name: "selected actions are included",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsByOrg,
+					expectPath(t, "/orgs/octo-org/actions/permissions").andThen(
+						mockResponse(t, http.StatusOK, &github.ActionsPermissions{
+							EnabledRepositories: github.Ptr("all"),
+							AllowedActions:      github.Ptr("selected"),
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsSelectedActionsByOrg,
+					mockResponse(t, http.StatusOK, &github.ActionsAllowed{
+						GithubOwnedAllowed: github.Ptr(true),
+						VerifiedAllowed:    github.Ptr(false),
+						PatternsAllowed:    []string{"octo-org/*"},
+					}),
+				),
+			),
+			expected: OrgActionsPermissions{
+				EnabledRepositories: "all",
+				AllowedActions:      "selected",
+				SelectedActions: &SelectedActions{
+					GithubOwnedAllowed: true,
+					PatternsAllowed:    []string{"octo-org/*"},
+				},
+			},
+		},
+		{
+			name: "all actions allowed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsByOrg,
+					mockResponse(t, http.StatusOK, &github.ActionsPermissions{
+						EnabledRepositories: github.Ptr("selected"),
+						AllowedActions:      github.Ptr("all"),
+					}),
+				),
+			),
+			expected: OrgActionsPermissions{
+				EnabledRepositories: "selected",
+				AllowedActions:      "all",
+			},
+		},
+		{
+			name: "token without org admin",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsByOrg,
+					mockResponse(t, http.StatusForbidden, map[string]string{"message": "Must have admin rights to Repository."}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "can only be managed by an organization owner with a token with the admin:org scope",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetOrgActionsPermissions(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"org": "octo-org",
+			}))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var permissions OrgActionsPermissions
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &permissions))
+			assert.Equal(t, tc.expected, permissions)
+		})
+	}
+}
+
+func Test_SetOrgActionsPermissions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetOrgActionsPermissions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_org_actions_permissions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "enabled_repositories")
+	assert.Contains(t, tool.InputSchema.Properties, "allowed_actions")
+	assert.Contains(t, tool.InputSchema.Properties, "patterns_allowed")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	t.Run("limit actions to selected ones", func(t *testing.T) {
+		// The policy allows all actions until it is updated
+		updated := false
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsActionsPermissionsByOrg,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					allowed := "all"
+					if updated {
+						allowed = "selected"
+					}
+					mockResponse(t, http.StatusOK, &github.ActionsPermissions{
+						EnabledRepositories: github.Ptr("all"),
+						AllowedActions:      github.Ptr(allowed),
+					})(w, r)
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PutOrgsActionsPermissionsByOrg,
+				expectRequestBody(t, map[string]any{
+					"enabled_repositories": "all",
+					"allowed_actions":      "selected",
+				}).andThen(
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						updated = true
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.GetOrgsActionsPermissionsSelectedActionsByOrg,
+				mockResponse(t, http.StatusOK, &github.ActionsAllowed{
+					GithubOwnedAllowed: github.Ptr(true),
+					VerifiedAllowed:    github.Ptr(true),
+					PatternsAllowed:    []string{"octo-org/*"},
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PutOrgsActionsPermissionsSelectedActionsByOrg,
+				expectRequestBody(t, map[string]any{
+					"github_owned_allowed": true,
+					"verified_allowed":     false,
+					"patterns_allowed":     []any{"octo-org/*"},
+				}).andThen(
+					mockResponse(t, http.StatusNoContent, nil),
+				),
+			),
+		))
+		_, handler := SetOrgActionsPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"org":              "octo-org",
+			"allowed_actions":  "selected",
+			"verified_allowed": false,
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var permissions OrgActionsPermissions
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &permissions))
+		assert.Equal(t, "selected", permissions.AllowedActions)
+		assert.NotNil(t, permissions.SelectedActions)
+	})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]interface{}
+		expectedErrMsg string
+	}{
+		{
+			name:           "nothing to update",
+			requestArgs:    map[string]interface{}{"org": "octo-org"},
+			expectedErrMsg: "at least one setting to update is required",
+		},
+		{
+			name: "selected actions without allowing selected actions",
+			requestArgs: map[string]interface{}{
+				"org":              "octo-org",
+				"patterns_allowed": []any{"octo-org/*"},
+			},
+			expectedErrMsg: "only apply when allowed_actions is selected",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsPermissionsByOrg,
+					mockResponse(t, http.StatusOK, &github.ActionsPermissions{
+						EnabledRepositories: github.Ptr("all"),
+						AllowedActions:      github.Ptr("all"),
+					}),
+				),
+			))
+			_, handler := SetOrgActionsPermissions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+		})
+	}
+}
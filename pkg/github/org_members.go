@@ -0,0 +1,295 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// orgInvitationRoles are the roles a user can be invited to an organization with
+var orgInvitationRoles = []string{"direct_member", "admin", "billing_manager"}
+
+// OrgInvitation is an open invitation to join an organization.
+type OrgInvitation struct {
+	ID        int64      `json:"id"`
+	Org       string     `json:"org"`
+	Login     string     `json:"login,omitempty"`
+	Email     string     `json:"email,omitempty"`
+	Role      string     `json:"role"`
+	Inviter   string     `json:"inviter,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// OrgMembership is the membership of a user in an organization, as left by a membership change.
+type OrgMembership struct {
+	Org      string `json:"org"`
+	Username string `json:"username"`
+	State    string `json:"state"`
+}
+
+// orgAdminForbiddenResult explains the 403 GitHub returns when the token cannot manage the members of
+// an organization. It returns nil for any other error.
+func orgAdminForbiddenResult(err error, org string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("managing the members of %s requires an organization owner and a token with the admin:org scope, the token in use is not allowed to: %s", org, ghErr.Message))
+}
+
+// ListOrgMembers creates a tool to list the members of an organization.
+func ListOrgMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_members",
+			mcp.WithDescription(t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of a GitHub organization. Only public members are listed unless the token belongs to a member of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role"),
+				mcp.Enum("all", "admin", "member"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("2fa_disabled only lists members without two-factor authentication, which only organization owners can see"),
+				mcp.Enum("all", "2fa_disabled"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListMembersOptions{
+				Role:   role,
+				Filter: filter,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			members, resp, err := client.Organizations.ListMembers(ctx, org, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list organization members: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list organization members: %s", string(body))), nil
+			}
+
+			minimalMembers := make([]MinimalUser, 0, len(members))
+			for _, member := range members {
+				minimalMembers = append(minimalMembers, MinimalUser{
+					Login:      member.GetLogin(),
+					ID:         member.GetID(),
+					ProfileURL: member.GetHTMLURL(),
+					AvatarURL:  member.GetAvatarURL(),
+				})
+			}
+
+			return MarshalledListResult(minimalMembers, resp, nil), nil
+		}
+}
+
+// InviteOrgMember creates a tool to invite a user to an organization by email address or user ID.
+func InviteOrgMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("invite_org_member",
+			mcp.WithDescription(t("TOOL_INVITE_ORG_MEMBER_DESCRIPTION", "Invite a user to a GitHub organization by email address or GitHub user ID. Returns the invitation, which stays open until the user accepts it. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_INVITE_ORG_MEMBER_USER_TITLE", "Invite organization member"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("email",
+				mcp.Description("Email address of the person to invite. Not together with user_id"),
+			),
+			mcp.WithNumber("user_id",
+				mcp.Description("GitHub user ID of the person to invite, as returned by get_user. Not together with email"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role in the organization, defaults to direct_member"),
+				mcp.Enum(orgInvitationRoles...),
+			),
+			mcp.WithArray("team_ids",
+				mcp.Description("IDs of teams to add the user to once they accept"),
+				mcp.Items(
+					map[string]any{
+						"type": "number",
+					},
+				),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			email, err := OptionalParam[string](request, "email")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			userID, err := OptionalIntParam(request, "user_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (email == "") == (userID == 0) {
+				return mcp.NewToolResultError("exactly one of email and user_id must be set"), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if role == "" {
+				role = "direct_member"
+			}
+			if !slices.Contains(orgInvitationRoles, role) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid role %q, must be one of %v", role, orgInvitationRoles)), nil
+			}
+			teamIDs, err := OptionalIntArrayParam(request, "team_ids")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.CreateOrgInvitationOptions{
+				Role: github.Ptr(role),
+			}
+			if email != "" {
+				opts.Email = github.Ptr(email)
+			} else {
+				opts.InviteeID = github.Ptr(int64(userID))
+			}
+			for _, id := range teamIDs {
+				opts.TeamID = append(opts.TeamID, int64(id))
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			invitation, resp, err := client.Organizations.CreateOrgInvitation(ctx, org, opts)
+			if result := orgAdminForbiddenResult(err, org); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to invite organization member: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to invite organization member: %s", string(body))), nil
+			}
+
+			result := OrgInvitation{
+				ID:      invitation.GetID(),
+				Org:     org,
+				Login:   invitation.GetLogin(),
+				Email:   invitation.GetEmail(),
+				Role:    invitation.GetRole(),
+				Inviter: invitation.GetInviter().GetLogin(),
+			}
+			if invitation.CreatedAt != nil {
+				result.CreatedAt = &invitation.CreatedAt.Time
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
+// RemoveOrgMember creates a tool to remove a user from an organization.
+func RemoveOrgMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_org_member",
+			mcp.WithDescription(t("TOOL_REMOVE_ORG_MEMBER_DESCRIPTION", "Remove a user from a GitHub organization. The user loses access to the organization's repositories and is removed from all its teams. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_ORG_MEMBER_USER_TITLE", "Remove organization member"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username of the member to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Organizations.RemoveMember(ctx, org, username)
+			if result := orgAdminForbiddenResult(err, org); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove organization member: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove organization member: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(OrgMembership{
+				Org:      org,
+				Username: username,
+				State:    "removed",
+			}), nil
+		}
+}
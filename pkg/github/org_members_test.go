@@ -0,0 +1,360 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockMembers := []*github.User{
+		{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), HTMLURL: github.Ptr("https://github.com/octocat")},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedMembers []MinimalUser
+	}{
+		{
+			name: "admins of the organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					expectPath(t, "/orgs/org/members").andThen(
+						expectQueryParams(t, map[string]string{"role": "admin", "page": "1", "per_page": "30"}).andThen(
+							mockResponse(t, http.StatusOK, mockMembers),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":  "org",
+				"role": "admin",
+			},
+			expectedMembers: []MinimalUser{
+				{Login: "octocat", ID: 1, ProfileURL: "https://github.com/octocat"},
+			},
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsMembersByOrg,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization members",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedMembers []MinimalUser
+			getListResult(t, textContent.Text, &returnedMembers)
+			assert.Equal(t, tc.expectedMembers, returnedMembers)
+		})
+	}
+}
+
+func Test_InviteOrgMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := InviteOrgMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "invite_org_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "email")
+	assert.Contains(t, tool.InputSchema.Properties, "user_id")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "team_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockInvitation := &github.Invitation{
+		ID:        github.Ptr(int64(99)),
+		Email:     github.Ptr("mona@example.com"),
+		Role:      github.Ptr("direct_member"),
+		Inviter:   &github.User{Login: github.Ptr("admin")},
+		CreatedAt: &github.Timestamp{Time: createdAt},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectToolError    bool
+		expectedErrMsg     string
+		expectedInvitation OrgInvitation
+	}{
+		{
+			name: "invite by email",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"email":    "mona@example.com",
+						"role":     "direct_member",
+						"team_ids": []any{float64(7)},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockInvitation),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"email":    "mona@example.com",
+				"team_ids": []any{float64(7)},
+			},
+			expectedInvitation: OrgInvitation{
+				ID:        99,
+				Org:       "org",
+				Email:     "mona@example.com",
+				Role:      "direct_member",
+				Inviter:   "admin",
+				CreatedAt: &createdAt,
+			},
+		},
+		{
+			name: "invite by user id",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"invitee_id": float64(42),
+						"role":       "admin",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Invitation{
+							ID:    github.Ptr(int64(100)),
+							Login: github.Ptr("mona"),
+							Role:  github.Ptr("admin"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "org",
+				"user_id": float64(42),
+				"role":    "admin",
+			},
+			expectedInvitation: OrgInvitation{
+				ID:    100,
+				Org:   "org",
+				Login: "mona",
+				Role:  "admin",
+			},
+		},
+		{
+			name:         "email and user id together",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":     "org",
+				"email":   "mona@example.com",
+				"user_id": float64(42),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "exactly one of email and user_id must be set",
+		},
+		{
+			name:         "invalid role",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":   "org",
+				"email": "mona@example.com",
+				"role":  "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  `invalid role "owner"`,
+		},
+		{
+			name: "not an organization owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "You must be an admin to create an invitation to an organization."}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "org",
+				"email": "mona@example.com",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "requires an organization owner and a token with the admin:org scope",
+		},
+		{
+			name: "validation failed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsInvitationsByOrg,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":   "org",
+				"email": "not-an-email",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to invite organization member",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := InviteOrgMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedInvitation OrgInvitation
+			err = json.Unmarshal([]byte(textContent.Text), &returnedInvitation)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInvitation, returnedInvitation)
+		})
+	}
+}
+
+func Test_RemoveOrgMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveOrgMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_org_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "username"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful removal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMembersByOrgByUsername,
+					expectPath(t, "/orgs/org/members/octocat").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "not an organization owner",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsMembersByOrgByUsername,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "managing the members of org requires an organization owner",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveOrgMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"org":      "org",
+				"username": "octocat",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedMembership OrgMembership
+			err = json.Unmarshal([]byte(textContent.Text), &returnedMembership)
+			require.NoError(t, err)
+			assert.Equal(t, OrgMembership{Org: "org", Username: "octocat", State: "removed"}, returnedMembership)
+		})
+	}
+}
@@ -0,0 +1,362 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// withOrgVisibility adds the visibility and selected_repository_ids parameters shared by the tools that set
+// organization secrets and variables
+func withOrgVisibility(kind string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("visibility",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Repositories that can use the %s: all of them, the private and internal ones, or the selected ones", kind)),
+			mcp.Enum("all", "private", "selected"),
+		)(tool)
+		mcp.WithArray("selected_repository_ids",
+			mcp.Description("IDs of the repositories that can use the "+kind+", required when visibility is selected. Replaces the current selection"),
+			mcp.Items(map[string]any{
+				"type": "number",
+			}),
+		)(tool)
+	}
+}
+
+// orgVisibilityParams reads the visibility and selected_repository_ids parameters, which GitHub only accepts
+// together
+func orgVisibilityParams(request mcp.CallToolRequest) (string, []int64, error) {
+	visibility, err := requiredParam[string](request, "visibility")
+	if err != nil {
+		return "", nil, err
+	}
+	ids, err := OptionalIntArrayParam(request, "selected_repository_ids")
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch visibility {
+	case "all", "private":
+		if len(ids) > 0 {
+			return "", nil, fmt.Errorf("selected_repository_ids can only be set when visibility is selected, not %s", visibility)
+		}
+		return visibility, nil, nil
+	case "selected":
+		if len(ids) == 0 {
+			return "", nil, fmt.Errorf("selected_repository_ids is required when visibility is selected")
+		}
+		selected := make([]int64, len(ids))
+		for i, id := range ids {
+			selected[i] = int64(id)
+		}
+		return visibility, selected, nil
+	default:
+		return "", nil, fmt.Errorf("invalid visibility %q, expected all, private or selected", visibility)
+	}
+}
+
+// sealSecret encrypts value for GitHub with a libsodium sealed box, using the public key of the organization
+// or repository the secret belongs to, and returns it base64 encoded
+func sealSecret(publicKey *github.PublicKey, value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(publicKey.GetKey())
+	if err != nil {
+		return "", fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if len(decoded) != 32 {
+		return "", fmt.Errorf("invalid public key of %d bytes, expected 32", len(decoded))
+	}
+	var key [32]byte
+	copy(key[:], decoded)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &key, rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// ListOrgSecrets creates a tool to list the Actions secrets of an organization.
+func ListOrgSecrets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_secrets",
+			mcp.WithDescription(t("TOOL_LIST_ORG_SECRETS_DESCRIPTION", "List the GitHub Actions secrets of an organization with their visibility. Secret values are never returned. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_SECRETS_USER_TITLE", "List organization secrets"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			// GitHub only ever returns the names and metadata of secrets, not their values
+			secrets, resp, err := client.Actions.ListOrgSecrets(ctx, org, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list organization secrets: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list organization secrets: %s", string(body))), nil
+			}
+
+			return MarshalledListResult(secrets.Secrets, resp, &secrets.TotalCount), nil
+		}
+}
+
+// ListOrgVariables creates a tool to list the Actions variables of an organization.
+func ListOrgVariables(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_variables",
+			mcp.WithDescription(t("TOOL_LIST_ORG_VARIABLES_DESCRIPTION", "List the GitHub Actions variables of an organization, including their values and visibility. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_VARIABLES_USER_TITLE", "List organization variables"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			variables, resp, err := client.Actions.ListOrgVariables(ctx, org, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list organization variables: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list organization variables: %s", string(body))), nil
+			}
+
+			return MarshalledListResult(variables.Variables, resp, &variables.TotalCount), nil
+		}
+}
+
+// SetOrgSecret creates a tool to create or update an Actions secret of an organization.
+func SetOrgSecret(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_org_secret",
+			mcp.WithDescription(t("TOOL_SET_ORG_SECRET_DESCRIPTION", "Create a GitHub Actions secret in an organization, or replace its value and visibility if it already exists. The value is encrypted before it is sent to GitHub. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_ORG_SECRET_USER_TITLE", "Set organization secret"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the secret, letters, digits and underscores only"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("Value of the secret"),
+				Sensitive(),
+			),
+			withOrgVisibility("secret"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateSecretName(name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := requiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, selectedRepositoryIDs, err := orgVisibilityParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			publicKey, resp, err := client.Actions.GetOrgPublicKey(ctx, org)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization public key: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			encryptedValue, err := sealSecret(publicKey, value)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err = client.Actions.CreateOrUpdateOrgSecret(ctx, org, &github.EncryptedSecret{
+				Name:                  name,
+				KeyID:                 publicKey.GetKeyID(),
+				EncryptedValue:        encryptedValue,
+				Visibility:            visibility,
+				SelectedRepositoryIDs: selectedRepositoryIDs,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to set organization secret: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set organization secret: %s", string(body))), nil
+			}
+
+			// The value is left out on purpose, secrets are never returned
+			return MarshalledTextResult(map[string]any{
+				"name":       name,
+				"visibility": visibility,
+				"created":    resp.StatusCode == http.StatusCreated,
+			}), nil
+		}
+}
+
+// SetOrgVariable creates a tool to create or update an Actions variable of an organization.
+func SetOrgVariable(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_org_variable",
+			mcp.WithDescription(t("TOOL_SET_ORG_VARIABLE_DESCRIPTION", "Create a GitHub Actions variable in an organization, or update its value and visibility if it already exists. Requires an organization owner")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SET_ORG_VARIABLE_USER_TITLE", "Set organization variable"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Name of the variable, letters, digits and underscores only"),
+			),
+			mcp.WithString("value",
+				mcp.Required(),
+				mcp.Description("Value of the variable"),
+			),
+			withOrgVisibility("variable"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateVariableName(name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			value, err := requiredParam[string](request, "value")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibility, selectedRepositoryIDs, err := orgVisibilityParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			variable := &github.ActionsVariable{Name: name, Value: value, Visibility: github.Ptr(visibility)}
+			if selectedRepositoryIDs != nil {
+				ids := github.SelectedRepoIDs(selectedRepositoryIDs)
+				variable.SelectedRepositoryIDs = &ids
+			}
+
+			// Update first, and only create the variable when it does not exist yet
+			created := false
+			resp, err := client.Actions.UpdateOrgVariable(ctx, org, variable)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				created = true
+				resp, err = client.Actions.CreateOrgVariable(ctx, org, variable)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to set organization variable: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			expectedStatus := http.StatusNoContent
+			if created {
+				expectedStatus = http.StatusCreated
+			}
+			if resp.StatusCode != expectedStatus {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set organization variable: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"name":       name,
+				"value":      value,
+				"visibility": visibility,
+				"created":    created,
+			}), nil
+		}
+}
@@ -0,0 +1,430 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func Test_ListOrgSecrets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgSecrets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_secrets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	updatedAt := github.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsActionsSecretsByOrg,
+			expectPath(t, "/orgs/octo-org/actions/secrets").andThen(
+				mockResponse(t, http.StatusOK, &github.Secrets{
+					TotalCount: 1,
+					Secrets: []*github.Secret{
+						{Name: "NPM_TOKEN", Visibility: "private", CreatedAt: updatedAt, UpdatedAt: updatedAt},
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListOrgSecrets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org": "octo-org",
+	}))
+	require.NoError(t, err)
+
+	var response struct {
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	require.Len(t, response.Items, 1)
+	assert.Equal(t, "NPM_TOKEN", response.Items[0]["name"])
+	assert.Equal(t, "private", response.Items[0]["visibility"])
+	assert.NotContains(t, response.Items[0], "value")
+}
+
+func Test_ListOrgVariables(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgVariables(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_variables", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "list variables",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsActionsVariablesByOrg,
+					&github.ActionsVariables{
+						TotalCount: 1,
+						Variables: []*github.ActionsVariable{
+							{Name: "REGION", Value: "eu", Visibility: github.Ptr("all")},
+						},
+					},
+				),
+			),
+		},
+		{
+			name: "forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsVariablesByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list organization variables",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListOrgVariables(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"org": "octo-org",
+			}))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			var response struct {
+				Items []*github.ActionsVariable `json:"items"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			require.Len(t, response.Items, 1)
+			assert.Equal(t, "REGION", response.Items[0].Name)
+			assert.Equal(t, "eu", response.Items[0].Value)
+			assert.Equal(t, "all", response.Items[0].GetVisibility())
+		})
+	}
+}
+
+func Test_SetOrgSecret(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetOrgSecret(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_org_secret", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "selected_repository_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "name", "value", "visibility"})
+	assert.Equal(t, []string{"value"}, SensitiveArguments(tool))
+
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	getPublicKey := func() mock.MockBackendOption {
+		return mock.WithRequestMatch(
+			mock.GetOrgsActionsSecretsPublicKeyByOrg,
+			&github.PublicKey{
+				KeyID: github.Ptr("568250167242549743"),
+				Key:   github.Ptr(base64.StdEncoding.EncodeToString(publicKey[:])),
+			},
+		)
+	}
+
+	// putSecret checks the secret GitHub is sent can be decrypted with the private key, then answers with status
+	putSecret := func(visibility string, selectedRepositoryIDs []int64, status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				KeyID                 string  `json:"key_id"`
+				EncryptedValue        string  `json:"encrypted_value"`
+				Visibility            string  `json:"visibility"`
+				SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "/orgs/octo-org/actions/secrets/NPM_TOKEN", r.URL.Path)
+			assert.Equal(t, "568250167242549743", body.KeyID)
+			assert.Equal(t, visibility, body.Visibility)
+			assert.Equal(t, selectedRepositoryIDs, body.SelectedRepositoryIDs)
+
+			sealed, err := base64.StdEncoding.DecodeString(body.EncryptedValue)
+			require.NoError(t, err)
+			value, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+			require.True(t, ok)
+			assert.Equal(t, "s3cr3t", string(value))
+
+			w.WriteHeader(status)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedCreated bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "create secret for private repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				getPublicKey(),
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsSecretsByOrgBySecretName,
+					putSecret("private", nil, http.StatusCreated),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"name":       "NPM_TOKEN",
+				"value":      "s3cr3t",
+				"visibility": "private",
+			},
+			expectedCreated: true,
+		},
+		{
+			name: "update secret for selected repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				getPublicKey(),
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsActionsSecretsByOrgBySecretName,
+					putSecret("selected", []int64{1296269, 1296270}, http.StatusNoContent),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                     "octo-org",
+				"name":                    "NPM_TOKEN",
+				"value":                   "s3cr3t",
+				"visibility":              "selected",
+				"selected_repository_ids": []any{float64(1296269), float64(1296270)},
+			},
+			expectedCreated: false,
+		},
+		{
+			name:         "selected visibility without repositories",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"name":       "NPM_TOKEN",
+				"value":      "s3cr3t",
+				"visibility": "selected",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "selected_repository_ids is required when visibility is selected",
+		},
+		{
+			name:         "repositories without selected visibility",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":                     "octo-org",
+				"name":                    "NPM_TOKEN",
+				"value":                   "s3cr3t",
+				"visibility":              "all",
+				"selected_repository_ids": []any{float64(1296269)},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "selected_repository_ids can only be set when visibility is selected",
+		},
+		{
+			name:         "invalid secret name",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"name":       "NPM-TOKEN",
+				"value":      "s3cr3t",
+				"visibility": "all",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid secret name",
+		},
+		{
+			name: "public key forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsActionsSecretsPublicKeyByOrg,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"name":       "NPM_TOKEN",
+				"value":      "s3cr3t",
+				"visibility": "all",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get organization public key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SetOrgSecret(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// The value must not be echoed back
+			assert.NotContains(t, textContent.Text, "s3cr3t")
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "NPM_TOKEN", response["name"])
+			assert.Equal(t, tc.requestArgs["visibility"], response["visibility"])
+			assert.Equal(t, tc.expectedCreated, response["created"])
+		})
+	}
+}
+
+func Test_SetOrgVariable(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetOrgVariable(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_org_variable", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "selected_repository_ids")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "name", "value", "visibility"})
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedCreated bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "update existing variable",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsActionsVariablesByOrgByName,
+					expectRequestBody(t, map[string]interface{}{
+						"name":       "REGION",
+						"value":      "eu",
+						"visibility": "all",
+					}).andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"name":       "REGION",
+				"value":      "eu",
+				"visibility": "all",
+			},
+			expectedCreated: false,
+		},
+		{
+			name: "create missing variable for selected repositories",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsActionsVariablesByOrgByName,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsActionsVariablesByOrg,
+					expectRequestBody(t, map[string]interface{}{
+						"name":                    "REGION",
+						"value":                   "eu",
+						"visibility":              "selected",
+						"selected_repository_ids": []any{float64(1296269)},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, map[string]any{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":                     "octo-org",
+				"name":                    "REGION",
+				"value":                   "eu",
+				"visibility":              "selected",
+				"selected_repository_ids": []any{float64(1296269)},
+			},
+			expectedCreated: true,
+		},
+		{
+			name: "create fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchOrgsActionsVariablesByOrgByName,
+					notFound,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostOrgsActionsVariablesByOrg,
+					notFound,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "missing-org",
+				"name":       "REGION",
+				"value":      "eu",
+				"visibility": "private",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set organization variable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := SetOrgVariable(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "REGION", response["name"])
+			assert.Equal(t, "eu", response["value"])
+			assert.Equal(t, tc.requestArgs["visibility"], response["visibility"])
+			assert.Equal(t, tc.expectedCreated, response["created"])
+		})
+	}
+}
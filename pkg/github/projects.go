@@ -0,0 +1,393 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// projectV2FieldName selects the name of the field a value belongs to, whatever the field type.
+type projectV2FieldName struct {
+	Common struct {
+		Name string
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// projectV2ItemNode is a single project item with its content and field values. The fragments on
+// the content and the field values share field names, so the typename is used to pick the right one.
+type projectV2ItemNode struct {
+	ID      githubv4.ID
+	Type    string
+	Content struct {
+		TypeName string `graphql:"__typename"`
+		Issue    struct {
+			Number     int
+			Title      string
+			URL        string
+			Repository struct {
+				NameWithOwner string
+			}
+		} `graphql:"... on Issue"`
+		PullRequest struct {
+			Number     int
+			Title      string
+			URL        string
+			Repository struct {
+				NameWithOwner string
+			}
+		} `graphql:"... on PullRequest"`
+		DraftIssue struct {
+			Title string
+		} `graphql:"... on DraftIssue"`
+	}
+	FieldValues struct {
+		Nodes []struct {
+			TypeName string `graphql:"__typename"`
+			Text     struct {
+				Text  string
+				Field projectV2FieldName
+			} `graphql:"... on ProjectV2ItemFieldTextValue"`
+			Number struct {
+				Number float64
+				Field  projectV2FieldName
+			} `graphql:"... on ProjectV2ItemFieldNumberValue"`
+			Date struct {
+				Date  string
+				Field projectV2FieldName
+			} `graphql:"... on ProjectV2ItemFieldDateValue"`
+			SingleSelect struct {
+				Name  string
+				Field projectV2FieldName
+			} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+			Iteration struct {
+				Title string
+				Field projectV2FieldName
+			} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+		}
+	} `graphql:"fieldValues(first: 20)"`
+}
+
+type projectV2WithItems struct {
+	ID    githubv4.ID
+	Title string
+	Items struct {
+		Nodes    []projectV2ItemNode
+		PageInfo struct {
+			HasNextPage bool
+			EndCursor   string
+		}
+	} `graphql:"items(first: $first, after: $after)"`
+}
+
+type orgProjectItemsQuery struct {
+	Organization struct {
+		ProjectV2 projectV2WithItems `graphql:"projectV2(number: $number)"`
+	} `graphql:"organization(login: $owner)"`
+}
+
+type userProjectItemsQuery struct {
+	User struct {
+		ProjectV2 projectV2WithItems `graphql:"projectV2(number: $number)"`
+	} `graphql:"user(login: $owner)"`
+}
+
+type orgProjectIDQuery struct {
+	Organization struct {
+		ProjectV2 struct {
+			ID githubv4.ID
+		} `graphql:"projectV2(number: $number)"`
+	} `graphql:"organization(login: $owner)"`
+}
+
+type userProjectIDQuery struct {
+	User struct {
+		ProjectV2 struct {
+			ID githubv4.ID
+		} `graphql:"projectV2(number: $number)"`
+	} `graphql:"user(login: $owner)"`
+}
+
+type issueOrPullRequestIDQuery struct {
+	Repository struct {
+		IssueOrPullRequest struct {
+			Issue struct {
+				ID githubv4.ID
+			} `graphql:"... on Issue"`
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"... on PullRequest"`
+		} `graphql:"issueOrPullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// ProjectItem is the flattened representation of a project item returned by the projects tools.
+type ProjectItem struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	Number     int            `json:"number,omitempty"`
+	Title      string         `json:"title,omitempty"`
+	URL        string         `json:"url,omitempty"`
+	Repository string         `json:"repository,omitempty"`
+	Fields     map[string]any `json:"fields"`
+}
+
+func newProjectItem(node projectV2ItemNode) ProjectItem {
+	item := ProjectItem{
+		ID:     fmt.Sprint(node.ID),
+		Type:   node.Type,
+		Fields: make(map[string]any),
+	}
+
+	switch node.Content.TypeName {
+	case "Issue":
+		item.Number = node.Content.Issue.Number
+		item.Title = node.Content.Issue.Title
+		item.URL = node.Content.Issue.URL
+		item.Repository = node.Content.Issue.Repository.NameWithOwner
+	case "PullRequest":
+		item.Number = node.Content.PullRequest.Number
+		item.Title = node.Content.PullRequest.Title
+		item.URL = node.Content.PullRequest.URL
+		item.Repository = node.Content.PullRequest.Repository.NameWithOwner
+	case "DraftIssue":
+		item.Title = node.Content.DraftIssue.Title
+	}
+
+	for _, value := range node.FieldValues.Nodes {
+		switch value.TypeName {
+		case "ProjectV2ItemFieldTextValue":
+			item.Fields[value.Text.Field.Common.Name] = value.Text.Text
+		case "ProjectV2ItemFieldNumberValue":
+			item.Fields[value.Number.Field.Common.Name] = value.Number.Number
+		case "ProjectV2ItemFieldDateValue":
+			item.Fields[value.Date.Field.Common.Name] = value.Date.Date
+		case "ProjectV2ItemFieldSingleSelectValue":
+			item.Fields[value.SingleSelect.Field.Common.Name] = value.SingleSelect.Name
+		case "ProjectV2ItemFieldIterationValue":
+			item.Fields[value.Iteration.Field.Common.Name] = value.Iteration.Title
+		}
+	}
+
+	return item
+}
+
+// withProjectOwner adds the parameters identifying a project by its owner and number.
+func withProjectOwner() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Login of the organization or user owning the project"),
+		)(tool)
+		mcp.WithString("owner_type",
+			mcp.Required(),
+			mcp.Description("Whether the owner is an organization or a user"),
+			mcp.Enum("org", "user"),
+		)(tool)
+		mcp.WithNumber("project_number",
+			mcp.Required(),
+			mcp.Description("Project number, as shown in the project URL"),
+		)(tool)
+	}
+}
+
+// validateProjectOwner checks the parameters added by withProjectOwner, which mapstructure does not enforce.
+func validateProjectOwner(owner, ownerType string, projectNumber int32) error {
+	if owner == "" {
+		return fmt.Errorf("missing required parameter: owner")
+	}
+	if ownerType != "org" && ownerType != "user" {
+		return fmt.Errorf("owner_type must be either org or user")
+	}
+	if projectNumber <= 0 {
+		return fmt.Errorf("missing required parameter: project_number")
+	}
+	return nil
+}
+
+// ListProjectItems creates a tool to list the items of a GitHub Project (v2) along with their field values.
+func ListProjectItems(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("list_project_items",
+			mcp.WithDescription(t("TOOL_LIST_PROJECT_ITEMS_DESCRIPTION", "List the items of a GitHub Project (v2), including the issue or pull request they refer to and their field values")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_PROJECT_ITEMS_USER_TITLE", "List project items"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			withProjectOwner(),
+			mcp.WithNumber("first",
+				mcp.Description("Number of items to return (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor from a previous call to get the next page of items"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner         string
+				OwnerType     string `mapstructure:"owner_type"`
+				ProjectNumber int32  `mapstructure:"project_number"`
+				First         int32
+				After         string
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateProjectOwner(params.Owner, params.OwnerType, params.ProjectNumber); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.First == 0 {
+				params.First = 30
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			variables := map[string]any{
+				"owner":  githubv4.String(params.Owner),
+				"number": githubv4.Int(params.ProjectNumber),
+				"first":  githubv4.Int(params.First),
+				"after":  (*githubv4.String)(nil),
+			}
+			if params.After != "" {
+				variables["after"] = githubv4.String(params.After)
+			}
+
+			var project projectV2WithItems
+			if params.OwnerType == "org" {
+				var query orgProjectItemsQuery
+				if err := client.Query(ctx, &query, variables); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list project items: %v", err)), nil
+				}
+				project = query.Organization.ProjectV2
+			} else {
+				var query userProjectItemsQuery
+				if err := client.Query(ctx, &query, variables); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to list project items: %v", err)), nil
+				}
+				project = query.User.ProjectV2
+			}
+
+			items := make([]ProjectItem, 0, len(project.Items.Nodes))
+			for _, node := range project.Items.Nodes {
+				items = append(items, newProjectItem(node))
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"project_id":    fmt.Sprint(project.ID),
+				"title":         project.Title,
+				"items":         items,
+				"has_next_page": project.Items.PageInfo.HasNextPage,
+				"end_cursor":    project.Items.PageInfo.EndCursor,
+			}), nil
+		}
+}
+
+// AddItemToProject creates a tool to add an issue or pull request to a GitHub Project (v2).
+func AddItemToProject(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("add_item_to_project",
+			mcp.WithDescription(t("TOOL_ADD_ITEM_TO_PROJECT_DESCRIPTION", "Add an issue or pull request to a GitHub Project (v2). Adding an item that is already in the project returns the existing item.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_ITEM_TO_PROJECT_USER_TITLE", "Add item to project"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			withProjectOwner(),
+			mcp.WithString("repo_owner",
+				mcp.Required(),
+				mcp.Description("Owner of the repository containing the issue or pull request"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Name of the repository containing the issue or pull request"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the issue or pull request to add"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner         string
+				OwnerType     string `mapstructure:"owner_type"`
+				ProjectNumber int32  `mapstructure:"project_number"`
+				RepoOwner     string `mapstructure:"repo_owner"`
+				Repo          string
+				IssueNumber   int32 `mapstructure:"issue_number"`
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validateProjectOwner(params.Owner, params.OwnerType, params.ProjectNumber); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.RepoOwner == "" || params.Repo == "" || params.IssueNumber <= 0 {
+				return mcp.NewToolResultError("repo_owner, repo and issue_number are required"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			// The mutation only accepts node IDs, so resolve both the project and the content first.
+			projectVariables := map[string]any{
+				"owner":  githubv4.String(params.Owner),
+				"number": githubv4.Int(params.ProjectNumber),
+			}
+			var projectID githubv4.ID
+			if params.OwnerType == "org" {
+				var query orgProjectIDQuery
+				if err := client.Query(ctx, &query, projectVariables); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get project ID: %v", err)), nil
+				}
+				projectID = query.Organization.ProjectV2.ID
+			} else {
+				var query userProjectIDQuery
+				if err := client.Query(ctx, &query, projectVariables); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("failed to get project ID: %v", err)), nil
+				}
+				projectID = query.User.ProjectV2.ID
+			}
+
+			var contentQuery issueOrPullRequestIDQuery
+			contentVariables := map[string]any{
+				"owner":  githubv4.String(params.RepoOwner),
+				"name":   githubv4.String(params.Repo),
+				"number": githubv4.Int(params.IssueNumber),
+			}
+			if err := client.Query(ctx, &contentQuery, contentVariables); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue or pull request ID: %v", err)), nil
+			}
+			contentID := contentQuery.Repository.IssueOrPullRequest.Issue.ID
+			if contentID == nil {
+				contentID = contentQuery.Repository.IssueOrPullRequest.PullRequest.ID
+			}
+
+			var mutation struct {
+				AddProjectV2ItemByID struct {
+					Item struct {
+						ID githubv4.ID
+					}
+				} `graphql:"addProjectV2ItemById(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.AddProjectV2ItemByIdInput{
+				ProjectID: projectID,
+				ContentID: contentID,
+			}, nil); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add item to project: %v", err)), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"item_id":    fmt.Sprint(mutation.AddProjectV2ItemByID.Item.ID),
+				"project_id": fmt.Sprint(projectID),
+			}), nil
+		}
+}
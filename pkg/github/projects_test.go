@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListProjectItems(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListProjectItems(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_project_items", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "owner_type")
+	assert.Contains(t, tool.InputSchema.Properties, "project_number")
+	assert.Contains(t, tool.InputSchema.Properties, "first")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "project_number"})
+
+	projectResponse := map[string]any{
+		"id":    "PVT_project",
+		"title": "Roadmap",
+		"items": map[string]any{
+			"nodes": []any{
+				map[string]any{
+					"id":   "PVTI_issue",
+					"type": "ISSUE",
+					"content": map[string]any{
+						"__typename": "Issue",
+						"number":     42,
+						"title":      "Fix the bug",
+						"url":        "https://github.com/owner/repo/issues/42",
+						"repository": map[string]any{"nameWithOwner": "owner/repo"},
+					},
+					"fieldValues": map[string]any{
+						"nodes": []any{
+							map[string]any{
+								"__typename": "ProjectV2ItemFieldSingleSelectValue",
+								"name":       "In Progress",
+								"field":      map[string]any{"name": "Status"},
+							},
+							map[string]any{
+								"__typename": "ProjectV2ItemFieldNumberValue",
+								"number":     3,
+								"field":      map[string]any{"name": "Estimate"},
+							},
+						},
+					},
+				},
+			},
+			"pageInfo": map[string]any{
+				"hasNextPage": true,
+				"endCursor":   "cursor-1",
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		requestArgs     map[string]any
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "list items of an organization project",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					orgProjectItemsQuery{},
+					map[string]any{
+						"owner":  githubv4.String("octo-org"),
+						"number": githubv4.Int(7),
+						"first":  githubv4.Int(30),
+						"after":  (*githubv4.String)(nil),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"organization": map[string]any{"projectV2": projectResponse},
+					}),
+				),
+			),
+		},
+		{
+			name: "list items of a user project after a cursor",
+			requestArgs: map[string]any{
+				"owner":          "octocat",
+				"owner_type":     "user",
+				"project_number": float64(7),
+				"first":          float64(10),
+				"after":          "cursor-0",
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					userProjectItemsQuery{},
+					map[string]any{
+						"owner":  githubv4.String("octocat"),
+						"number": githubv4.Int(7),
+						"first":  githubv4.Int(10),
+						"after":  githubv4.String("cursor-0"),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"user": map[string]any{"projectV2": projectResponse},
+					}),
+				),
+			),
+		},
+		{
+			name: "invalid owner type",
+			requestArgs: map[string]any{
+				"owner":          "octocat",
+				"owner_type":     "team",
+				"project_number": float64(7),
+			},
+			mockedClient:    githubv4mock.NewMockedHTTPClient(),
+			expectToolError: true,
+			expectedErrMsg:  "owner_type must be either org or user",
+		},
+		{
+			name: "project not found",
+			requestArgs: map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(99),
+			},
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					orgProjectItemsQuery{},
+					map[string]any{
+						"owner":  githubv4.String("octo-org"),
+						"number": githubv4.Int(99),
+						"first":  githubv4.Int(30),
+						"after":  (*githubv4.String)(nil),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to a ProjectV2 with the number 99."),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "failed to list project items",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ListProjectItems(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned struct {
+				ProjectID   string        `json:"project_id"`
+				Items       []ProjectItem `json:"items"`
+				HasNextPage bool          `json:"has_next_page"`
+				EndCursor   string        `json:"end_cursor"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "PVT_project", returned.ProjectID)
+			assert.True(t, returned.HasNextPage)
+			assert.Equal(t, "cursor-1", returned.EndCursor)
+			require.Len(t, returned.Items, 1)
+			item := returned.Items[0]
+			assert.Equal(t, "PVTI_issue", item.ID)
+			assert.Equal(t, 42, item.Number)
+			assert.Equal(t, "owner/repo", item.Repository)
+			assert.Equal(t, "In Progress", item.Fields["Status"])
+			assert.Equal(t, float64(3), item.Fields["Estimate"])
+		})
+	}
+}
+
+func Test_AddItemToProject(t *testing.T) {
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := AddItemToProject(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_item_to_project", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "owner_type", "project_number", "repo_owner", "repo", "issue_number"})
+
+	projectIDMatcher := githubv4mock.NewQueryMatcher(
+		orgProjectIDQuery{},
+		map[string]any{
+			"owner":  githubv4.String("octo-org"),
+			"number": githubv4.Int(7),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"organization": map[string]any{
+				"projectV2": map[string]any{"id": "PVT_project"},
+			},
+		}),
+	)
+
+	contentIDQueryVars := map[string]any{
+		"owner":  githubv4.String("owner"),
+		"name":   githubv4.String("repo"),
+		"number": githubv4.Int(42),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "add a pull request to an organization project",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				projectIDMatcher,
+				githubv4mock.NewQueryMatcher(
+					issueOrPullRequestIDQuery{},
+					contentIDQueryVars,
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"issueOrPullRequest": map[string]any{"id": "PR_node"},
+						},
+					}),
+				),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						AddProjectV2ItemByID struct {
+							Item struct {
+								ID githubv4.ID
+							}
+						} `graphql:"addProjectV2ItemById(input: $input)"`
+					}{},
+					githubv4.AddProjectV2ItemByIdInput{
+						ProjectID: githubv4.ID("PVT_project"),
+						ContentID: githubv4.ID("PR_node"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addProjectV2ItemById": map[string]any{
+							"item": map[string]any{"id": "PVTI_new"},
+						},
+					}),
+				),
+			),
+		},
+		{
+			name: "issue not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				projectIDMatcher,
+				githubv4mock.NewQueryMatcher(
+					issueOrPullRequestIDQuery{},
+					contentIDQueryVars,
+					githubv4mock.ErrorResponse("Could not resolve to an issue or pull request with the number of 42."),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "failed to get issue or pull request ID",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := AddItemToProject(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]any{
+				"owner":          "octo-org",
+				"owner_type":     "org",
+				"project_number": float64(7),
+				"repo_owner":     "owner",
+				"repo":           "repo",
+				"issue_number":   float64(42),
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, textContent.Text)
+			var returned map[string]string
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, "PVTI_new", returned["item_id"])
+			assert.Equal(t, "PVT_project", returned["project_id"])
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -428,8 +430,7 @@ func Test_ListPullRequests(t *testing.T) {
 
 			// Unmarshal and verify the result
 			var returnedPRs []*github.PullRequest
-			err = json.Unmarshal([]byte(textContent.Text), &returnedPRs)
-			require.NoError(t, err)
+			getListResult(t, textContent.Text, &returnedPRs)
 			assert.Len(t, returnedPRs, 2)
 			assert.Equal(t, *tc.expectedPRs[0].Number, *returnedPRs[0].Number)
 			assert.Equal(t, *tc.expectedPRs[0].Title, *returnedPRs[0].Title)
@@ -454,6 +455,7 @@ func Test_MergePullRequest(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "commit_title")
 	assert.Contains(t, tool.InputSchema.Properties, "commit_message")
 	assert.Contains(t, tool.InputSchema.Properties, "merge_method")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	// Setup mock merge result for success case
@@ -468,6 +470,7 @@ func Test_MergePullRequest(t *testing.T) {
 		mockedClient        *http.Client
 		requestArgs         map[string]interface{}
 		expectError         bool
+		expectToolError     bool
 		expectedMergeResult *github.PullRequestMergeResult
 		expectedErrMsg      string
 	}{
@@ -497,7 +500,30 @@ func Test_MergePullRequest(t *testing.T) {
 			expectedMergeResult: mockMergeResult,
 		},
 		{
-			name: "merge fails",
+			name: "successful merge at expected head sha",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"merge_method": "rebase",
+						"sha":          "abcd1234",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockMergeResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"pullNumber":   float64(42),
+				"merge_method": "rebase",
+				"sha":          "abcd1234",
+			},
+			expectError:         false,
+			expectedMergeResult: mockMergeResult,
+		},
+		{
+			name: "pull request not mergeable",
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
@@ -512,6 +538,45 @@ func Test_MergePullRequest(t *testing.T) {
 				"repo":       "repo",
 				"pullNumber": float64(42),
 			},
+			expectToolError: true,
+			expectedErrMsg:  `{"error":"not_mergeable","message":"Pull request cannot be merged","reason":"not_mergeable"}`,
+		},
+		{
+			name: "head sha mismatch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusConflict)
+						_, _ = w.Write([]byte(`{"message": "Head branch was modified. Review and try the merge again."}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"sha":        "stale",
+			},
+			expectToolError: true,
+			expectedErrMsg:  `"reason":"head_sha_mismatch"`,
+		},
+		{
+			name: "merge fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusInternalServerError)
+						_, _ = w.Write([]byte(`{"message": "Internal error"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
 			expectError:    true,
 			expectedErrMsg: "failed to merge pull request",
 		},
@@ -538,6 +603,12 @@ func Test_MergePullRequest(t *testing.T) {
 
 			require.NoError(t, err)
 
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
@@ -955,6 +1026,8 @@ func Test_GetPullRequestComments(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	// Setup mock PR comments for success case
@@ -998,9 +1071,13 @@ func Test_GetPullRequestComments(t *testing.T) {
 		{
 			name: "successful comments fetch",
 			mockedClient: mock.NewMockedHTTPClient(
-				mock.WithRequestMatch(
+				mock.WithRequestMatchHandler(
 					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
-					mockComments,
+					expectQueryParams(t, map[string]string{
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockComments),
+					),
 				),
 			),
 			requestArgs: map[string]interface{}{
@@ -1011,6 +1088,29 @@ func Test_GetPullRequestComments(t *testing.T) {
 			expectError:      false,
 			expectedComments: mockComments,
 		},
+		{
+			name: "successful comments fetch with pagination",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsCommentsByOwnerByRepoByPullNumber,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockComments),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"page":       float64(2),
+				"perPage":    float64(10),
+			},
+			expectError:      false,
+			expectedComments: mockComments,
+		},
 		{
 			name: "comments fetch fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -1195,6 +1295,46 @@ func Test_GetPullRequestReviews(t *testing.T) {
 	}
 }
 
+func Test_GetPullRequestReviewsAllPages(t *testing.T) {
+	// Three pages of one review each, the first announcing the last page so the rest are fetched concurrently
+	var mu sync.Mutex
+	var pages []string
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsReviewsByOwnerByRepoByPullNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page := r.URL.Query().Get("page")
+				assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+				mu.Lock()
+				pages = append(pages, page)
+				mu.Unlock()
+				if page == "1" {
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/pulls/42/reviews?page=2>; rel="next", <https://api.github.com/repos/owner/repo/pulls/42/reviews?page=3>; rel="last"`)
+				}
+				id, _ := strconv.Atoi(page)
+				_ = json.NewEncoder(w).Encode([]*github.PullRequestReview{{ID: github.Ptr(int64(200 + id))}})
+			}),
+		),
+	))
+	_, handler := GetPullRequestReviews(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	ctx := ContextWithPaginationConcurrency(context.Background(), 2)
+	result, err := handler(ctx, createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	}))
+	require.NoError(t, err)
+
+	var reviews []*github.PullRequestReview
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &reviews))
+	require.Len(t, reviews, 3)
+	for i, review := range reviews {
+		assert.Equal(t, int64(201+i), review.GetID())
+	}
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, pages)
+}
+
 func Test_CreatePullRequest(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -2291,3 +2431,107 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 		),
 	)
 }
+
+func Test_ReplyToReviewComment(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReplyToReviewComment(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "reply_to_review_comment", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "comment_id")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "comment_id", "body"})
+
+	mockReply := &github.PullRequestComment{
+		ID:        github.Ptr(int64(201)),
+		Body:      github.Ptr("Done, renamed"),
+		InReplyTo: github.Ptr(int64(102)),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful reply",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"body":        "Done, renamed",
+						"in_reply_to": float64(102),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockReply),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"comment_id": float64(102),
+				"body":       "Done, renamed",
+			},
+			expectError: false,
+		},
+		{
+			name: "reply fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"comment_id": float64(999),
+				"body":       "Done, renamed",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to reply to review comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReplyToReviewComment(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedComment github.PullRequestComment
+			err = json.Unmarshal([]byte(textContent.Text), &returnedComment)
+			require.NoError(t, err)
+			assert.Equal(t, *mockReply.ID, *returnedComment.ID)
+			assert.Equal(t, *mockReply.InReplyTo, *returnedComment.InReplyTo)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// reactionContents are the reactions GitHub accepts
+var reactionContents = []string{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"}
+
+// reactionSubjectTypes are the things reactions can be added to. Pull requests take reactions
+// through the issues API, the same way they take comments.
+var reactionSubjectTypes = []string{"issue", "pull_request", "issue_comment", "pull_request_review_comment"}
+
+// MinimalReaction is a reaction with the login of the user who left it.
+type MinimalReaction struct {
+	ID        int64      `json:"id"`
+	Content   string     `json:"content"`
+	User      string     `json:"user,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+func newMinimalReaction(reaction *github.Reaction) MinimalReaction {
+	minimal := MinimalReaction{
+		ID:      reaction.GetID(),
+		Content: reaction.GetContent(),
+		User:    reaction.GetUser().GetLogin(),
+	}
+	if reaction.CreatedAt != nil {
+		minimal.CreatedAt = &reaction.CreatedAt.Time
+	}
+	return minimal
+}
+
+// withReactionSubject adds the parameters identifying what a reaction belongs to.
+func withReactionSubject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithString("subject_type",
+			mcp.Required(),
+			mcp.Description("What the reaction belongs to"),
+			mcp.Enum(reactionSubjectTypes...),
+		)(tool)
+		mcp.WithNumber("subject_id",
+			mcp.Required(),
+			mcp.Description("Issue or pull request number, or comment ID for comments"),
+		)(tool)
+	}
+}
+
+// reactionSubjectParams reads the parameters added by withReactionSubject
+func reactionSubjectParams(request mcp.CallToolRequest) (owner, repo, subjectType string, subjectID int, err error) {
+	if owner, err = requiredParam[string](request, "owner"); err != nil {
+		return "", "", "", 0, err
+	}
+	if repo, err = requiredParam[string](request, "repo"); err != nil {
+		return "", "", "", 0, err
+	}
+	if subjectType, err = requiredParam[string](request, "subject_type"); err != nil {
+		return "", "", "", 0, err
+	}
+	if !slices.Contains(reactionSubjectTypes, subjectType) {
+		return "", "", "", 0, fmt.Errorf("invalid subject_type %q, must be one of %v", subjectType, reactionSubjectTypes)
+	}
+	if subjectID, err = RequiredInt(request, "subject_id"); err != nil {
+		return "", "", "", 0, err
+	}
+	return owner, repo, subjectType, subjectID, nil
+}
+
+// ListReactions creates a tool to list the reactions to an issue, pull request or comment.
+func ListReactions(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_reactions",
+			mcp.WithDescription(t("TOOL_LIST_REACTIONS_DESCRIPTION", "List the reactions to an issue, pull request, issue comment or pull request review comment")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_REACTIONS_USER_TITLE", "List reactions"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Description("Only list reactions of this kind"),
+				mcp.Enum(reactionContents...),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, subjectType, subjectID, err := reactionSubjectParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := OptionalParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if content != "" && !slices.Contains(reactionContents, content) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid content %q, must be one of %v", content, reactionContents)), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListReactionOptions{
+				Content: content,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var reactions []*github.Reaction
+			var resp *github.Response
+			switch subjectType {
+			case "issue", "pull_request":
+				reactions, resp, err = client.Reactions.ListIssueReactions(ctx, owner, repo, subjectID, opts)
+			case "issue_comment":
+				reactions, resp, err = client.Reactions.ListIssueCommentReactions(ctx, owner, repo, int64(subjectID), opts)
+			case "pull_request_review_comment":
+				reactions, resp, err = client.Reactions.ListPullRequestCommentReactions(ctx, owner, repo, int64(subjectID), opts)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to list reactions: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list reactions: %s", string(body))), nil
+			}
+
+			minimalReactions := make([]MinimalReaction, 0, len(reactions))
+			for _, reaction := range reactions {
+				minimalReactions = append(minimalReactions, newMinimalReaction(reaction))
+			}
+
+			return MarshalledListResult(minimalReactions, resp, nil), nil
+		}
+}
+
+// CreateReaction creates a tool to react to an issue, pull request or comment.
+func CreateReaction(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_reaction",
+			mcp.WithDescription(t("TOOL_CREATE_REACTION_DESCRIPTION", "Add a reaction to an issue, pull request, issue comment or pull request review comment. Adding a reaction the user already left returns the existing reaction")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_CREATE_REACTION_USER_TITLE", "Add reaction"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("Kind of reaction"),
+				mcp.Enum(reactionContents...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, subjectType, subjectID, err := reactionSubjectParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := requiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(reactionContents, content) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid content %q, must be one of %v", content, reactionContents)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var reaction *github.Reaction
+			var resp *github.Response
+			switch subjectType {
+			case "issue", "pull_request":
+				reaction, resp, err = client.Reactions.CreateIssueReaction(ctx, owner, repo, subjectID, content)
+			case "issue_comment":
+				reaction, resp, err = client.Reactions.CreateIssueCommentReaction(ctx, owner, repo, int64(subjectID), content)
+			case "pull_request_review_comment":
+				reaction, resp, err = client.Reactions.CreatePullRequestCommentReaction(ctx, owner, repo, int64(subjectID), content)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to create reaction: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// 200 means the user had already left this reaction
+			if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create reaction: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalReaction(reaction)), nil
+		}
+}
@@ -0,0 +1,284 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListReactions(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListReactions(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_reactions", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "subject_type")
+	assert.Contains(t, tool.InputSchema.Properties, "subject_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "subject_id"})
+
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockReactions := []*github.Reaction{
+		{ID: github.Ptr(int64(1)), Content: github.Ptr("heart"), User: &github.User{Login: github.Ptr("octocat")}, CreatedAt: &github.Timestamp{Time: createdAt}},
+	}
+	expectedReactions := []MinimalReaction{
+		{ID: 1, Content: "heart", User: "octocat", CreatedAt: &createdAt},
+	}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectError       bool
+		expectToolError   bool
+		expectedErrMsg    string
+		expectedReactions []MinimalReaction
+	}{
+		{
+			name: "pull request reactions of one kind",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/reactions").andThen(
+						expectQueryParams(t, map[string]string{"content": "heart", "page": "1", "per_page": "30"}).andThen(
+							mockResponse(t, http.StatusOK, mockReactions),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request",
+				"subject_id":   float64(42),
+				"content":      "heart",
+			},
+			expectedReactions: expectedReactions,
+		},
+		{
+			name: "review comment reactions",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					expectPath(t, "/repos/owner/repo/pulls/comments/7/reactions").andThen(
+						mockResponse(t, http.StatusOK, mockReactions),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"subject_id":   float64(7),
+			},
+			expectedReactions: expectedReactions,
+		},
+		{
+			name:         "invalid subject type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "release",
+				"subject_id":   float64(7),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid subject_type",
+		},
+		{
+			name: "comment not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"subject_id":   float64(7),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list reactions",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListReactions(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedReactions []MinimalReaction
+			getListResult(t, textContent.Text, &returnedReactions)
+			assert.Equal(t, tc.expectedReactions, returnedReactions)
+		})
+	}
+}
+
+func Test_CreateReaction(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateReaction(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "subject_id", "content"})
+
+	mockReaction := &github.Reaction{
+		ID:      github.Ptr(int64(9)),
+		Content: github.Ptr("rocket"),
+		User:    &github.User{Login: github.Ptr("octocat")},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectToolError  bool
+		expectedErrMsg   string
+		expectedReaction MinimalReaction
+	}{
+		{
+			name: "react to an issue comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					expectPath(t, "/repos/owner/repo/issues/comments/7/reactions").andThen(
+						expectRequestBody(t, map[string]any{"content": "rocket"}).andThen(
+							mockResponse(t, http.StatusCreated, mockReaction),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"subject_id":   float64(7),
+				"content":      "rocket",
+			},
+			expectedReaction: MinimalReaction{ID: 9, Content: "rocket", User: "octocat"},
+		},
+		{
+			name: "reaction already left",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusOK, mockReaction),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"subject_id":   float64(42),
+				"content":      "rocket",
+			},
+			expectedReaction: MinimalReaction{ID: 9, Content: "rocket", User: "octocat"},
+		},
+		{
+			name:         "invalid content",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"subject_id":   float64(42),
+				"content":      "thumbsup",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid content",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"subject_id":   float64(999),
+				"content":      "heart",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create reaction",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateReaction(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedReaction MinimalReaction
+			err = json.Unmarshal([]byte(textContent.Text), &returnedReaction)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReaction, returnedReaction)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// defaultBranchCacheTTL is how long a resolved default branch is reused before the
+// repository metadata is queried again.
+var defaultBranchCacheTTL = time.Minute
+
+type defaultBranchKey struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+type defaultBranchEntry struct {
+	branch  string
+	expires time.Time
+}
+
+var defaultBranches = struct {
+	mu      sync.Mutex
+	entries map[defaultBranchKey]defaultBranchEntry
+}{entries: make(map[defaultBranchKey]defaultBranchEntry)}
+
+// resolveRef returns ref unchanged when it is set, and the repository's default branch otherwise.
+// Tools taking an optional ref use it so that an empty ref consistently means "the default branch".
+func resolveRef(ctx context.Context, client *github.Client, owner, repo, ref string) (string, error) {
+	if ref != "" {
+		return ref, nil
+	}
+	return defaultBranch(ctx, client, owner, repo)
+}
+
+// defaultBranch returns the default branch of a repository, briefly caching the answer per client.
+func defaultBranch(ctx context.Context, client *github.Client, owner, repo string) (string, error) {
+	key := defaultBranchKey{client: client, owner: owner, repo: repo}
+	now := time.Now()
+
+	defaultBranches.mu.Lock()
+	entry, ok := defaultBranches.entries[key]
+	defaultBranches.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.branch, nil
+	}
+
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read response body: %w", err)
+		}
+		return "", fmt.Errorf("failed to get repository: %s", string(body))
+	}
+
+	branch := repository.GetDefaultBranch()
+	if branch == "" {
+		return "", fmt.Errorf("repository %s/%s has no default branch", owner, repo)
+	}
+
+	defaultBranches.mu.Lock()
+	defer defaultBranches.mu.Unlock()
+	for k, e := range defaultBranches.entries {
+		if now.After(e.expires) {
+			delete(defaultBranches.entries, k)
+		}
+	}
+	defaultBranches.entries[key] = defaultBranchEntry{branch: branch, expires: now.Add(defaultBranchCacheTTL)}
+
+	return branch, nil
+}
+
+// forgetDefaultBranch drops the cached default branch of a repository after it was changed
+func forgetDefaultBranch(client *github.Client, owner, repo string) {
+	defaultBranches.mu.Lock()
+	defer defaultBranches.mu.Unlock()
+	delete(defaultBranches.entries, defaultBranchKey{client: client, owner: owner, repo: repo})
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResolveRef(t *testing.T) {
+	calls := 0
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls++
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"default_branch": "trunk"}`))
+			}),
+		),
+	))
+
+	// An explicit ref is used as is
+	ref, err := resolveRef(context.Background(), client, "owner", "repo", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, "feature", ref)
+	assert.Equal(t, 0, calls)
+
+	// An empty ref resolves to the default branch, which is then cached
+	for i := 0; i < 2; i++ {
+		ref, err = resolveRef(context.Background(), client, "owner", "repo", "")
+		require.NoError(t, err)
+		assert.Equal(t, "trunk", ref)
+	}
+	assert.Equal(t, 1, calls)
+}
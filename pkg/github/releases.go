@@ -0,0 +1,131 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ReleaseAssetDownloads is the download count of a release asset.
+type ReleaseAssetDownloads struct {
+	Name          string `json:"name"`
+	DownloadCount int    `json:"download_count"`
+	Size          int    `json:"size"`
+}
+
+// ReleaseDownloads is the download count of a release, the sum of those of its assets.
+type ReleaseDownloads struct {
+	TagName       string                  `json:"tag_name"`
+	Name          string                  `json:"name,omitempty"`
+	Prerelease    bool                    `json:"prerelease,omitempty"`
+	PublishedAt   *time.Time              `json:"published_at,omitempty"`
+	DownloadCount int                     `json:"download_count"`
+	Assets        []ReleaseAssetDownloads `json:"assets"`
+}
+
+// ReleaseDownloadStats are the download counts of the latest releases of a repository.
+type ReleaseDownloadStats struct {
+	TotalDownloadCount int                `json:"total_download_count"`
+	Releases           []ReleaseDownloads `json:"releases"`
+}
+
+// newReleaseDownloadStats sums the download counts of the assets of releases. Drafts are skipped, they
+// cannot be downloaded.
+func newReleaseDownloadStats(releases []*github.RepositoryRelease) ReleaseDownloadStats {
+	stats := ReleaseDownloadStats{Releases: []ReleaseDownloads{}}
+	for _, release := range releases {
+		if release.GetDraft() {
+			continue
+		}
+
+		downloads := ReleaseDownloads{
+			TagName:    release.GetTagName(),
+			Name:       release.GetName(),
+			Prerelease: release.GetPrerelease(),
+			Assets:     make([]ReleaseAssetDownloads, 0, len(release.Assets)),
+		}
+		if release.PublishedAt != nil {
+			downloads.PublishedAt = &release.PublishedAt.Time
+		}
+		for _, asset := range release.Assets {
+			downloads.Assets = append(downloads.Assets, ReleaseAssetDownloads{
+				Name:          asset.GetName(),
+				DownloadCount: asset.GetDownloadCount(),
+				Size:          asset.GetSize(),
+			})
+			downloads.DownloadCount += asset.GetDownloadCount()
+		}
+
+		stats.TotalDownloadCount += downloads.DownloadCount
+		stats.Releases = append(stats.Releases, downloads)
+	}
+	return stats
+}
+
+// GetReleaseDownloadStats creates a tool to get the download counts of the latest releases of a repository.
+func GetReleaseDownloadStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_release_download_stats",
+			mcp.WithDescription(t("TOOL_GET_RELEASE_DOWNLOAD_STATS_DESCRIPTION", "Get the download counts of the latest releases of a repository, newest first, with the count of each asset, the total of each release and the total across them. Only release assets are counted, not the source code archives GitHub generates")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RELEASE_DOWNLOAD_STATS_USER_TITLE", "Get release download stats"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("latest",
+				mcp.Description("Number of latest releases to include (min 1, max 100), defaults to 10"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			latest, err := OptionalIntParamWithDefault(request, "latest", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if latest < 1 || latest > 100 {
+				return mcp.NewToolResultError("latest must be between 1 and 100"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			releases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: latest})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list releases: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list releases: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newReleaseDownloadStats(releases)), nil
+		}
+}
@@ -0,0 +1,169 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetReleaseDownloadStats(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetReleaseDownloadStats(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_release_download_stats", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "latest")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	publishedAt := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	mockReleases := []*github.RepositoryRelease{
+		{
+			TagName: github.Ptr("v2.0.0-rc.1"),
+			Draft:   github.Ptr(true),
+		},
+		{
+			TagName:     github.Ptr("v1.1.0"),
+			Name:        github.Ptr("v1.1.0"),
+			PublishedAt: &github.Timestamp{Time: publishedAt},
+			Assets: []*github.ReleaseAsset{
+				{Name: github.Ptr("server_linux_amd64.tar.gz"), DownloadCount: github.Ptr(120), Size: github.Ptr(4096)},
+				{Name: github.Ptr("server_darwin_arm64.tar.gz"), DownloadCount: github.Ptr(30), Size: github.Ptr(4000)},
+			},
+		},
+		{
+			TagName:    github.Ptr("v1.0.0-beta"),
+			Prerelease: github.Ptr(true),
+			Assets: []*github.ReleaseAsset{
+				{Name: github.Ptr("server_linux_amd64.tar.gz"), DownloadCount: github.Ptr(5), Size: github.Ptr(3900)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        ReleaseDownloadStats
+	}{
+		{
+			name: "latest releases",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per_page": "3"}).andThen(
+						mockResponse(t, http.StatusOK, mockReleases),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"latest": float64(3),
+			},
+			expected: ReleaseDownloadStats{
+				TotalDownloadCount: 155,
+				Releases: []ReleaseDownloads{
+					{
+						TagName:       "v1.1.0",
+						Name:          "v1.1.0",
+						PublishedAt:   &publishedAt,
+						DownloadCount: 150,
+						Assets: []ReleaseAssetDownloads{
+							{Name: "server_linux_amd64.tar.gz", DownloadCount: 120, Size: 4096},
+							{Name: "server_darwin_arm64.tar.gz", DownloadCount: 30, Size: 4000},
+						},
+					},
+					{
+						TagName:       "v1.0.0-beta",
+						Prerelease:    true,
+						DownloadCount: 5,
+						Assets: []ReleaseAssetDownloads{
+							{Name: "server_linux_amd64.tar.gz", DownloadCount: 5, Size: 3900},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "repository without releases",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"per_page": "10"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.RepositoryRelease{}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expected: ReleaseDownloadStats{Releases: []ReleaseDownloads{}},
+		},
+		{
+			name:         "too many releases",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"latest": float64(101),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "latest must be between 1 and 100",
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposReleasesByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list releases",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetReleaseDownloadStats(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var stats ReleaseDownloadStats
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &stats))
+			assert.Equal(t, tc.expected, stats)
+		})
+	}
+}
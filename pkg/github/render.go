@@ -0,0 +1,116 @@
+package github
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// RenderModeRaw returns issue and comment bodies as GitHub stores them, in markdown.
+	RenderModeRaw = "raw"
+	// RenderModeText strips markdown and HTML formatting from issue and comment bodies.
+	RenderModeText = "text"
+)
+
+// WithRenderMode adds the render_mode parameter to tools returning issue or comment bodies.
+func WithRenderMode() mcp.ToolOption {
+	return mcp.WithString("render_mode",
+		mcp.Description("How to return issue and comment bodies: 'raw' markdown as written on GitHub, or 'text' with the formatting stripped"),
+		mcp.Enum(RenderModeRaw, RenderModeText),
+		mcp.DefaultString(RenderModeRaw),
+	)
+}
+
+// renderModeParam returns the render mode requested by a tool call, defaulting to raw.
+func renderModeParam(r mcp.CallToolRequest) (string, error) {
+	mode, err := OptionalParam[string](r, "render_mode")
+	if err != nil {
+		return "", err
+	}
+	switch mode {
+	case "":
+		return RenderModeRaw, nil
+	case RenderModeRaw, RenderModeText:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid render_mode %q, expected %q or %q", mode, RenderModeRaw, RenderModeText)
+	}
+}
+
+// renderBody rewrites body in place according to mode.
+func renderBody(body *string, mode string) {
+	if body == nil || mode != RenderModeText {
+		return
+	}
+	*body = markdownToText(*body)
+}
+
+// renderIssues applies the render mode to the body of every issue.
+func renderIssues(mode string, issues ...*github.Issue) {
+	for _, issue := range issues {
+		renderBody(issue.Body, mode)
+	}
+}
+
+// renderIssueComments applies the render mode to the body of every issue comment.
+func renderIssueComments(mode string, comments ...*github.IssueComment) {
+	for _, comment := range comments {
+		renderBody(comment.Body, mode)
+	}
+}
+
+// renderPullRequestComments applies the render mode to the body of every review comment.
+func renderPullRequestComments(mode string, comments ...*github.PullRequestComment) {
+	for _, comment := range comments {
+		renderBody(comment.Body, mode)
+	}
+}
+
+var (
+	htmlCommentPattern   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	codeFencePattern     = regexp.MustCompile("(?m)^[ \t]*(```|~~~).*$\n?")
+	htmlBreakPattern     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	htmlTagPattern       = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+	imagePattern         = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern          = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	headingPattern       = regexp.MustCompile(`(?m)^[ \t]*#{1,6}[ \t]+`)
+	blockquotePattern    = regexp.MustCompile(`(?m)^[ \t]*>[ \t]?`)
+	ruleLinePattern      = regexp.MustCompile(`(?m)^[ \t]*([-*_][ \t]*){3,}$`)
+	taskItemPattern      = regexp.MustCompile(`(?m)^([ \t]*[-*+][ \t]+)\[[ xX]\][ \t]+`)
+	strongPattern        = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	emphasisStarPattern  = regexp.MustCompile(`\*(\S(?:[^*]*?\S)?)\*`)
+	emphasisUnderPattern = regexp.MustCompile(`(^|[^\w])_(\S(?:[^_]*?\S)?)_([^\w]|$)`)
+	strikePattern        = regexp.MustCompile(`~~(.+?)~~`)
+	inlineCodePattern    = regexp.MustCompile("`([^`]+)`")
+	blankLinesPattern    = regexp.MustCompile(`\n{3,}`)
+)
+
+// markdownToText is a small, lossy markdown to plain text pass. It keeps the words and the line
+// structure of a body and drops the markup around them: code fences, HTML tags, link targets,
+// heading and quote markers, and emphasis. It does not try to handle every corner of the spec.
+func markdownToText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = htmlCommentPattern.ReplaceAllString(s, "")
+	s = codeFencePattern.ReplaceAllString(s, "")
+	s = htmlBreakPattern.ReplaceAllString(s, "\n")
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = imagePattern.ReplaceAllString(s, "$1")
+	s = linkPattern.ReplaceAllString(s, "$1")
+	s = ruleLinePattern.ReplaceAllString(s, "")
+	s = headingPattern.ReplaceAllString(s, "")
+	s = blockquotePattern.ReplaceAllString(s, "")
+	s = taskItemPattern.ReplaceAllString(s, "$1")
+	s = strongPattern.ReplaceAllString(s, "$2")
+	s = emphasisStarPattern.ReplaceAllString(s, "$1")
+	s = emphasisUnderPattern.ReplaceAllString(s, "$1$2$3")
+	s = strikePattern.ReplaceAllString(s, "$1")
+	s = inlineCodePattern.ReplaceAllString(s, "$1")
+	s = html.UnescapeString(s)
+	s = blankLinesPattern.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
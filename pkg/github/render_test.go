@@ -0,0 +1,100 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RenderModeParam(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestArgs  map[string]interface{}
+		expectedMode string
+		expectError  bool
+	}{
+		{
+			name:         "defaults to raw",
+			requestArgs:  map[string]interface{}{},
+			expectedMode: RenderModeRaw,
+		},
+		{
+			name:         "text",
+			requestArgs:  map[string]interface{}{"render_mode": "text"},
+			expectedMode: RenderModeText,
+		},
+		{
+			name:        "unknown mode",
+			requestArgs: map[string]interface{}{"render_mode": "html"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := renderModeParam(createMCPRequest(tc.requestArgs))
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMode, mode)
+		})
+	}
+}
+
+func Test_MarkdownToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text is unchanged",
+			input:    "Nothing to strip here",
+			expected: "Nothing to strip here",
+		},
+		{
+			name:     "headings and emphasis",
+			input:    "# Title\n\nSome **bold**, *italic*, _under_ and ~~struck~~ text",
+			expected: "Title\n\nSome bold, italic, under and struck text",
+		},
+		{
+			name:     "snake_case identifiers are kept",
+			input:    "call my_func_name now",
+			expected: "call my_func_name now",
+		},
+		{
+			name:     "links and images keep their text",
+			input:    "See [the docs](https://example.com) and ![diagram](https://example.com/a.png)",
+			expected: "See the docs and diagram",
+		},
+		{
+			name:     "code fences and inline code",
+			input:    "Run `make`:\n```sh\nmake build\n```",
+			expected: "Run make:\nmake build",
+		},
+		{
+			name:     "html tags, comments and entities",
+			input:    "<!-- template -->\n<details><summary>Logs</summary>\n\na &amp; b<br>c</details>",
+			expected: "Logs\n\na & b\nc",
+		},
+		{
+			name:     "quotes, rules and task lists",
+			input:    "> quoted\n\n---\n\n- [x] done\n- [ ] todo",
+			expected: "quoted\n\n- done\n- todo",
+		},
+		{
+			name:     "blank lines are collapsed",
+			input:    "a\r\n\r\n\r\n\r\nb",
+			expected: "a\n\nb",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, markdownToText(tc.input))
+		})
+	}
+}
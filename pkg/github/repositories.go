@@ -3,9 +3,13 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
@@ -29,8 +33,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Description("Repository name"),
 			),
 			mcp.WithString("sha",
-				mcp.Required(),
-				mcp.Description("Commit SHA, branch name, or tag name"),
+				mcp.Description("Commit SHA, branch name, or tag name, defaults to the repository's default branch"),
 			),
 			WithPagination(),
 		),
@@ -43,7 +46,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			sha, err := requiredParam[string](request, "sha")
+			sha, err := OptionalParam[string](request, "sha")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -61,6 +64,10 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+			sha, err = resolveRef(ctx, client, owner, repo, sha)
+			if err != nil {
+				return nil, err
+			}
 			commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get commit: %w", err)
@@ -101,7 +108,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("Repository name"),
 			),
 			mcp.WithString("sha",
-				mcp.Description("SHA or Branch name"),
+				mcp.Description("SHA or Branch name, defaults to the repository's default branch"),
 			),
 			WithPagination(),
 		),
@@ -123,6 +130,15 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			sha, err = resolveRef(ctx, client, owner, repo, sha)
+			if err != nil {
+				return nil, err
+			}
+
 			opts := &github.CommitsListOptions{
 				SHA: sha,
 				ListOptions: github.ListOptions{
@@ -130,11 +146,6 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 					PerPage: pagination.perPage,
 				},
 			}
-
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
 			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to list commits: %w", err)
@@ -149,12 +160,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(commits)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledListResult(commits, resp, nil), nil
 		}
 }
 
@@ -216,12 +222,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list branches: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(branches)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledListResult(branches, resp, nil), nil
 		}
 }
 
@@ -260,6 +261,7 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			mcp.WithString("sha",
 				mcp.Description("SHA of file being replaced (for updates)"),
 			),
+			WithAllowSecrets(),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -286,6 +288,9 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			if result, err := blockSecrets(ctx, request, secretScanFile{path: path, content: content}); result != nil || err != nil {
+				return result, err
+			}
 
 			// json.Marshal encodes byte arrays with base64, which is required for the API.
 			contentBytes := []byte(content)
@@ -429,7 +434,7 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Description("Path to file/directory"),
 			),
 			mcp.WithString("branch",
-				mcp.Description("Branch to get contents from"),
+				mcp.Description("Branch to get contents from, defaults to the repository's default branch"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -454,6 +459,10 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+			branch, err = resolveRef(ctx, client, owner, repo, branch)
+			if err != nil {
+				return nil, err
+			}
 			opts := &github.RepositoryContentGetOptions{Ref: branch}
 			fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
 			if err != nil {
@@ -485,10 +494,205 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 		}
 }
 
+// Readme is the README of a repository as returned by get_readme.
+type Readme struct {
+	Found bool `json:"found"`
+	// Path is where GitHub found the README, such as README.md or docs/README.rst
+	Path    string `json:"path,omitempty"`
+	SHA     string `json:"sha,omitempty"`
+	Branch  string `json:"branch"`
+	Content string `json:"content,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// GetReadme creates a tool to get the README of a GitHub repository.
+func GetReadme(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_readme",
+			mcp.WithDescription(t("TOOL_GET_README_DESCRIPTION", "Get the decoded README of a GitHub repository, wherever GitHub finds it. To edit it, pass the returned path, sha and branch to create_or_update_file.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_README_USER_TITLE", "Get repository README"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner (username or organization)"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Description("Branch to get the README from, defaults to the repository's default branch"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := OptionalParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			branch, err = resolveRef(ctx, client, owner, repo, branch)
+			if err != nil {
+				return nil, err
+			}
+			readme, resp, err := client.Repositories.GetReadme(ctx, owner, repo, &github.RepositoryContentGetOptions{Ref: branch})
+			// GitHub answers 404 when the repository has no README on the branch
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				return MarshalledTextResult(Readme{
+					Branch:  branch,
+					Message: fmt.Sprintf("%s/%s has no README on %s, create one with create_or_update_file", owner, repo, branch),
+				}), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get README: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get README: %s", string(body))), nil
+			}
+
+			content, err := readme.GetContent()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode README: %w", err)
+			}
+
+			return MarshalledTextResult(Readme{
+				Found:   true,
+				Path:    readme.GetPath(),
+				SHA:     readme.GetSHA(),
+				Branch:  branch,
+				Content: content,
+				URL:     readme.GetHTMLURL(),
+			}), nil
+		}
+}
+
+// forkReadyPollInterval and forkReadyPollAttempts bound how long fork_repository waits for an
+// asynchronous fork to become usable when asked to wait for it.
+var (
+	forkReadyPollInterval = 2 * time.Second
+	forkReadyPollAttempts = 15
+)
+
+// TreeEntry is a file or directory in a git tree.
+type TreeEntry struct {
+	Path string `json:"path"`
+	// Type is blob for files, tree for directories and commit for submodules
+	Type string `json:"type"`
+	Size int    `json:"size,omitempty"`
+	SHA  string `json:"sha"`
+}
+
+// GetTree creates a tool to get the file tree of a GitHub repository at a ref.
+func GetTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_tree",
+			mcp.WithDescription(t("TOOL_GET_TREE_DESCRIPTION", "Get the file tree of a GitHub repository at a branch, tag or commit, listing the path, type and size of each entry")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_TREE_USER_TITLE", "Get repository tree"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag, commit SHA or tree SHA, defaults to the repository's default branch"),
+			),
+			mcp.WithBoolean("recursive",
+				mcp.Description("Include the contents of subdirectories. Otherwise only the top level of the tree is returned"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			recursive, err := OptionalParam[bool](request, "recursive")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref, err = resolveRef(ctx, client, owner, repo, ref)
+			if err != nil {
+				return nil, err
+			}
+
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, recursive)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get tree: %s", string(body))), nil
+			}
+
+			entries := make([]TreeEntry, 0, len(tree.Entries))
+			for _, entry := range tree.Entries {
+				entries = append(entries, TreeEntry{
+					Path: entry.GetPath(),
+					Type: entry.GetType(),
+					Size: entry.GetSize(),
+					SHA:  entry.GetSHA(),
+				})
+			}
+
+			result := map[string]any{
+				"sha":       tree.GetSHA(),
+				"truncated": tree.GetTruncated(),
+				"entries":   entries,
+			}
+			if tree.GetTruncated() {
+				result["warning"] = "The tree is too large to return in full and was truncated. Call get_tree without recursive, then with the SHA of each subdirectory as ref, to walk the rest of it"
+			}
+			return MarshalledTextResult(result), nil
+		}
+}
+
 // ForkRepository creates a tool to fork a repository.
 func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("fork_repository",
-			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization")),
+			mcp.WithDescription(t("TOOL_FORK_REPOSITORY_DESCRIPTION", "Fork a GitHub repository to your account or specified organization. Forking happens asynchronously, set wait_for_ready to wait until the fork can be used.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_FORK_REPOSITORY_USER_TITLE", "Fork repository"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -504,6 +708,12 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			mcp.WithString("organization",
 				mcp.Description("Organization to fork to"),
 			),
+			mcp.WithBoolean("default_branch_only",
+				mcp.Description("Only fork the default branch"),
+			),
+			mcp.WithBoolean("wait_for_ready",
+				mcp.Description("Wait until the fork is ready before returning"),
+			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -518,8 +728,18 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			defaultBranchOnly, err := OptionalParam[bool](request, "default_branch_only")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			waitForReady, err := OptionalParam[bool](request, "wait_for_ready")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
-			opts := &github.RepositoryCreateForkOptions{}
+			opts := &github.RepositoryCreateForkOptions{
+				DefaultBranchOnly: defaultBranchOnly,
+			}
 			if org != "" {
 				opts.Organization = org
 			}
@@ -533,7 +753,10 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
 				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Fork is in progress"), nil
+					if waitForReady && forkedRepo.GetName() != "" {
+						return waitForFork(ctx, client, forkedRepo)
+					}
+					return mcp.NewToolResultText(forkInProgressMessage(forkedRepo)), nil
 				}
 				return nil, fmt.Errorf("failed to fork repository: %w", err)
 			}
@@ -556,6 +779,47 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 		}
 }
 
+// forkInProgressMessage describes a fork that GitHub is still creating in the background.
+func forkInProgressMessage(fork *github.Repository) string {
+	if fork.GetFullName() == "" {
+		return "Fork is in progress"
+	}
+	return fmt.Sprintf("Fork is in progress: %s", fork.GetFullName())
+}
+
+// waitForFork polls the fork until its git data can be read, which is when GitHub has finished
+// creating it. If the fork is still not ready after the last attempt, an in-progress message is returned.
+func waitForFork(ctx context.Context, client *github.Client, fork *github.Repository) (*mcp.CallToolResult, error) {
+	opts := &github.BranchListOptions{
+		ListOptions: github.ListOptions{PerPage: 1},
+	}
+
+	for attempt := 0; attempt < forkReadyPollAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to wait for fork: %w", ctx.Err())
+		case <-time.After(forkReadyPollInterval):
+		}
+
+		branches, resp, err := client.Repositories.ListBranches(ctx, fork.GetOwner().GetLogin(), fork.GetName(), opts)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil || len(branches) == 0 {
+			continue
+		}
+
+		r, err := json.Marshal(fork)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(r)), nil
+	}
+
+	return mcp.NewToolResultText(forkInProgressMessage(fork) + ", it is not ready yet so try again shortly"), nil
+}
+
 // DeleteFile creates a tool to delete a file in a GitHub repository.
 // This tool uses a more roundabout way of deleting a file than just using the client.Repositories.DeleteFile.
 // This is because REST file deletion endpoint (and client.Repositories.DeleteFile) don't add commit signing to the deletion commit,
@@ -763,18 +1027,10 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the source branch SHA
-			var ref *github.Reference
-
-			if fromBranch == "" {
-				// Get default branch if from_branch not specified
-				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get repository: %w", err)
-				}
-				defer func() { _ = resp.Body.Close() }()
-
-				fromBranch = *repository.DefaultBranch
+			// Get default branch if from_branch not specified
+			fromBranch, err = resolveRef(ctx, client, owner, repo, fromBranch)
+			if err != nil {
+				return nil, err
 			}
 
 			// Get SHA of source branch
@@ -805,13 +1061,14 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 		}
 }
 
-// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
-func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
-	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+// SetDefaultBranch creates a tool to change the default branch of a GitHub repository.
+func SetDefaultBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_default_branch",
+			mcp.WithDescription(t("TOOL_SET_DEFAULT_BRANCH_DESCRIPTION", "Change the default branch of a GitHub repository to an existing branch, for example to migrate from master to main. Returns the updated repository")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
-				ReadOnlyHint: toBoolPtr(false),
+				Title:          t("TOOL_SET_DEFAULT_BRANCH_USER_TITLE", "Set default branch"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
 			}),
 			mcp.WithString("owner",
 				mcp.Required(),
@@ -823,31 +1080,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			),
 			mcp.WithString("branch",
 				mcp.Required(),
-				mcp.Description("Branch to push to"),
-			),
-			mcp.WithArray("files",
-				mcp.Required(),
-				mcp.Items(
-					map[string]interface{}{
-						"type":                 "object",
-						"additionalProperties": false,
-						"required":             []string{"path", "content"},
-						"properties": map[string]interface{}{
-							"path": map[string]interface{}{
-								"type":        "string",
-								"description": "path to the file",
-							},
-							"content": map[string]interface{}{
-								"type":        "string",
-								"description": "file content",
-							},
-						},
-					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
-			),
-			mcp.WithString("message",
-				mcp.Required(),
-				mcp.Description("Commit message"),
+				mcp.Description("Name of the existing branch to make the default"),
 			),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -863,66 +1096,416 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			message, err := requiredParam[string](request, "message")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Parse files parameter - this should be an array of objects with path and content
-			filesObj, ok := request.GetArguments()["files"].([]interface{})
-			if !ok {
-				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
-			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the reference for the branch
-			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			// GitHub rejects unknown branches with a generic validation error, so check first
+			_, resp, err := client.Repositories.GetBranch(ctx, owner, repo, branch, 0)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				_ = resp.Body.Close()
+				return mcp.NewToolResultError(fmt.Sprintf("branch %s not found in %s/%s", branch, owner, repo)), nil
+			}
 			if err != nil {
-				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+				return nil, fmt.Errorf("failed to get branch: %w", err)
 			}
-			defer func() { _ = resp.Body.Close() }()
+			_ = resp.Body.Close()
 
-			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			updatedRepo, resp, err := client.Repositories.Edit(ctx, owner, repo, &github.Repository{
+				DefaultBranch: github.Ptr(branch),
+			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to get base commit: %w", err)
+				return nil, fmt.Errorf("failed to set default branch: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Create tree entries for all files
-			var entries []*github.TreeEntry
-
-			for _, file := range filesObj {
-				fileMap, ok := file.(map[string]interface{})
-				if !ok {
-					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
 				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to set default branch: %s", string(body))), nil
+			}
+			forgetDefaultBranch(client, owner, repo)
 
-				path, ok := fileMap["path"].(string)
-				if !ok || path == "" {
-					return mcp.NewToolResultError("each file must have a path"), nil
-				}
+			return MarshalledTextResult(updatedRepo), nil
+		}
+}
 
-				content, ok := fileMap["content"].(string)
-				if !ok {
-					return mcp.NewToolResultError("each file must have content"), nil
-				}
+// RepoSettings are the repository settings update_repo_settings can change.
+type RepoSettings struct {
+	FullName            string `json:"full_name"`
+	Visibility          string `json:"visibility"`
+	Private             bool   `json:"private"`
+	Description         string `json:"description,omitempty"`
+	Homepage            string `json:"homepage,omitempty"`
+	HasIssues           bool   `json:"has_issues"`
+	HasWiki             bool   `json:"has_wiki"`
+	HasProjects         bool   `json:"has_projects"`
+	HasDiscussions      bool   `json:"has_discussions"`
+	AllowMergeCommit    bool   `json:"allow_merge_commit"`
+	AllowSquashMerge    bool   `json:"allow_squash_merge"`
+	AllowRebaseMerge    bool   `json:"allow_rebase_merge"`
+	AllowAutoMerge      bool   `json:"allow_auto_merge"`
+	AllowUpdateBranch   bool   `json:"allow_update_branch"`
+	DeleteBranchOnMerge bool   `json:"delete_branch_on_merge"`
+}
 
-				// Create a tree entry for the file
-				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
-					Mode:    github.Ptr("100644"), // Regular file mode
-					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
-				})
-			}
+func newRepoSettings(repo *github.Repository) RepoSettings {
+	return RepoSettings{
+		FullName:            repo.GetFullName(),
+		Visibility:          repoVisibility(repo),
+		Private:             repo.GetPrivate(),
+		Description:         repo.GetDescription(),
+		Homepage:            repo.GetHomepage(),
+		HasIssues:           repo.GetHasIssues(),
+		HasWiki:             repo.GetHasWiki(),
+		HasProjects:         repo.GetHasProjects(),
+		HasDiscussions:      repo.GetHasDiscussions(),
+		AllowMergeCommit:    repo.GetAllowMergeCommit(),
+		AllowSquashMerge:    repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:    repo.GetAllowRebaseMerge(),
+		AllowAutoMerge:      repo.GetAllowAutoMerge(),
+		AllowUpdateBranch:   repo.GetAllowUpdateBranch(),
+		DeleteBranchOnMerge: repo.GetDeleteBranchOnMerge(),
+	}
+}
 
-			// Create a new tree with the file entries
-			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
+// repoVisibility returns the visibility of a repository, which older GitHub Enterprise Server
+// versions only report through the private flag.
+func repoVisibility(repo *github.Repository) string {
+	if visibility := repo.GetVisibility(); visibility != "" {
+		return visibility
+	}
+	if repo.GetPrivate() {
+		return "private"
+	}
+	return "public"
+}
+
+// repoVisibilities are the visibilities a repository can have
+var repoVisibilities = []string{"public", "private", "internal"}
+
+// repoSettingFlags are the boolean settings of update_repo_settings and the repository fields they set.
+var repoSettingFlags = []struct {
+	name        string
+	description string
+	field       func(*github.Repository) **bool
+}{
+	{"has_issues", "Enable issues", func(r *github.Repository) **bool { return &r.HasIssues }},
+	{"has_wiki", "Enable the wiki", func(r *github.Repository) **bool { return &r.HasWiki }},
+	{"has_projects", "Enable projects", func(r *github.Repository) **bool { return &r.HasProjects }},
+	{"has_discussions", "Enable discussions", func(r *github.Repository) **bool { return &r.HasDiscussions }},
+	{"allow_merge_commit", "Allow merging pull requests with a merge commit", func(r *github.Repository) **bool { return &r.AllowMergeCommit }},
+	{"allow_squash_merge", "Allow squash merging pull requests", func(r *github.Repository) **bool { return &r.AllowSquashMerge }},
+	{"allow_rebase_merge", "Allow rebase merging pull requests", func(r *github.Repository) **bool { return &r.AllowRebaseMerge }},
+	{"allow_auto_merge", "Allow auto-merge on pull requests", func(r *github.Repository) **bool { return &r.AllowAutoMerge }},
+	{"allow_update_branch", "Suggest updating pull request branches that are behind their base", func(r *github.Repository) **bool { return &r.AllowUpdateBranch }},
+	{"delete_branch_on_merge", "Delete head branches when pull requests are merged", func(r *github.Repository) **bool { return &r.DeleteBranchOnMerge }},
+}
+
+// keepsMergeMethod reports whether a repository still allows a pull request merge method once update is applied to
+// its current settings
+func keepsMergeMethod(update, current *github.Repository) bool {
+	allowed := func(updated, current *bool) bool {
+		if updated != nil {
+			return *updated
+		}
+		return current != nil && *current
+	}
+	return allowed(update.AllowMergeCommit, current.AllowMergeCommit) ||
+		allowed(update.AllowSquashMerge, current.AllowSquashMerge) ||
+		allowed(update.AllowRebaseMerge, current.AllowRebaseMerge)
+}
+
+// UpdateRepoSettings creates a tool to change the visibility, features and merge settings of a repository.
+func UpdateRepoSettings(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	options := []mcp.ToolOption{
+		mcp.WithDescription(t("TOOL_UPDATE_REPO_SETTINGS_DESCRIPTION", "Update the visibility, description, features and merge settings of a GitHub repository. Only the settings provided are changed. Changing the visibility requires confirm to be true")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:          t("TOOL_UPDATE_REPO_SETTINGS_USER_TITLE", "Update repository settings"),
+			ReadOnlyHint:   toBoolPtr(false),
+			IdempotentHint: toBoolPtr(true),
+		}),
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		),
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		),
+		mcp.WithBoolean("private",
+			mcp.Description("Make the repository private or public. Not together with visibility"),
+		),
+		mcp.WithString("visibility",
+			mcp.Description("New visibility. internal is only available to organizations on GitHub Enterprise. Not together with private"),
+			mcp.Enum(repoVisibilities...),
+		),
+		mcp.WithString("description",
+			mcp.Description("New description"),
+		),
+		mcp.WithString("homepage",
+			mcp.Description("New homepage URL"),
+		),
+	}
+	for _, flag := range repoSettingFlags {
+		options = append(options, mcp.WithBoolean(flag.name, mcp.Description(flag.description)))
+	}
+	options = append(options, mcp.WithBoolean("confirm",
+		mcp.Description("Must be true to change the visibility of the repository"),
+	))
+
+	return mcp.NewTool("update_repo_settings", options...),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			confirm, err := OptionalParam[bool](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Build the update only with the provided settings
+			update := &github.Repository{}
+			updateNeeded := false
+
+			var visibility string
+			private, privateOK, err := OptionalParamOK[bool](request, "private")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			visibilityParam, visibilityOK, err := OptionalParamOK[string](request, "visibility")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			switch {
+			case privateOK && visibilityOK:
+				return mcp.NewToolResultError("private and visibility cannot be set together, use visibility"), nil
+			case privateOK:
+				visibility = "public"
+				if private {
+					visibility = "private"
+				}
+				update.Private = github.Ptr(private)
+				updateNeeded = true
+			case visibilityOK:
+				if !slices.Contains(repoVisibilities, visibilityParam) {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid visibility %q, must be one of %v", visibilityParam, repoVisibilities)), nil
+				}
+				visibility = visibilityParam
+				update.Visibility = github.Ptr(visibilityParam)
+				updateNeeded = true
+			}
+
+			if description, ok, err := OptionalParamOK[string](request, "description"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.Description = github.Ptr(description)
+				updateNeeded = true
+			}
+
+			if homepage, ok, err := OptionalParamOK[string](request, "homepage"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				update.Homepage = github.Ptr(homepage)
+				updateNeeded = true
+			}
+
+			for _, flag := range repoSettingFlags {
+				if value, ok, err := OptionalParamOK[bool](request, flag.name); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				} else if ok {
+					*flag.field(update) = github.Ptr(value)
+					updateNeeded = true
+				}
+			}
+
+			if !updateNeeded {
+				return mcp.NewToolResultError("No settings provided."), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The checks against the current settings only need the repository when they apply
+			disablesMergeMethod := (update.AllowMergeCommit != nil && !*update.AllowMergeCommit) ||
+				(update.AllowSquashMerge != nil && !*update.AllowSquashMerge) ||
+				(update.AllowRebaseMerge != nil && !*update.AllowRebaseMerge)
+			if visibility != "" || disablesMergeMethod {
+				current, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				if currentVisibility := repoVisibility(current); visibility != "" && visibility != currentVisibility && !confirm {
+					return mcp.NewToolResultError(fmt.Sprintf("changing the visibility of %s/%s from %s to %s must be confirmed, call again with confirm set to true", owner, repo, currentVisibility, visibility)), nil
+				}
+
+				if disablesMergeMethod && !keepsMergeMethod(update, current) {
+					return mcp.NewToolResultError("at least one of allow_merge_commit, allow_squash_merge and allow_rebase_merge must stay enabled"), nil
+				}
+			}
+
+			updatedRepo, resp, err := client.Repositories.Edit(ctx, owner, repo, update)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update repository settings: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update repository settings: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newRepoSettings(updatedRepo)), nil
+		}
+}
+
+// PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
+func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("push_files",
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("branch",
+				mcp.Required(),
+				mcp.Description("Branch to push to"),
+			),
+			mcp.WithArray("files",
+				mcp.Required(),
+				mcp.Items(
+					map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": false,
+						"required":             []string{"path", "content"},
+						"properties": map[string]interface{}{
+							"path": map[string]interface{}{
+								"type":        "string",
+								"description": "path to the file",
+							},
+							"content": map[string]interface{}{
+								"type":        "string",
+								"description": "file content",
+							},
+						},
+					}),
+				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Commit message"),
+			),
+			WithAllowSecrets(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			branch, err := requiredParam[string](request, "branch")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := requiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Parse files parameter - this should be an array of objects with path and content
+			filesObj, ok := request.GetArguments()["files"].([]interface{})
+			if !ok {
+				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
+			}
+
+			// Malformed entries are reported when the tree is built below
+			scanFiles := make([]secretScanFile, 0, len(filesObj))
+			for _, file := range filesObj {
+				fileMap, _ := file.(map[string]interface{})
+				path, _ := fileMap["path"].(string)
+				content, _ := fileMap["content"].(string)
+				scanFiles = append(scanFiles, secretScanFile{path: path, content: content})
+			}
+			if result, err := blockSecrets(ctx, request, scanFiles...); result != nil || err != nil {
+				return result, err
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Get the reference for the branch
+			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get branch reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Get the commit object that the branch points to
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get base commit: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// Create tree entries for all files
+			var entries []*github.TreeEntry
+
+			for _, file := range filesObj {
+				fileMap, ok := file.(map[string]interface{})
+				if !ok {
+					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+				}
+
+				path, ok := fileMap["path"].(string)
+				if !ok || path == "" {
+					return mcp.NewToolResultError("each file must have a path"), nil
+				}
+
+				content, ok := fileMap["content"].(string)
+				if !ok {
+					return mcp.NewToolResultError("each file must have content"), nil
+				}
+
+				// Create a tree entry for the file
+				entries = append(entries, &github.TreeEntry{
+					Path:    github.Ptr(path),
+					Mode:    github.Ptr("100644"), // Regular file mode
+					Type:    github.Ptr("blob"),
+					Content: github.Ptr(content),
+				})
+			}
+
+			// Create a new tree with the file entries
+			newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, entries)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create tree: %w", err)
 			}
@@ -1013,12 +1596,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(fmt.Sprintf("failed to list tags: %s", string(body))), nil
 			}
 
-			r, err := json.Marshal(tags)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return MarshalledListResult(tags, resp, nil), nil
 		}
 }
 
@@ -1100,3 +1678,545 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// CreateTag creates a tool to create an annotated tag and its ref in a GitHub repository.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_tag",
+			mcp.WithDescription(t("TOOL_CREATE_TAG_DESCRIPTION", "Create an annotated git tag pointing at a commit in a GitHub repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_TAG_USER_TITLE", "Create tag"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("tag",
+				mcp.Required(),
+				mcp.Description("Tag name, e.g. v1.2.0"),
+			),
+			mcp.WithString("message",
+				mcp.Required(),
+				mcp.Description("Tag message"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA of the commit to tag"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			tag, err := requiredParam[string](request, "tag")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			message, err := requiredParam[string](request, "message")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// An annotated tag is a tag object plus a ref pointing at it, the tag object alone is not visible
+			tagObj, resp, err := client.Git.CreateTag(ctx, owner, repo, &github.Tag{
+				Tag:     github.Ptr(tag),
+				Message: github.Ptr(message),
+				Object: &github.GitObject{
+					SHA:  github.Ptr(sha),
+					Type: github.Ptr("commit"),
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create tag object: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create tag object: %s", string(body))), nil
+			}
+
+			_, resp, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr("refs/tags/" + tag),
+				Object: &github.GitObject{SHA: tagObj.SHA},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create tag reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create tag reference: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(tagObj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+const (
+	// maxDispatchEventTypeLength is the longest event_type GitHub accepts for a repository dispatch
+	maxDispatchEventTypeLength = 100
+	// maxDispatchPayloadProperties is the most top-level properties GitHub accepts in a client payload
+	maxDispatchPayloadProperties = 10
+	// maxDispatchPayloadBytes bounds the size of a client payload once encoded as JSON
+	maxDispatchPayloadBytes = 64 * 1024
+)
+
+// CreateRepositoryDispatch creates a tool to trigger a repository_dispatch event in a GitHub repository.
+func CreateRepositoryDispatch(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_repository_dispatch",
+			mcp.WithDescription(t("TOOL_CREATE_REPOSITORY_DISPATCH_DESCRIPTION", "Trigger a repository_dispatch event, which starts the GitHub Actions workflows listening for its event type")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_REPOSITORY_DISPATCH_USER_TITLE", "Create repository dispatch event"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("event_type",
+				mcp.Required(),
+				mcp.Description("Custom event type, matched against the types of the repository_dispatch workflow trigger"),
+			),
+			mcp.WithObject("client_payload",
+				mcp.Description("JSON object passed to the workflows as github.event.client_payload, at most 10 top-level properties"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			eventType, err := requiredParam[string](request, "event_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(eventType) > maxDispatchEventTypeLength {
+				return mcp.NewToolResultError(fmt.Sprintf("event_type must be at most %d characters", maxDispatchEventTypeLength)), nil
+			}
+
+			opts := github.DispatchRequestOptions{EventType: eventType}
+			if value, ok := request.GetArguments()["client_payload"]; ok && value != nil {
+				payload, errResult := dispatchClientPayload(value)
+				if errResult != nil {
+					return errResult, nil
+				}
+				opts.ClientPayload = &payload
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			_, resp, err := client.Repositories.Dispatch(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create repository dispatch event: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create repository dispatch event: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"event_type": eventType,
+				"message":    fmt.Sprintf("Dispatched %s event to %s/%s", eventType, owner, repo),
+			}), nil
+		}
+}
+
+// dispatchClientPayload checks a client_payload argument against GitHub's limits and encodes it.
+// The payload may also be given as a string holding a JSON object.
+func dispatchClientPayload(value any) (json.RawMessage, *mcp.CallToolResult) {
+	if s, ok := value.(string); ok {
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return nil, mcp.NewToolResultError(fmt.Sprintf("client_payload is not valid JSON: %s", err))
+		}
+		value = decoded
+	}
+
+	payload, ok := value.(map[string]any)
+	if !ok {
+		return nil, mcp.NewToolResultError("client_payload must be a JSON object")
+	}
+	if len(payload) > maxDispatchPayloadProperties {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("client_payload has %d top-level properties, at most %d are allowed", len(payload), maxDispatchPayloadProperties))
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("failed to encode client_payload: %s", err))
+	}
+	if len(encoded) > maxDispatchPayloadBytes {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("client_payload is %d bytes, at most %d are allowed", len(encoded), maxDispatchPayloadBytes))
+	}
+	return encoded, nil
+}
+
+// GetRepoLanguages creates a tool to get the language breakdown and size of a GitHub repository.
+func GetRepoLanguages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_languages",
+			mcp.WithDescription(t("TOOL_GET_REPO_LANGUAGES_DESCRIPTION", "Get the languages used in a GitHub repository, in bytes of code per language, together with the total size of the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_LANGUAGES_USER_TITLE", "Get repository languages"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			languages, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repository languages: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list repository languages: %s", string(body))), nil
+			}
+
+			// The languages endpoint only reports code, the repository metadata carries the total size
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository: %s", string(body))), nil
+			}
+
+			totalBytes := 0
+			for _, bytes := range languages {
+				totalBytes += bytes
+			}
+
+			result := map[string]any{
+				"languages":   languages,
+				"total_bytes": totalBytes,
+				"size_kb":     repository.GetSize(),
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// contributorStatsRetryDelays are the waits between attempts while GitHub is still computing the
+// contributor statistics of a repository, which it signals with a 202.
+var contributorStatsRetryDelays = []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+
+// ContributorWeek is a week of contribution activity, weeks without any activity are left out.
+type ContributorWeek struct {
+	Week      time.Time `json:"week"`
+	Commits   int       `json:"commits"`
+	Additions int       `json:"additions"`
+	Deletions int       `json:"deletions"`
+}
+
+// ContributorStats is the commit activity of one contributor to a repository.
+type ContributorStats struct {
+	Login     string            `json:"login"`
+	Commits   int               `json:"commits"`
+	Additions int               `json:"additions"`
+	Deletions int               `json:"deletions"`
+	Weeks     []ContributorWeek `json:"weeks"`
+}
+
+func newContributorStats(stats *github.ContributorStats) ContributorStats {
+	result := ContributorStats{
+		Login:   stats.GetAuthor().GetLogin(),
+		Commits: stats.GetTotal(),
+		Weeks:   []ContributorWeek{},
+	}
+	for _, week := range stats.Weeks {
+		result.Additions += week.GetAdditions()
+		result.Deletions += week.GetDeletions()
+		if week.GetCommits() == 0 && week.GetAdditions() == 0 && week.GetDeletions() == 0 {
+			continue
+		}
+		result.Weeks = append(result.Weeks, ContributorWeek{
+			Week:      week.GetWeek().Time,
+			Commits:   week.GetCommits(),
+			Additions: week.GetAdditions(),
+			Deletions: week.GetDeletions(),
+		})
+	}
+	return result
+}
+
+// GetContributorStats creates a tool to get the commit activity of the contributors to a GitHub repository.
+func GetContributorStats(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_contributor_stats",
+			mcp.WithDescription(t("TOOL_GET_CONTRIBUTOR_STATS_DESCRIPTION", "Get commit counts and weekly additions and deletions for each contributor to a GitHub repository, most active contributors first")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CONTRIBUTOR_STATS_USER_TITLE", "Get contributor statistics"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var stats []*github.ContributorStats
+			for attempt := 0; ; attempt++ {
+				var resp *github.Response
+				stats, resp, err = client.Repositories.ListContributorsStats(ctx, owner, repo)
+				if resp != nil {
+					_ = resp.Body.Close()
+				}
+				if !isAcceptedError(err) {
+					break
+				}
+				if attempt == len(contributorStatsRetryDelays) {
+					return mcp.NewToolResultError(fmt.Sprintf("GitHub is still computing the contributor statistics of %s/%s, try again shortly", owner, repo)), nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(contributorStatsRetryDelays[attempt]):
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get contributor stats: %w", err)
+			}
+
+			result := make([]ContributorStats, 0, len(stats))
+			for _, contributor := range stats {
+				result = append(result, newContributorStats(contributor))
+			}
+			sort.SliceStable(result, func(i, j int) bool {
+				return result[i].Commits > result[j].Commits
+			})
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// TrafficDay is the traffic of a repository on one day.
+type TrafficDay struct {
+	Date    time.Time `json:"date"`
+	Count   int       `json:"count"`
+	Uniques int       `json:"uniques"`
+}
+
+// TrafficSummary is the traffic of a repository over the last 14 days.
+type TrafficSummary struct {
+	Count   int          `json:"count"`
+	Uniques int          `json:"uniques"`
+	Daily   []TrafficDay `json:"daily"`
+}
+
+// RepoTraffic holds the traffic metrics requested from get_repo_traffic.
+type RepoTraffic struct {
+	Views  *TrafficSummary `json:"views,omitempty"`
+	Clones *TrafficSummary `json:"clones,omitempty"`
+}
+
+func newTrafficSummary(count, uniques int, days []*github.TrafficData) *TrafficSummary {
+	summary := &TrafficSummary{
+		Count:   count,
+		Uniques: uniques,
+		Daily:   make([]TrafficDay, 0, len(days)),
+	}
+	for _, day := range days {
+		summary.Daily = append(summary.Daily, TrafficDay{
+			Date:    day.GetTimestamp().Time,
+			Count:   day.GetCount(),
+			Uniques: day.GetUniques(),
+		})
+	}
+	return summary
+}
+
+// trafficForbiddenResult explains the 403 GitHub returns to tokens without push access to a repository.
+// It returns nil for any other error.
+func trafficForbiddenResult(err error, owner, repo string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("repository traffic of %s/%s is only available with push access to the repository, the token in use does not have it: %s", owner, repo, ghErr.Message))
+}
+
+// GetRepoTraffic creates a tool to get the views and clones of a GitHub repository over the last 14 days.
+func GetRepoTraffic(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_repo_traffic",
+			mcp.WithDescription(t("TOOL_GET_REPO_TRAFFIC_DESCRIPTION", "Get the daily views and clones of a GitHub repository, with totals and unique visitors, over the last 14 days. Requires push access to the repository")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_REPO_TRAFFIC_USER_TITLE", "Get repository traffic"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("metric",
+				mcp.Description("Only get one metric, views or clones. Both are returned when omitted"),
+				mcp.Enum("views", "clones"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			metric, err := OptionalParam[string](request, "metric")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if metric != "" && metric != "views" && metric != "clones" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid metric %q, expected views or clones", metric)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.TrafficBreakdownOptions{Per: "day"}
+			var traffic RepoTraffic
+
+			if metric == "" || metric == "views" {
+				views, resp, err := client.Repositories.ListTrafficViews(ctx, owner, repo, opts)
+				if result := trafficForbiddenResult(err, owner, repo); result != nil {
+					return result, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository views: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				traffic.Views = newTrafficSummary(views.GetCount(), views.GetUniques(), views.Views)
+			}
+
+			if metric == "" || metric == "clones" {
+				clones, resp, err := client.Repositories.ListTrafficClones(ctx, owner, repo, opts)
+				if result := trafficForbiddenResult(err, owner, repo); result != nil {
+					return result, nil
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to get repository clones: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				traffic.Clones = newTrafficSummary(clones.GetCount(), clones.GetUniques(), clones.Clones)
+			}
+
+			r, err := json.Marshal(traffic)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxInlineRepoArchiveSize is the largest archive download_repo_archive returns the contents of. Larger
+// archives are returned as a download URL instead.
+const maxInlineRepoArchiveSize = 1 << 20
+
+// CloneURLs are the URLs a repository can be cloned from.
+type CloneURLs struct {
+	FullName      string `json:"full_name"`
+	HTTPS         string `json:"https"`
+	SSH           string `json:"ssh"`
+	DefaultBranch string `json:"default_branch"`
+	Private       bool   `json:"private"`
+}
+
+// GetCloneURLs creates a tool to get the URLs a repository can be cloned from.
+func GetCloneURLs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_clone_urls",
+			mcp.WithDescription(t("TOOL_GET_CLONE_URLS_DESCRIPTION", "Get the HTTPS and SSH URLs a repository can be cloned from, along with its default branch")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_CLONE_URLS_USER_TITLE", "Get clone URLs"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get repository: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(CloneURLs{
+				FullName:      repository.GetFullName(),
+				HTTPS:         repository.GetCloneURL(),
+				SSH:           repository.GetSSHURL(),
+				DefaultBranch: repository.GetDefaultBranch(),
+				Private:       repository.GetPrivate(),
+			}), nil
+		}
+}
+
+// DownloadRepoArchive creates a tool to download the source of a repository at a ref as an archive,
+// returning small archives inline.
+func DownloadRepoArchive(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("download_repo_archive",
+			mcp.WithDescription(t("TOOL_DOWNLOAD_REPO_ARCHIVE_DESCRIPTION", fmt.Sprintf("Download the source of a repository at a branch, tag or commit as a tarball or zip archive, which is quicker than reading it file by file. Archives up to %d bytes are returned base64 encoded, larger ones as a short-lived download URL", maxInlineRepoArchiveSize))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DOWNLOAD_REPO_ARCHIVE_USER_TITLE", "Download repository archive"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA, defaults to the repository's default branch"),
+			),
+			mcp.WithString("format",
+				mcp.Description("Archive format, a gzipped tarball or a zip archive, defaults to tarball"),
+				mcp.Enum("tarball", "zipball"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			format, err := OptionalParam[string](request, "format")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			archiveFormat := github.Tarball
+			switch format {
+			case "", "tarball":
+			case "zipball":
+				archiveFormat = github.Zipball
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid format %q, expected tarball or zipball", format)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// GitHub answers with a redirect to codeload, signed for a few minutes for private repositories
+			downloadURL, resp, err := client.Repositories.GetArchiveLink(ctx, owner, repo, archiveFormat, &github.RepositoryContentGetOptions{Ref: ref}, 1)
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return mcp.NewToolResultError(fmt.Sprintf("%s/%s or its ref %q not found", owner, repo, ref)), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get archive download URL: %w", err)
+			}
+
+			// The URL carries its own credentials and is not a GitHub API URL, so it is fetched without the token
+			content, err := downloadCapped(ctx, downloadURL.String(), maxInlineRepoArchiveSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to download archive: %w", err)
+			}
+			if content == nil {
+				return MarshalledTextResult(map[string]any{
+					"format":       string(archiveFormat),
+					"download_url": downloadURL.String(),
+					"message":      fmt.Sprintf("The archive is larger than %d bytes. Download it from download_url, which expires after a few minutes for private repositories", maxInlineRepoArchiveSize),
+				}), nil
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"format":         string(archiveFormat),
+				"size_in_bytes":  len(content),
+				"content_base64": base64.StdEncoding.EncodeToString(content),
+			}), nil
+		}
+}
@@ -0,0 +1,224 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetCloneURLs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetCloneURLs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_clone_urls", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+		expected       CloneURLs
+	}{
+		{
+			name: "clone URLs",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{
+						FullName:      github.Ptr("owner/repo"),
+						CloneURL:      github.Ptr("https://github.com/owner/repo.git"),
+						SSHURL:        github.Ptr("git@github.com:owner/repo.git"),
+						DefaultBranch: github.Ptr("main"),
+						Private:       github.Ptr(true),
+					},
+				),
+			),
+			expected: CloneURLs{
+				FullName:      "owner/repo",
+				HTTPS:         "https://github.com/owner/repo.git",
+				SSH:           "git@github.com:owner/repo.git",
+				DefaultBranch: "main",
+				Private:       true,
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetCloneURLs(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			var urls CloneURLs
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &urls))
+			assert.Equal(t, tc.expected, urls)
+		})
+	}
+}
+
+func Test_DownloadRepoArchive(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DownloadRepoArchive(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "download_repo_archive", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	archive := []byte("\x1f\x8b tarball")
+	largeArchive := bytes.Repeat([]byte("a"), maxInlineRepoArchiveSize+1)
+	codeload := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The signed URL must not be sent the GitHub token
+		assert.Empty(t, r.Header.Get("Authorization"))
+		if r.URL.Path == "/large" {
+			// Codeload streams archives without a length
+			w.Header().Set("Transfer-Encoding", "chunked")
+			_, _ = w.Write(largeArchive)
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer codeload.Close()
+
+	redirectTo := func(path string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", codeload.URL+path+"?token=signed")
+			w.WriteHeader(http.StatusFound)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		format          string
+		expectToolError bool
+		expectedErrMsg  string
+		expectedFormat  string
+		expectedContent []byte
+		expectedURL     string
+	}{
+		{
+			name: "small tarball is returned inline",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTarballByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/tarball/v1.0.0").andThen(redirectTo("/small")),
+				),
+			),
+			expectedFormat:  "tarball",
+			expectedContent: archive,
+		},
+		{
+			name: "zipball",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposZipballByOwnerByRepoByRef,
+					redirectTo("/small"),
+				),
+			),
+			format:          "zipball",
+			expectedFormat:  "zipball",
+			expectedContent: archive,
+		},
+		{
+			name: "large archive is returned as a URL",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTarballByOwnerByRepoByRef,
+					redirectTo("/large"),
+				),
+			),
+			expectedFormat: "tarball",
+			expectedURL:    codeload.URL + "/large?token=signed",
+		},
+		{
+			name: "ref not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposTarballByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  `owner/repo or its ref "v1.0.0" not found`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := DownloadRepoArchive(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "v1.0.0",
+			}
+			if tc.format != "" {
+				args["format"] = tc.format
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response struct {
+				Format        string `json:"format"`
+				ContentBase64 string `json:"content_base64"`
+				DownloadURL   string `json:"download_url"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedFormat, response.Format)
+			assert.Equal(t, tc.expectedURL, response.DownloadURL)
+			if tc.expectedContent != nil {
+				content, err := base64.StdEncoding.DecodeString(response.ContentBase64)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedContent, content)
+			} else {
+				assert.Empty(t, response.ContentBase64)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// PartialResultMethod is the method of the notifications carrying each page a tool reading a whole list
+// fetches, sent ahead of the tool result when result streaming is on
+const PartialResultMethod = "notifications/github/partial_result"
+
+type resultStreamContextKey struct{}
+
+// resultStream identifies the tool call the pages sent as partial results belong to
+type resultStream struct {
+	tool          string
+	progressToken mcp.ProgressToken
+}
+
+// ResultStreamingMiddleware returns a tool handler middleware making FetchAllPages send every page it
+// fetches to the client as a partial result notification, so that agents can start on a long list before
+// the tool returns. The tool result still holds the whole list.
+func ResultStreamingMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		stream := &resultStream{tool: request.Params.Name}
+		if request.Params.Meta != nil {
+			stream.progressToken = request.Params.Meta.ProgressToken
+		}
+		return next(context.WithValue(ctx, resultStreamContextKey{}, stream), request)
+	}
+}
+
+// streamPage sends a page fetched by FetchAllPages as a partial result, when result streaming is on and the
+// session of the call can be notified. Pages fetched concurrently may arrive out of order, page tells them
+// apart. Notifications that cannot be sent are dropped, the tool result has every item anyway.
+func streamPage[T any](ctx context.Context, page int, items []T) {
+	stream, ok := ctx.Value(resultStreamContextKey{}).(*resultStream)
+	if !ok {
+		return
+	}
+	mcpServer := server.ServerFromContext(ctx)
+	if mcpServer == nil {
+		return
+	}
+
+	params := map[string]any{
+		"tool":  stream.tool,
+		"page":  page,
+		"items": items,
+	}
+	if stream.progressToken != nil {
+		params["progressToken"] = stream.progressToken
+	}
+	_ = mcpServer.SendNotificationToClient(ctx, PartialResultMethod, params)
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notifiedSession is a client session collecting the notifications sent to it
+type notifiedSession struct {
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *notifiedSession) Initialize()       {}
+func (s *notifiedSession) Initialized() bool { return true }
+func (s *notifiedSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+func (s *notifiedSession) SessionID() string { return "session" }
+
+func Test_ResultStreamingMiddleware(t *testing.T) {
+	// list_numbers reads a list of two pages
+	listNumbers := func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		numbers, err := FetchAllPages(ctx, func(_ context.Context, page int) ([]int, *github.Response, error) {
+			resp := &github.Response{}
+			if page == 1 {
+				resp.NextPage = 2
+			}
+			return []int{2*page - 1, 2 * page}, resp, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return MarshalledTextResult(numbers), nil
+	}
+
+	call := func(opts ...server.ServerOption) ([]mcp.JSONRPCNotification, mcp.JSONRPCMessage) {
+		mcpServer := server.NewMCPServer("test", "0.0.1", opts...)
+		mcpServer.AddTool(mcp.NewTool("list_numbers"), listNumbers)
+
+		session := &notifiedSession{notifications: make(chan mcp.JSONRPCNotification, 10)}
+		ctx := mcpServer.WithContext(context.Background(), session)
+		response := mcpServer.HandleMessage(ctx, json.RawMessage(`{
+			"jsonrpc": "2.0",
+			"id": 1,
+			"method": "tools/call",
+			"params": {"name": "list_numbers", "_meta": {"progressToken": "token-1"}}
+		}`))
+		close(session.notifications)
+
+		var notifications []mcp.JSONRPCNotification
+		for notification := range session.notifications {
+			notifications = append(notifications, notification)
+		}
+		return notifications, response
+	}
+
+	t.Run("pages are streamed ahead of the result", func(t *testing.T) {
+		notifications, response := call(server.WithToolHandlerMiddleware(ResultStreamingMiddleware))
+
+		require.Len(t, notifications, 2)
+		for i, notification := range notifications {
+			assert.Equal(t, PartialResultMethod, notification.Method)
+			assert.Equal(t, map[string]any{
+				"tool":          "list_numbers",
+				"page":          i + 1,
+				"items":         []int{2*i + 1, 2*i + 2},
+				"progressToken": "token-1",
+			}, notification.Params.AdditionalFields)
+		}
+
+		// The result still has the whole list
+		raw, err := json.Marshal(response)
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), `"text":"[1,2,3,4]"`)
+	})
+
+	t.Run("nothing is streamed without the middleware", func(t *testing.T) {
+		notifications, _ := call()
+		assert.Empty(t, notifications)
+	})
+}
@@ -0,0 +1,214 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OutputFormat controls how JSON tool results are serialized before they are returned to the client.
+type OutputFormat string
+
+const (
+	// OutputFormatCompact returns JSON results without any insignificant whitespace.
+	OutputFormatCompact OutputFormat = "compact"
+	// OutputFormatPretty returns JSON results indented for human readability.
+	OutputFormatPretty OutputFormat = "pretty"
+)
+
+// ParseOutputFormat parses an output format name, defaulting to compact when empty.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(strings.ToLower(strings.TrimSpace(s))) {
+	case "", OutputFormatCompact:
+		return OutputFormatCompact, nil
+	case OutputFormatPretty:
+		return OutputFormatPretty, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, expected %q or %q", s, OutputFormatCompact, OutputFormatPretty)
+	}
+}
+
+type outputFormatContextKey struct{}
+
+// ContextWithOutputFormat returns a context carrying the output format requested for a single call,
+// overriding the server default.
+func ContextWithOutputFormat(ctx context.Context, format OutputFormat) context.Context {
+	return context.WithValue(ctx, outputFormatContextKey{}, format)
+}
+
+// OutputFormatFromContext returns the output format requested for the current call, if any.
+func OutputFormatFromContext(ctx context.Context) (OutputFormat, bool) {
+	format, ok := ctx.Value(outputFormatContextKey{}).(OutputFormat)
+	return format, ok
+}
+
+// OutputFormatMiddleware returns a tool handler middleware that serializes JSON text results in the
+// output format requested on the context, falling back to defaultFormat.
+// Text content that is not valid JSON is left untouched.
+func OutputFormatMiddleware(defaultFormat OutputFormat) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil {
+				return result, err
+			}
+
+			format := defaultFormat
+			if f, ok := OutputFormatFromContext(ctx); ok {
+				format = f
+			}
+
+			// Results are marshalled compactly by the tools, so there is only work to do when pretty printing.
+			if format != OutputFormatPretty {
+				return result, nil
+			}
+
+			for i, content := range result.Content {
+				textContent, ok := content.(mcp.TextContent)
+				if !ok {
+					continue
+				}
+				textContent.Text = indentJSONText(textContent.Text)
+				result.Content[i] = textContent
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// indentJSONText indents s if it holds a JSON document, otherwise it is returned unchanged.
+func indentJSONText(s string) string {
+	if !json.Valid([]byte(s)) {
+		return s
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(s), "", "  "); err != nil {
+		return s
+	}
+	return buf.String()
+}
+
+// WithFields adds the fields parameter, which FieldsMiddleware uses to trim the result of a tool.
+func WithFields() mcp.ToolOption {
+	return mcp.WithArray("fields",
+		mcp.Description("Only return these top-level fields of the result, or of each item for lists. Unknown fields are ignored"),
+		mcp.Items(map[string]any{
+			"type": "string",
+		}),
+	)
+}
+
+// FieldsMiddleware filters JSON text results down to the fields requested with the fields parameter.
+// Tool errors and text content that is not a JSON object or array are left untouched.
+func FieldsMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		fields, err := OptionalStringArrayParam(request, "fields")
+		if err != nil || len(fields) == 0 {
+			return result, nil
+		}
+
+		for i, content := range result.Content {
+			textContent, ok := content.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			textContent.Text = filterJSONFields(textContent.Text, fields)
+			result.Content[i] = textContent
+		}
+
+		return result, nil
+	}
+}
+
+// filterJSONFields keeps only fields in s if it holds a JSON object, in every object of s if it holds
+// an array, or in every item of a ListResult. Anything else is returned unchanged.
+func filterJSONFields(s string, fields []string) string {
+	var value any
+	switch trimmed := strings.TrimSpace(s); {
+	case strings.HasPrefix(trimmed, "{"):
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(s), &object); err != nil {
+			return s
+		}
+		if isListResult(object) {
+			object["items"] = filterJSONArrayFields(object["items"], fields)
+			value = object
+		} else {
+			value = filterJSONObjectFields(object, fields)
+		}
+	case strings.HasPrefix(trimmed, "["):
+		value = filterJSONArrayFields(json.RawMessage(s), fields)
+	default:
+		return s
+	}
+
+	r, err := json.Marshal(value)
+	if err != nil {
+		return s
+	}
+	return string(r)
+}
+
+// isListResult reports whether object has the shape of a marshalled ListResult
+func isListResult(object map[string]json.RawMessage) bool {
+	if _, ok := object["items"]; !ok {
+		return false
+	}
+	for key := range object {
+		if key != "items" && key != "pagination" {
+			return false
+		}
+	}
+	return true
+}
+
+// filterJSONArrayFields filters every object in a JSON array, other elements are kept as they are
+func filterJSONArrayFields(raw json.RawMessage, fields []string) json.RawMessage {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(raw, &elements); err != nil {
+		return raw
+	}
+	for i, element := range elements {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(element, &object); err != nil || object == nil {
+			continue
+		}
+		filtered, err := json.Marshal(filterJSONObjectFields(object, fields))
+		if err != nil {
+			continue
+		}
+		elements[i] = filtered
+	}
+	r, err := json.Marshal(elements)
+	if err != nil {
+		return raw
+	}
+	return r
+}
+
+// filterJSONObjectFields keeps the requested fields of object. When none of them exist the object is
+// returned whole, so a mistyped field name does not leave the caller with an empty result.
+func filterJSONObjectFields(object map[string]json.RawMessage, fields []string) map[string]json.RawMessage {
+	filtered := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := object[field]; ok {
+			filtered[field] = value
+		}
+	}
+	if len(filtered) == 0 {
+		return object
+	}
+	return filtered
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    OutputFormat
+		expectError bool
+	}{
+		{name: "empty defaults to compact", input: "", expected: OutputFormatCompact},
+		{name: "compact", input: "compact", expected: OutputFormatCompact},
+		{name: "pretty is case insensitive", input: " Pretty ", expected: OutputFormatPretty},
+		{name: "unknown format", input: "yaml", expectError: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := ParseOutputFormat(tc.input)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, format)
+		})
+	}
+}
+
+func Test_OutputFormatMiddleware(t *testing.T) {
+	handler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"name":"repo","topics":["a","b"]}`), nil
+	}
+	plainHandler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("Fork is in progress"), nil
+	}
+
+	pretty := "{\n  \"name\": \"repo\",\n  \"topics\": [\n    \"a\",\n    \"b\"\n  ]\n}"
+	compact := `{"name":"repo","topics":["a","b"]}`
+
+	tests := []struct {
+		name          string
+		defaultFormat OutputFormat
+		ctx           context.Context
+		handler       func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		expectedText  string
+	}{
+		{
+			name:          "compact default leaves result untouched",
+			defaultFormat: OutputFormatCompact,
+			ctx:           context.Background(),
+			handler:       handler,
+			expectedText:  compact,
+		},
+		{
+			name:          "pretty default indents result",
+			defaultFormat: OutputFormatPretty,
+			ctx:           context.Background(),
+			handler:       handler,
+			expectedText:  pretty,
+		},
+		{
+			name:          "context overrides default",
+			defaultFormat: OutputFormatCompact,
+			ctx:           ContextWithOutputFormat(context.Background(), OutputFormatPretty),
+			handler:       handler,
+			expectedText:  pretty,
+		},
+		{
+			name:          "non JSON text is left untouched",
+			defaultFormat: OutputFormatPretty,
+			ctx:           context.Background(),
+			handler:       plainHandler,
+			expectedText:  "Fork is in progress",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapped := OutputFormatMiddleware(tc.defaultFormat)(tc.handler)
+
+			result, err := wrapped(tc.ctx, createMCPRequest(map[string]any{}))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_FieldsMiddleware(t *testing.T) {
+	textHandler := func(text string) server.ToolHandlerFunc {
+		return func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(text), nil
+		}
+	}
+
+	tests := []struct {
+		name         string
+		handler      server.ToolHandlerFunc
+		requestArgs  map[string]any
+		expectedText string
+	}{
+		{
+			name:         "no fields leaves result untouched",
+			handler:      textHandler(`{"name":"repo","size":1}`),
+			requestArgs:  map[string]any{},
+			expectedText: `{"name":"repo","size":1}`,
+		},
+		{
+			name:         "object is filtered",
+			handler:      textHandler(`{"name":"repo","size":1,"owner":{"login":"octocat"}}`),
+			requestArgs:  map[string]any{"fields": []any{"name", "owner"}},
+			expectedText: `{"name":"repo","owner":{"login":"octocat"}}`,
+		},
+		{
+			name:         "unknown fields are ignored",
+			handler:      textHandler(`{"name":"repo","size":1}`),
+			requestArgs:  map[string]any{"fields": []any{"name", "nope"}},
+			expectedText: `{"name":"repo"}`,
+		},
+		{
+			name:         "only unknown fields leaves object whole",
+			handler:      textHandler(`{"name":"repo","size":1}`),
+			requestArgs:  map[string]any{"fields": []any{"nope"}},
+			expectedText: `{"name":"repo","size":1}`,
+		},
+		{
+			name:         "array elements are filtered",
+			handler:      textHandler(`[{"number":1,"title":"a","body":"x"},{"number":2,"title":"b","body":"y"}]`),
+			requestArgs:  map[string]any{"fields": []any{"number", "title"}},
+			expectedText: `[{"number":1,"title":"a"},{"number":2,"title":"b"}]`,
+		},
+		{
+			name:         "list result items are filtered and pagination kept",
+			handler:      textHandler(`{"items":[{"number":1,"body":"x"}],"pagination":{"next_page":2}}`),
+			requestArgs:  map[string]any{"fields": []any{"number"}},
+			expectedText: `{"items":[{"number":1}],"pagination":{"next_page":2}}`,
+		},
+		{
+			name:         "non JSON text is left untouched",
+			handler:      textHandler("Fork is in progress"),
+			requestArgs:  map[string]any{"fields": []any{"name"}},
+			expectedText: "Fork is in progress",
+		},
+		{
+			name: "tool errors are left untouched",
+			handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultError(`{"error":"not_mergeable","message":"conflict"}`), nil
+			},
+			requestArgs:  map[string]any{"fields": []any{"message"}},
+			expectedText: `{"error":"not_mergeable","message":"conflict"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapped := FieldsMiddleware(tc.handler)
+
+			result, err := wrapped(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
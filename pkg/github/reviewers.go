@@ -0,0 +1,288 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RequestedReviewers are the users and teams whose review of a pull request is pending.
+type RequestedReviewers struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+}
+
+// RejectedReviewer is a reviewer that was left out of a review request, and why.
+type RejectedReviewer struct {
+	Reviewer string `json:"reviewer"`
+	// Type is user or team
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// ReviewersResult is the result of request_reviewers and remove_requested_reviewers.
+type ReviewersResult struct {
+	RequestedReviewers RequestedReviewers `json:"requested_reviewers"`
+	Rejected           []RejectedReviewer `json:"rejected,omitempty"`
+}
+
+func newRequestedReviewers(users []*github.User, teams []*github.Team) RequestedReviewers {
+	requested := RequestedReviewers{
+		Users: make([]string, 0, len(users)),
+		Teams: make([]string, 0, len(teams)),
+	}
+	for _, user := range users {
+		requested.Users = append(requested.Users, user.GetLogin())
+	}
+	for _, team := range teams {
+		requested.Teams = append(requested.Teams, team.GetSlug())
+	}
+	return requested
+}
+
+// withReviewerParams adds the reviewers and team_reviewers parameters
+func withReviewerParams(action string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray("reviewers",
+			mcp.Description(fmt.Sprintf("Logins of the users to %s", action)),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		)(tool)
+		mcp.WithArray("team_reviewers",
+			mcp.Description(fmt.Sprintf("Slugs of the teams to %s, for repositories owned by an organization", action)),
+			mcp.Items(map[string]any{
+				"type": "string",
+			}),
+		)(tool)
+	}
+}
+
+// reviewerParams returns the reviewers and team_reviewers parameters, at least one of which must be given
+func reviewerParams(request mcp.CallToolRequest) ([]string, []string, error) {
+	users, err := OptionalStringArrayParam(request, "reviewers")
+	if err != nil {
+		return nil, nil, err
+	}
+	teams, err := OptionalStringArrayParam(request, "team_reviewers")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(users) == 0 && len(teams) == 0 {
+		return nil, nil, errors.New("at least one of reviewers or team_reviewers is required")
+	}
+	return users, teams, nil
+}
+
+// eligibleReviewers splits the reviewers into those GitHub accepts a review request for and those it rejects,
+// since a single ineligible reviewer fails the whole request. Users must be collaborators other than the author
+// of the pull request, teams must have access to the repository.
+func eligibleReviewers(ctx context.Context, client *github.Client, owner, repo, author string, users, teams []string) ([]string, []string, []RejectedReviewer, error) {
+	var eligibleUsers, eligibleTeams []string
+	var rejected []RejectedReviewer
+
+	for _, user := range users {
+		if strings.EqualFold(user, author) {
+			rejected = append(rejected, RejectedReviewer{Reviewer: user, Type: "user", Reason: "is the author of the pull request"})
+			continue
+		}
+		isCollaborator, resp, err := client.Repositories.IsCollaborator(ctx, owner, repo, user)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to check whether %s is a collaborator: %w", user, err)
+		}
+		if !isCollaborator {
+			rejected = append(rejected, RejectedReviewer{Reviewer: user, Type: "user", Reason: "is not a collaborator on the repository"})
+			continue
+		}
+		eligibleUsers = append(eligibleUsers, user)
+	}
+
+	for _, team := range teams {
+		_, resp, err := client.Teams.IsTeamRepoBySlug(ctx, owner, team, owner, repo)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		var ghErr *github.ErrorResponse
+		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
+			rejected = append(rejected, RejectedReviewer{Reviewer: team, Type: "team", Reason: "does not exist or has no access to the repository"})
+			continue
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to check whether team %s has access to the repository: %w", team, err)
+		}
+		eligibleTeams = append(eligibleTeams, team)
+	}
+
+	return eligibleUsers, eligibleTeams, rejected, nil
+}
+
+// RequestReviewers creates a tool to request reviews of a pull request from users and teams.
+func RequestReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("request_reviewers",
+			mcp.WithDescription(t("TOOL_REQUEST_REVIEWERS_DESCRIPTION", "Request reviews of a pull request from users and teams. Reviewers GitHub would not accept, such as the author or users who are not collaborators, are left out and returned as rejected, the others are requested. Returns the pending review requests")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REQUEST_REVIEWERS_USER_TITLE", "Request pull request reviewers"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			withReviewerParams("request a review from"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, teams, err := reviewerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			users, teams, rejected, err := eligibleReviewers(ctx, client, owner, repo, pr.GetUser().GetLogin(), users, teams)
+			if err != nil {
+				return nil, err
+			}
+			if len(users) == 0 && len(teams) == 0 {
+				return MarshalledTextResult(ReviewersResult{
+					RequestedReviewers: newRequestedReviewers(pr.RequestedReviewers, pr.RequestedTeams),
+					Rejected:           rejected,
+				}), nil
+			}
+
+			pr, resp, err = client.PullRequests.RequestReviewers(ctx, owner, repo, pullNumber, github.ReviewersRequest{
+				Reviewers:     users,
+				TeamReviewers: teams,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to request reviewers: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to request reviewers: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(ReviewersResult{
+				RequestedReviewers: newRequestedReviewers(pr.RequestedReviewers, pr.RequestedTeams),
+				Rejected:           rejected,
+			}), nil
+		}
+}
+
+// RemoveRequestedReviewers creates a tool to withdraw review requests of a pull request.
+func RemoveRequestedReviewers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_requested_reviewers",
+			mcp.WithDescription(t("TOOL_REMOVE_REQUESTED_REVIEWERS_DESCRIPTION", "Withdraw the review requests of a pull request from users and teams, and return the review requests still pending. Reviews already submitted are kept")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_REQUESTED_REVIEWERS_USER_TITLE", "Remove pull request reviewers"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			withReviewerParams("withdraw the review request of"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			users, teams, err := reviewerParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.PullRequests.RemoveReviewers(ctx, owner, repo, pullNumber, github.ReviewersRequest{
+				Reviewers:     users,
+				TeamReviewers: teams,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove requested reviewers: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove requested reviewers: %s", string(body))), nil
+			}
+
+			reviewers, listResp, err := client.PullRequests.ListReviewers(ctx, owner, repo, pullNumber, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list requested reviewers: %w", err)
+			}
+			defer func() { _ = listResp.Body.Close() }()
+
+			return MarshalledTextResult(ReviewersResult{
+				RequestedReviewers: newRequestedReviewers(reviewers.Users, reviewers.Teams),
+			}), nil
+		}
+}
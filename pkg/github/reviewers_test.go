@@ -0,0 +1,207 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequestReviewers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RequestReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "request_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "reviewers")
+	assert.Contains(t, tool.InputSchema.Properties, "team_reviewers")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pullRequest := mock.WithRequestMatchHandler(
+		mock.GetReposPullsByOwnerByRepoByPullNumber,
+		mockResponse(t, http.StatusOK, &github.PullRequest{
+			Number:             github.Ptr(42),
+			User:               &github.User{Login: github.Ptr("author")},
+			RequestedReviewers: []*github.User{{Login: github.Ptr("existing")}},
+		}),
+	)
+	// Only octocat is a collaborator
+	collaborators := mock.WithRequestMatchHandler(
+		mock.GetReposCollaboratorsByOwnerByRepoByUsername,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/octocat") {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}),
+	)
+	// Only the reviewers team has access to the repository
+	teams := mock.WithRequestMatchHandler(
+		mock.GetOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, "/orgs/owner/teams/reviewers/") {
+				mockResponse(t, http.StatusOK, &github.Repository{Name: github.Ptr("repo")})(w, r)
+				return
+			}
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+		}),
+	)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+		expectedResult  ReviewersResult
+	}{
+		{
+			name: "ineligible reviewers are rejected, the others requested",
+			mockedClient: mock.NewMockedHTTPClient(
+				pullRequest,
+				collaborators,
+				teams,
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+					expectRequestBody(t, map[string]any{
+						"reviewers":      []any{"octocat"},
+						"team_reviewers": []any{"reviewers"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.PullRequest{
+							Number:             github.Ptr(42),
+							RequestedReviewers: []*github.User{{Login: github.Ptr("existing")}, {Login: github.Ptr("octocat")}},
+							RequestedTeams:     []*github.Team{{Slug: github.Ptr("reviewers")}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"reviewers":      []any{"octocat", "Author", "stranger"},
+				"team_reviewers": []any{"reviewers", "outsiders"},
+			},
+			expectedResult: ReviewersResult{
+				RequestedReviewers: RequestedReviewers{
+					Users: []string{"existing", "octocat"},
+					Teams: []string{"reviewers"},
+				},
+				Rejected: []RejectedReviewer{
+					{Reviewer: "Author", Type: "user", Reason: "is the author of the pull request"},
+					{Reviewer: "stranger", Type: "user", Reason: "is not a collaborator on the repository"},
+					{Reviewer: "outsiders", Type: "team", Reason: "does not exist or has no access to the repository"},
+				},
+			},
+		},
+		{
+			name: "nothing is requested when every reviewer is rejected",
+			mockedClient: mock.NewMockedHTTPClient(
+				pullRequest,
+				collaborators,
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"reviewers":  []any{"stranger"},
+			},
+			expectedResult: ReviewersResult{
+				RequestedReviewers: RequestedReviewers{
+					Users: []string{"existing"},
+					Teams: []string{},
+				},
+				Rejected: []RejectedReviewer{
+					{Reviewer: "stranger", Type: "user", Reason: "is not a collaborator on the repository"},
+				},
+			},
+		},
+		{
+			name:         "no reviewers",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "at least one of reviewers or team_reviewers is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := RequestReviewers(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned ReviewersResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
+
+func Test_RemoveRequestedReviewers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveRequestedReviewers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_requested_reviewers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+			expectRequestBody(t, map[string]any{
+				"reviewers": []any{"octocat"},
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.PullRequest{Number: github.Ptr(42)}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposPullsRequestedReviewersByOwnerByRepoByPullNumber,
+			mockResponse(t, http.StatusOK, &github.Reviewers{
+				Users: []*github.User{{Login: github.Ptr("hubot")}},
+				Teams: []*github.Team{{Slug: github.Ptr("reviewers")}},
+			}),
+		),
+	))
+	_, handler := RemoveRequestedReviewers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+		"reviewers":  []any{"octocat"},
+	}))
+	require.NoError(t, err)
+
+	var returned ReviewersResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, ReviewersResult{
+		RequestedReviewers: RequestedReviewers{
+			Users: []string{"hubot"},
+			Teams: []string{"reviewers"},
+		},
+	}, returned)
+}
@@ -0,0 +1,398 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// rulesetEnforcements are the enforcement levels of a ruleset
+var rulesetEnforcements = []string{"active", "evaluate", "disabled"}
+
+// rulesetTargets are the kinds of refs or pushes a ruleset applies to
+var rulesetTargets = []string{"branch", "tag", "push"}
+
+// rulesetRuleTypes are the rule types the client library knows how to send. Rules of any other type
+// would be silently dropped, so they are rejected instead.
+var rulesetRuleTypes = []string{
+	"creation", "update", "deletion", "required_linear_history", "merge_queue", "required_deployments",
+	"required_signatures", "pull_request", "required_status_checks", "non_fast_forward",
+	"commit_message_pattern", "commit_author_email_pattern", "committer_email_pattern",
+	"branch_name_pattern", "tag_name_pattern", "file_path_restriction", "max_file_path_length",
+	"file_extension_restriction", "max_file_size", "workflows", "code_scanning",
+}
+
+// MinimalRuleset describes a ruleset without its conditions and rules.
+type MinimalRuleset struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Target      string     `json:"target,omitempty"`
+	Enforcement string     `json:"enforcement"`
+	SourceType  string     `json:"source_type,omitempty"`
+	Source      string     `json:"source,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+func newMinimalRuleset(ruleset *github.RepositoryRuleset) MinimalRuleset {
+	minimal := MinimalRuleset{
+		ID:          ruleset.GetID(),
+		Name:        ruleset.Name,
+		Enforcement: string(ruleset.Enforcement),
+		Source:      ruleset.Source,
+	}
+	if ruleset.Target != nil {
+		minimal.Target = string(*ruleset.Target)
+	}
+	if ruleset.SourceType != nil {
+		minimal.SourceType = string(*ruleset.SourceType)
+	}
+	if ruleset.UpdatedAt != nil {
+		minimal.UpdatedAt = &ruleset.UpdatedAt.Time
+	}
+	return minimal
+}
+
+// parseRulesetSpec checks the fields of a ruleset argument that GitHub requires and decodes it. The
+// ruleset may also be given as a string holding a JSON object. Read-only fields, such as those returned
+// by get_ruleset, are dropped so that a ruleset can be read, edited and sent back.
+func parseRulesetSpec(value any) (github.RepositoryRuleset, error) {
+	var ruleset github.RepositoryRuleset
+	if s, ok := value.(string); ok {
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return ruleset, fmt.Errorf("ruleset is not valid JSON: %w", err)
+		}
+		value = decoded
+	}
+
+	spec, ok := value.(map[string]any)
+	if !ok {
+		return ruleset, fmt.Errorf("ruleset must be a JSON object")
+	}
+	if name, _ := spec["name"].(string); name == "" {
+		return ruleset, fmt.Errorf("ruleset.name is required")
+	}
+	enforcement, _ := spec["enforcement"].(string)
+	if !slices.Contains(rulesetEnforcements, enforcement) {
+		return ruleset, fmt.Errorf("ruleset.enforcement is required and must be one of %v", rulesetEnforcements)
+	}
+	if target, ok := spec["target"]; ok {
+		if s, _ := target.(string); !slices.Contains(rulesetTargets, s) {
+			return ruleset, fmt.Errorf("ruleset.target must be one of %v", rulesetTargets)
+		}
+	}
+	if rules, ok := spec["rules"]; ok {
+		list, ok := rules.([]any)
+		if !ok {
+			return ruleset, fmt.Errorf("ruleset.rules must be an array of rules")
+		}
+		for i, rule := range list {
+			ruleObj, ok := rule.(map[string]any)
+			if !ok {
+				return ruleset, fmt.Errorf("ruleset.rules[%d] must be an object with a type", i)
+			}
+			ruleType, _ := ruleObj["type"].(string)
+			if !slices.Contains(rulesetRuleTypes, ruleType) {
+				return ruleset, fmt.Errorf("ruleset.rules[%d].type %q is not a known rule type", i, ruleType)
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return ruleset, fmt.Errorf("failed to encode ruleset: %w", err)
+	}
+	if err := json.Unmarshal(encoded, &ruleset); err != nil {
+		return ruleset, fmt.Errorf("invalid ruleset: %w", err)
+	}
+
+	ruleset.ID = nil
+	ruleset.NodeID = nil
+	ruleset.SourceType = nil
+	ruleset.Source = ""
+	ruleset.CurrentUserCanBypass = nil
+	ruleset.Links = nil
+	ruleset.CreatedAt = nil
+	ruleset.UpdatedAt = nil
+	return ruleset, nil
+}
+
+// rulesetSpecDescription documents the ruleset argument of create_ruleset and update_ruleset
+const rulesetSpecDescription = "Ruleset as in the GitHub REST API: name and enforcement (active, evaluate or disabled) are required, " +
+	"target (branch, tag or push), bypass_actors, conditions (e.g. {\"ref_name\": {\"include\": [\"~DEFAULT_BRANCH\"], \"exclude\": []}}) " +
+	"and rules (e.g. [{\"type\": \"deletion\"}, {\"type\": \"pull_request\", \"parameters\": {...}}]) are optional"
+
+// ListRulesets creates a tool to list the rulesets of a repository.
+func ListRulesets(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_rulesets",
+			mcp.WithDescription(t("TOOL_LIST_RULESETS_DESCRIPTION", "List the rulesets of a GitHub repository, without their rules. Use get_ruleset for the conditions and rules of a ruleset")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_RULESETS_USER_TITLE", "List repository rulesets"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithBoolean("includes_parents",
+				mcp.Description("Include the rulesets of the organization or enterprise that apply to the repository, defaults to true"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includesParents, hasIncludesParents, err := OptionalParamOK[bool](request, "includes_parents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.RepositoryListRulesetsOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+			if hasIncludesParents {
+				opts.IncludesParents = github.Ptr(includesParents)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list rulesets: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list rulesets: %s", string(body))), nil
+			}
+
+			minimalRulesets := make([]MinimalRuleset, 0, len(rulesets))
+			for _, ruleset := range rulesets {
+				minimalRulesets = append(minimalRulesets, newMinimalRuleset(ruleset))
+			}
+
+			return MarshalledListResult(minimalRulesets, resp, nil), nil
+		}
+}
+
+// GetRuleset creates a tool to get a ruleset of a repository with its conditions and rules.
+func GetRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_ruleset",
+			mcp.WithDescription(t("TOOL_GET_RULESET_DESCRIPTION", "Get a ruleset of a GitHub repository with its bypass actors, conditions and rules")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_RULESET_USER_TITLE", "Get repository ruleset"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("Ruleset ID, as returned by list_rulesets"),
+			),
+			mcp.WithBoolean("includes_parents",
+				mcp.Description("Also find rulesets of the organization or enterprise that apply to the repository, defaults to true"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			includesParents, hasIncludesParents, err := OptionalParamOK[bool](request, "includes_parents")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !hasIncludesParents {
+				includesParents = true
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			ruleset, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, int64(rulesetID), includesParents)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get ruleset: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(ruleset), nil
+		}
+}
+
+// CreateRuleset creates a tool to create a ruleset in a repository.
+func CreateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_ruleset",
+			mcp.WithDescription(t("TOOL_CREATE_RULESET_DESCRIPTION", "Create a ruleset in a GitHub repository. Returns the ID of the new ruleset. Use evaluate enforcement to try a ruleset without blocking anyone")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_RULESET_USER_TITLE", "Create repository ruleset"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithObject("ruleset",
+				mcp.Required(),
+				mcp.Description(rulesetSpecDescription),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ruleset, err := parseRulesetSpec(request.GetArguments()["ruleset"])
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Repositories.CreateRuleset(ctx, owner, repo, ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to create ruleset: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalRuleset(created)), nil
+		}
+}
+
+// UpdateRuleset creates a tool to replace a ruleset of a repository.
+func UpdateRuleset(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_ruleset",
+			mcp.WithDescription(t("TOOL_UPDATE_RULESET_DESCRIPTION", "Update a ruleset of a GitHub repository. The given ruleset replaces the existing one, so pass the full ruleset, e.g. as returned by get_ruleset with the changes applied")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_UPDATE_RULESET_USER_TITLE", "Update repository ruleset"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("ruleset_id",
+				mcp.Required(),
+				mcp.Description("ID of the ruleset to update, as returned by list_rulesets"),
+			),
+			mcp.WithObject("ruleset",
+				mcp.Required(),
+				mcp.Description(rulesetSpecDescription),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			rulesetID, err := RequiredInt(request, "ruleset_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ruleset, err := parseRulesetSpec(request.GetArguments()["ruleset"])
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			updated, resp, err := client.Repositories.UpdateRuleset(ctx, owner, repo, int64(rulesetID), ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update ruleset: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to update ruleset: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(newMinimalRuleset(updated)), nil
+		}
+}
@@ -0,0 +1,377 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListRulesets(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListRulesets(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_rulesets", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "includes_parents")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockRulesets := []*github.RepositoryRuleset{
+		{
+			ID:          github.Ptr(int64(42)),
+			Name:        "main",
+			Target:      github.Ptr(github.RulesetTargetBranch),
+			SourceType:  github.Ptr(github.RulesetSourceTypeRepository),
+			Source:      "owner/repo",
+			Enforcement: github.RulesetEnforcementActive,
+		},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectedErrMsg   string
+		expectedRulesets []MinimalRuleset
+	}{
+		{
+			name: "repository rulesets only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{"includes_parents": "false", "page": "1", "per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, mockRulesets),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"includes_parents": false,
+			},
+			expectedRulesets: []MinimalRuleset{
+				{ID: 42, Name: "main", Target: "branch", Enforcement: "active", SourceType: "Repository", Source: "owner/repo"},
+			},
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesetsByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list rulesets",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListRulesets(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedRulesets []MinimalRuleset
+			getListResult(t, textContent.Text, &returnedRulesets)
+			assert.Equal(t, tc.expectedRulesets, returnedRulesets)
+		})
+	}
+}
+
+func Test_GetRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id"})
+
+	mockClient = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposRulesetsByOwnerByRepoByRulesetId,
+			expectPath(t, "/repos/owner/repo/rulesets/42").andThen(
+				expectQueryParams(t, map[string]string{"includes_parents": "true"}).andThen(
+					mockResponse(t, http.StatusOK, &github.RepositoryRuleset{
+						ID:          github.Ptr(int64(42)),
+						Name:        "main",
+						Enforcement: github.RulesetEnforcementEvaluate,
+						Rules:       &github.RepositoryRulesetRules{Deletion: &github.EmptyRuleParameters{}},
+					}),
+				),
+			),
+		),
+	))
+	_, handler := GetRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ruleset_id": float64(42),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedRuleset github.RepositoryRuleset
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedRuleset))
+	assert.Equal(t, int64(42), returnedRuleset.GetID())
+	assert.Equal(t, github.RulesetEnforcementEvaluate, returnedRuleset.Enforcement)
+	require.NotNil(t, returnedRuleset.Rules)
+	assert.NotNil(t, returnedRuleset.Rules.Deletion)
+}
+
+func Test_CreateRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "ruleset")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset"})
+
+	mockCreated := &github.RepositoryRuleset{
+		ID:          github.Ptr(int64(7)),
+		Name:        "protect main",
+		Target:      github.Ptr(github.RulesetTargetBranch),
+		Enforcement: github.RulesetEnforcementActive,
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		ruleset         any
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedRuleset MinimalRuleset
+	}{
+		{
+			name: "ruleset created",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"name":        "protect main",
+						"source":      "",
+						"target":      "branch",
+						"enforcement": "active",
+						"conditions": map[string]any{
+							"ref_name": map[string]any{
+								"include": []any{"~DEFAULT_BRANCH"},
+								"exclude": []any{},
+							},
+						},
+						"rules": []any{
+							map[string]any{"type": "deletion"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockCreated),
+					),
+				),
+			),
+			ruleset: map[string]any{
+				"id":          float64(1),
+				"name":        "protect main",
+				"target":      "branch",
+				"enforcement": "active",
+				"conditions": map[string]any{
+					"ref_name": map[string]any{
+						"include": []any{"~DEFAULT_BRANCH"},
+						"exclude": []any{},
+					},
+				},
+				"rules": []any{
+					map[string]any{"type": "deletion"},
+				},
+			},
+			expectedRuleset: MinimalRuleset{ID: 7, Name: "protect main", Target: "branch", Enforcement: "active"},
+		},
+		{
+			name: "ruleset as a JSON string",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					mockResponse(t, http.StatusCreated, mockCreated),
+				),
+			),
+			ruleset:         `{"name": "protect main", "enforcement": "active"}`,
+			expectedRuleset: MinimalRuleset{ID: 7, Name: "protect main", Target: "branch", Enforcement: "active"},
+		},
+		{
+			name:            "missing name",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			ruleset:         map[string]any{"enforcement": "active"},
+			expectToolError: true,
+			expectedErrMsg:  "ruleset.name is required",
+		},
+		{
+			name:            "invalid enforcement",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			ruleset:         map[string]any{"name": "main", "enforcement": "strict"},
+			expectToolError: true,
+			expectedErrMsg:  "ruleset.enforcement is required and must be one of",
+		},
+		{
+			name:            "invalid target",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			ruleset:         map[string]any{"name": "main", "enforcement": "active", "target": "commit"},
+			expectToolError: true,
+			expectedErrMsg:  "ruleset.target must be one of",
+		},
+		{
+			name:         "unknown rule type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			ruleset: map[string]any{
+				"name":        "main",
+				"enforcement": "active",
+				"rules":       []any{map[string]any{"type": "no_force_push"}},
+			},
+			expectToolError: true,
+			expectedErrMsg:  `ruleset.rules[0].type "no_force_push" is not a known rule type`,
+		},
+		{
+			name:            "invalid JSON string",
+			mockedClient:    mock.NewMockedHTTPClient(),
+			ruleset:         `{"name": `,
+			expectToolError: true,
+			expectedErrMsg:  "ruleset is not valid JSON",
+		},
+		{
+			name: "validation failed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposRulesetsByOwnerByRepo,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			ruleset:        map[string]any{"name": "main", "enforcement": "active"},
+			expectError:    true,
+			expectedErrMsg: "failed to create ruleset",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateRuleset(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"ruleset": tc.ruleset,
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedRuleset MinimalRuleset
+			err = json.Unmarshal([]byte(textContent.Text), &returnedRuleset)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRuleset, returnedRuleset)
+		})
+	}
+}
+
+func Test_UpdateRuleset(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_ruleset", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ruleset_id", "ruleset"})
+
+	mockClient = github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PutReposRulesetsByOwnerByRepoByRulesetId,
+			expectPath(t, "/repos/owner/repo/rulesets/42").andThen(
+				expectRequestBody(t, map[string]any{
+					"name":        "main",
+					"source":      "",
+					"enforcement": "disabled",
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.RepositoryRuleset{
+						ID:          github.Ptr(int64(42)),
+						Name:        "main",
+						Enforcement: github.RulesetEnforcementDisabled,
+					}),
+				),
+			),
+		),
+	))
+	_, handler := UpdateRuleset(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	// Read-only fields returned by get_ruleset are not sent back
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ruleset_id": float64(42),
+		"ruleset": map[string]any{
+			"id":          float64(42),
+			"name":        "main",
+			"source_type": "Repository",
+			"source":      "owner/repo",
+			"enforcement": "disabled",
+			"created_at":  "2024-01-01T00:00:00Z",
+		},
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedRuleset MinimalRuleset
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedRuleset))
+	assert.Equal(t, MinimalRuleset{ID: 42, Name: "main", Enforcement: "disabled"}, returnedRuleset)
+}
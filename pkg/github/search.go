@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
@@ -159,17 +161,27 @@ type MinimalSearchUsersResult struct {
 	Items             []MinimalUser `json:"items"`
 }
 
+// userSearchQuery scopes q to users. A bare email address is only matched against emails, which
+// GitHub searches for users who made a verified email public.
+func userSearchQuery(q string) string {
+	q = strings.TrimSpace(q)
+	if strings.Contains(q, "@") && !strings.ContainsAny(q, " :") {
+		q += " in:email"
+	}
+	return "type:user " + q
+}
+
 // SearchUsers creates a tool to search for GitHub users.
 func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_users",
-			mcp.WithDescription(t("TOOL_SEARCH_USERS_DESCRIPTION", "Search for GitHub users")),
+			mcp.WithDescription(t("TOOL_SEARCH_USERS_DESCRIPTION", "Search for GitHub users, returning their logins and profile URLs. Also resolves an email address to a user, but only when the user made a verified email public. Search results carry no names, use get_user for the full profile")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_SEARCH_USERS_USER_TITLE", "Search users"),
 				ReadOnlyHint: toBoolPtr(true),
 			}),
 			mcp.WithString("q",
 				mcp.Required(),
-				mcp.Description("Search query using GitHub users search syntax"),
+				mcp.Description("Search query using GitHub users search syntax, or an email address"),
 			),
 			mcp.WithString("sort",
 				mcp.Description("Sort field by category"),
@@ -213,7 +225,7 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			result, resp, err := client.Search.Users(ctx, "type:user "+query, opts)
+			result, resp, err := client.Search.Users(ctx, userSearchQuery(query), opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to search users: %w", err)
 			}
@@ -252,3 +264,130 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			return mcp.NewToolResultText(string(r)), nil
 		}
 }
+
+// searchResultCap is the number of results GitHub search returns at most, pages past it fail
+const searchResultCap = 1000
+
+// checkSearchCap rejects pages past the results GitHub search returns
+func checkSearchCap(pagination PaginationParams) error {
+	if pagination.page*pagination.perPage > searchResultCap {
+		return fmt.Errorf("GitHub search only returns the first %d results, page %d with perPage %d is past them. Narrow the query instead", searchResultCap, pagination.page, pagination.perPage)
+	}
+	return nil
+}
+
+// MinimalSearchCommit is a commit found by search_commits.
+type MinimalSearchCommit struct {
+	SHA         string     `json:"sha"`
+	Message     string     `json:"message"`
+	Author      string     `json:"author,omitempty"`
+	AuthorEmail string     `json:"author_email,omitempty"`
+	AuthorLogin string     `json:"author_login,omitempty"`
+	Date        *time.Time `json:"date,omitempty"`
+	Repository  string     `json:"repository,omitempty"`
+	URL         string     `json:"url"`
+}
+
+type MinimalSearchCommitsResult struct {
+	TotalCount        int                   `json:"total_count"`
+	IncompleteResults bool                  `json:"incomplete_results"`
+	Items             []MinimalSearchCommit `json:"items"`
+}
+
+// SearchCommits creates a tool to search for commits across GitHub repositories.
+func SearchCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_commits",
+			mcp.WithDescription(t("TOOL_SEARCH_COMMITS_DESCRIPTION", "Search for commits by message, author, committer, date or hash, returning their SHA, message, author and URL. Only the first 1000 results can be paged through")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_COMMITS_USER_TITLE", "Search commits"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("q",
+				mcp.Required(),
+				mcp.Description("Search query using GitHub commit search syntax, e.g. 'fix typo author:octocat repo:octo-org/octo-repo'"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, best match if omitted"),
+				mcp.Enum("author-date", "committer-date"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := requiredParam[string](request, "q")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			order, err := OptionalParam[string](request, "order")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := checkSearchCap(pagination); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SearchOptions{
+				Sort:  sort,
+				Order: order,
+				ListOptions: github.ListOptions{
+					PerPage: pagination.perPage,
+					Page:    pagination.page,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// The client sends the cloak-preview media type commit search used to require
+			result, resp, err := client.Search.Commits(ctx, query, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search commits: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != 200 {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to search commits: %s", string(body))), nil
+			}
+
+			minimalCommits := make([]MinimalSearchCommit, 0, len(result.Commits))
+			for _, commit := range result.Commits {
+				author := commit.GetCommit().GetAuthor()
+				mc := MinimalSearchCommit{
+					SHA:         commit.GetSHA(),
+					Message:     commit.GetCommit().GetMessage(),
+					Author:      author.GetName(),
+					AuthorEmail: author.GetEmail(),
+					AuthorLogin: commit.GetAuthor().GetLogin(),
+					Repository:  commit.GetRepository().GetFullName(),
+					URL:         commit.GetHTMLURL(),
+				}
+				if author.Date != nil {
+					mc.Date = &author.Date.Time
+				}
+				minimalCommits = append(minimalCommits, mc)
+			}
+
+			return MarshalledTextResult(MinimalSearchCommitsResult{
+				TotalCount:        result.GetTotal(),
+				IncompleteResults: result.GetIncompleteResults(),
+				Items:             minimalCommits,
+			}), nil
+		}
+}
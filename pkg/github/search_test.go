@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
@@ -400,6 +401,26 @@ func Test_SearchUsers(t *testing.T) {
 			expectError:    false,
 			expectedResult: mockSearchResult,
 		},
+		{
+			name: "email address is matched against emails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchUsers,
+					expectQueryParams(t, map[string]string{
+						"q":        "type:user octocat@example.com in:email",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"q": "octocat@example.com",
+			},
+			expectError:    false,
+			expectedResult: mockSearchResult,
+		},
 		{
 			name: "search users fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -461,3 +482,153 @@ func Test_SearchUsers(t *testing.T) {
 		})
 	}
 }
+
+func Test_SearchCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "search_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "q")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "order")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"q"})
+
+	authorDate := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockSearchResult := &github.CommitsSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Commits: []*github.CommitResult{
+			{
+				SHA:     github.Ptr("abc123"),
+				HTMLURL: github.Ptr("https://github.com/octo-org/octo-repo/commit/abc123"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Fix typo"),
+					Author: &github.CommitAuthor{
+						Name:  github.Ptr("Mona Octocat"),
+						Email: github.Ptr("mona@example.com"),
+						Date:  &github.Timestamp{Time: authorDate},
+					},
+				},
+				Author:     &github.User{Login: github.Ptr("octocat")},
+				Repository: &github.Repository{FullName: github.Ptr("octo-org/octo-repo")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedResult  MinimalSearchCommitsResult
+	}{
+		{
+			name: "commits found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCommits,
+					expectQueryParams(t, map[string]string{
+						"q":        "fix typo author:octocat",
+						"sort":     "author-date",
+						"order":    "desc",
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						func(w http.ResponseWriter, r *http.Request) {
+							assert.Contains(t, r.Header.Get("Accept"), "cloak-preview")
+							mockResponse(t, http.StatusOK, mockSearchResult)(w, r)
+						},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"q":       "fix typo author:octocat",
+				"sort":    "author-date",
+				"order":   "desc",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedResult: MinimalSearchCommitsResult{
+				TotalCount: 1,
+				Items: []MinimalSearchCommit{
+					{
+						SHA:         "abc123",
+						Message:     "Fix typo",
+						Author:      "Mona Octocat",
+						AuthorEmail: "mona@example.com",
+						AuthorLogin: "octocat",
+						Date:        &authorDate,
+						Repository:  "octo-org/octo-repo",
+						URL:         "https://github.com/octo-org/octo-repo/commit/abc123",
+					},
+				},
+			},
+		},
+		{
+			name:         "page past the search cap",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"q":       "fix",
+				"page":    float64(11),
+				"perPage": float64(100),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "GitHub search only returns the first 1000 results",
+		},
+		{
+			name: "invalid query",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCommits,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"q": "author-date:>",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to search commits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedResult MinimalSearchCommitsResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	mcplog "github.com/github/github-mcp-server/pkg/log"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrorCodeSecretDetected is returned when a file tool refuses to write content that looks like a secret.
+const ErrorCodeSecretDetected = "secret_detected"
+
+// SecretPattern is a named regular expression matching one kind of secret.
+type SecretPattern struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultSecretPatterns returns the patterns used when none are configured: AWS keys, GitHub tokens
+// and PEM encoded private keys.
+func DefaultSecretPatterns() []SecretPattern {
+	return []SecretPattern{
+		{Name: "aws_access_key_id", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{Name: "aws_secret_access_key", Pattern: regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}`)},
+		{Name: "github_token", Pattern: regexp.MustCompile(`\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}\b`)},
+		{Name: "github_fine_grained_token", Pattern: regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{82}\b`)},
+		{Name: "private_key", Pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY( BLOCK)?-----`)},
+	}
+}
+
+// ParseSecretPatterns reads one name=regex pattern per line. Blank lines and lines starting with # are skipped.
+func ParseSecretPatterns(r io.Reader) ([]SecretPattern, error) {
+	var patterns []SecretPattern
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, expr, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.TrimSpace(expr) == "" {
+			return nil, fmt.Errorf("line %d: expected name=regex", lineNumber)
+		}
+		pattern, err := regexp.Compile(strings.TrimSpace(expr))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %s: %w", lineNumber, name, err)
+		}
+		patterns = append(patterns, SecretPattern{Name: name, Pattern: pattern})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
+
+// SecretFinding locates a match of a secret pattern. It never carries the matched text.
+type SecretFinding struct {
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+	Line    int    `json:"line"`
+}
+
+// SecretScanner looks for secrets in file content before it is written to GitHub.
+type SecretScanner struct {
+	patterns []SecretPattern
+}
+
+// NewSecretScanner creates a scanner for patterns, falling back to DefaultSecretPatterns when there are none.
+func NewSecretScanner(patterns []SecretPattern) *SecretScanner {
+	if len(patterns) == 0 {
+		patterns = DefaultSecretPatterns()
+	}
+	return &SecretScanner{patterns: patterns}
+}
+
+// Scan returns the first match of every pattern in content.
+func (s *SecretScanner) Scan(path, content string) []SecretFinding {
+	var findings []SecretFinding
+	for _, pattern := range s.patterns {
+		loc := pattern.Pattern.FindStringIndex(content)
+		if loc == nil {
+			continue
+		}
+		findings = append(findings, SecretFinding{
+			Path:    path,
+			Pattern: pattern.Name,
+			Line:    strings.Count(content[:loc[0]], "\n") + 1,
+		})
+	}
+	return findings
+}
+
+type secretScannerContextKey struct{}
+
+// ContextWithSecretScanner returns a context carrying the scanner the file tools check content with.
+func ContextWithSecretScanner(ctx context.Context, scanner *SecretScanner) context.Context {
+	return context.WithValue(ctx, secretScannerContextKey{}, scanner)
+}
+
+// SecretScannerFromContext returns the secret scanner carried by ctx, if any.
+func SecretScannerFromContext(ctx context.Context) (*SecretScanner, bool) {
+	scanner, ok := ctx.Value(secretScannerContextKey{}).(*SecretScanner)
+	return scanner, ok && scanner != nil
+}
+
+// SecretScanMiddleware returns a tool handler middleware making scanner available to the file tools.
+// Without it, content is written without being scanned.
+func SecretScanMiddleware(scanner *SecretScanner) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(ContextWithSecretScanner(ctx, scanner), request)
+		}
+	}
+}
+
+// WithAllowSecrets adds the parameter that skips the secret scan of a file tool.
+func WithAllowSecrets() mcp.ToolOption {
+	return mcp.WithBoolean("allow_secrets",
+		mcp.Description("Write the content even if it looks like it contains secrets such as access tokens or private keys. Only set this when the match is known to be harmless, e.g. a test fixture"),
+	)
+}
+
+// secretScanFile is a file about to be written by a tool
+type secretScanFile struct {
+	path    string
+	content string
+}
+
+// blockSecrets scans files with the scanner on ctx. When a secret is found and the call does not set
+// allow_secrets, it logs the attempt and returns a secret_detected tool error. It returns nil otherwise.
+func blockSecrets(ctx context.Context, request mcp.CallToolRequest, files ...secretScanFile) (*mcp.CallToolResult, error) {
+	scanner, ok := SecretScannerFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	allowSecrets, err := OptionalParam[bool](request, "allow_secrets")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if allowSecrets {
+		return nil, nil
+	}
+
+	var findings []SecretFinding
+	for _, file := range files {
+		findings = append(findings, scanner.Scan(file.path, file.content)...)
+	}
+	if len(findings) == 0 {
+		return nil, nil
+	}
+
+	patterns := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		patterns = append(patterns, finding.Path+":"+finding.Pattern)
+	}
+	mcplog.FromContext(ctx).WithFields(logrus.Fields{
+		"tool":     request.Params.Name,
+		"findings": strings.Join(patterns, ","),
+	}).Warn("Blocked write of content that looks like a secret")
+
+	r, err := json.Marshal(map[string]any{
+		"error":    ErrorCodeSecretDetected,
+		"message":  "The content looks like it contains secrets and was not written. Remove them, or set allow_secrets if they are known to be harmless.",
+		"findings": findings,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	return mcp.NewToolResultError(string(r)), nil
+}
@@ -228,8 +228,7 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 
 			// Unmarshal and verify the result
 			var returnedAlerts []*github.SecretScanningAlert
-			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
-			assert.NoError(t, err)
+			getListResult(t, textContent.Text, &returnedAlerts)
 			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
 			for i, alert := range returnedAlerts {
 				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RedactedValue replaces the sensitive arguments of tool calls wherever the server passes arguments on or
+// stores them
+const RedactedValue = "[REDACTED]"
+
+// Sensitive marks a tool parameter as holding a secret, such as the value of an Actions secret. It sets the
+// JSON schema writeOnly keyword, so clients know the value is never returned either.
+func Sensitive() mcp.PropertyOption {
+	return func(schema map[string]any) {
+		schema["writeOnly"] = true
+	}
+}
+
+// SensitiveArguments returns the names of the parameters of tool marked Sensitive, sorted
+func SensitiveArguments(tool mcp.Tool) []string {
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(tool.InputSchema.Properties)) {
+		schema, ok := tool.InputSchema.Properties[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		if writeOnly, _ := schema["writeOnly"].(bool); writeOnly {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// RedactArguments returns a copy of args with the arguments called names replaced by RedactedValue. args is
+// returned as is when none of them are set, and is never modified.
+func RedactArguments(args map[string]any, names []string) map[string]any {
+	redacted := args
+	cloned := false
+	for _, name := range names {
+		if _, ok := args[name]; !ok {
+			continue
+		}
+		if !cloned {
+			redacted = maps.Clone(args)
+			cloned = true
+		}
+		redacted[name] = RedactedValue
+	}
+	return redacted
+}
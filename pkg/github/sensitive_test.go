@@ -0,0 +1,18 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RedactArguments(t *testing.T) {
+	args := map[string]any{"org": "octo-org", "value": "hunter2"}
+
+	redacted := RedactArguments(args, []string{"value", "token"})
+	assert.Equal(t, map[string]any{"org": "octo-org", "value": RedactedValue}, redacted)
+	assert.Equal(t, "hunter2", args["value"], "the arguments of the request are left untouched")
+
+	assert.Equal(t, args, RedactArguments(args, nil))
+	assert.Nil(t, RedactArguments(nil, []string{"value"}))
+}
@@ -1,9 +1,11 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -174,6 +176,34 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
+// OptionalIntArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns its zero-value
+// 2. If it is present, iterates the elements and checks each is a number
+func OptionalIntArrayParam(r mcp.CallToolRequest, p string) ([]int, error) {
+	// Check if the parameter is present in the request
+	if _, ok := r.GetArguments()[p]; !ok {
+		return []int{}, nil
+	}
+
+	switch v := r.GetArguments()[p].(type) {
+	case nil:
+		return []int{}, nil
+	case []any:
+		intSlice := make([]int, len(v))
+		for i, v := range v {
+			n, ok := v.(float64)
+			if !ok {
+				return []int{}, fmt.Errorf("parameter %s is not of type number, is %T", p, v)
+			}
+			intSlice[i] = int(n)
+		}
+		return intSlice, nil
+	default:
+		return []int{}, fmt.Errorf("parameter %s could not be coerced to []int, is %T", p, r.GetArguments()[p])
+	}
+}
+
 // WithPagination returns a ToolOption that adds "page" and "perPage" parameters to the tool.
 // The "page" parameter is optional, min 1. The "perPage" parameter is optional, min 1, max 100.
 func WithPagination() mcp.ToolOption {
@@ -224,3 +254,154 @@ func MarshalledTextResult(v any) *mcp.CallToolResult {
 
 	return mcp.NewToolResultText(string(data))
 }
+
+// PageInfo describes where a page of list results sits, as parsed from GitHub's Link header.
+// Pages that do not exist are left out.
+type PageInfo struct {
+	NextPage   int  `json:"next_page,omitempty"`
+	PrevPage   int  `json:"prev_page,omitempty"`
+	LastPage   int  `json:"last_page,omitempty"`
+	TotalCount *int `json:"total_count,omitempty"`
+}
+
+// ListResult is the envelope list tools return, so that every list can be traversed the same way.
+type ListResult struct {
+	Items      any      `json:"items"`
+	Pagination PageInfo `json:"pagination"`
+}
+
+// NewPageInfo reads the pagination links of resp. totalCount is set for the APIs that report one.
+func NewPageInfo(resp *github.Response, totalCount *int) PageInfo {
+	info := PageInfo{TotalCount: totalCount}
+	if resp != nil {
+		info.NextPage = resp.NextPage
+		info.PrevPage = resp.PrevPage
+		info.LastPage = resp.LastPage
+	}
+	return info
+}
+
+// MarshalledListResult wraps a page of items and its pagination metadata in a ListResult
+func MarshalledListResult(items any, resp *github.Response, totalCount *int) *mcp.CallToolResult {
+	return MarshalledTextResult(ListResult{
+		Items:      items,
+		Pagination: NewPageInfo(resp, totalCount),
+	})
+}
+
+type paginationConcurrencyContextKey struct{}
+
+// ContextWithPaginationConcurrency returns a context allowing FetchAllPages to fetch up to n pages at once.
+func ContextWithPaginationConcurrency(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, paginationConcurrencyContextKey{}, n)
+}
+
+// PaginationConcurrencyFromContext returns how many pages FetchAllPages may fetch at once, 1 unless configured.
+func PaginationConcurrencyFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(paginationConcurrencyContextKey{}).(int); ok && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// PaginationConcurrencyMiddleware returns a tool handler middleware letting tools that read whole lists
+// fetch up to n pages at once.
+func PaginationConcurrencyMiddleware(n int) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(ContextWithPaginationConcurrency(ctx, n), request)
+		}
+	}
+}
+
+// PageFetcher fetches one page of a list. Pages are numbered from 1.
+type PageFetcher[T any] func(ctx context.Context, page int) ([]T, *github.Response, error)
+
+// FetchAllPages fetches every page of a list and returns the items in page order. When the Link header
+// of the first page gives the last page, the remaining pages are fetched concurrently, bounded by
+// PaginationConcurrencyFromContext. Otherwise the next links are followed one page at a time. Each page
+// is also streamed to the client as it arrives, see ResultStreamingMiddleware.
+func FetchAllPages[T any](ctx context.Context, fetch PageFetcher[T]) ([]T, error) {
+	items, resp, err := fetch(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	streamPage(ctx, 1, items)
+	if resp == nil || resp.NextPage == 0 {
+		return items, nil
+	}
+
+	if concurrency := PaginationConcurrencyFromContext(ctx); concurrency > 1 && resp.LastPage > 1 {
+		rest, err := fetchPagesConcurrently(ctx, concurrency, resp.NextPage, resp.LastPage, fetch)
+		if err != nil {
+			return nil, err
+		}
+		return append(items, rest...), nil
+	}
+
+	for resp != nil && resp.NextPage != 0 {
+		pageNumber := resp.NextPage
+		var page []T
+		page, resp, err = fetch(ctx, pageNumber)
+		if err != nil {
+			return nil, err
+		}
+		streamPage(ctx, pageNumber, page)
+		items = append(items, page...)
+	}
+	return items, nil
+}
+
+// fetchPagesConcurrently fetches pages first to last with at most concurrency requests in flight.
+// The first error cancels the pages still to be fetched and is returned.
+func fetchPagesConcurrently[T any](ctx context.Context, concurrency, first, last int, fetch PageFetcher[T]) ([]T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]T, last-first+1)
+	sem := make(chan struct{}, concurrency)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		fetchErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			fetchErr = err
+			cancel()
+		})
+	}
+
+pages:
+	for page := first; page <= last; page++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			fail(ctx.Err())
+			break pages
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			items, _, err := fetch(ctx, page)
+			if err != nil {
+				fail(err)
+				return
+			}
+			streamPage(ctx, page, items)
+			results[page-first] = items
+		}()
+	}
+	wg.Wait()
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	var items []T
+	for _, page := range results {
+		items = append(items, page...)
+	}
+	return items, nil
+}
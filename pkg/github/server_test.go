@@ -6,11 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func stubGetClientFn(client *github.Client) GetClientFn {
@@ -471,6 +475,65 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
+func TestOptionalIntArrayParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		paramName   string
+		expected    []int
+		expectError bool
+	}{
+		{
+			name:        "parameter not in request",
+			params:      map[string]any{},
+			paramName:   "ids",
+			expected:    []int{},
+			expectError: false,
+		},
+		{
+			name: "valid number array parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), float64(2)},
+			},
+			paramName:   "ids",
+			expected:    []int{1, 2},
+			expectError: false,
+		},
+		{
+			name: "wrong type parameter",
+			params: map[string]any{
+				"ids": 1,
+			},
+			paramName:   "ids",
+			expected:    []int{},
+			expectError: true,
+		},
+		{
+			name: "wrong slice type parameter",
+			params: map[string]any{
+				"ids": []any{float64(1), "2"},
+			},
+			paramName:   "ids",
+			expected:    []int{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.params)
+			result, err := OptionalIntArrayParam(request, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestOptionalPaginationParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -553,3 +616,124 @@ func TestOptionalPaginationParams(t *testing.T) {
 		})
 	}
 }
+
+func Test_MarshalledListResult(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposBranchesByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/branches?page=3>; rel="next", `+
+					`<https://api.github.com/repos/owner/repo/branches?page=1>; rel="prev", `+
+					`<https://api.github.com/repos/owner/repo/branches?page=5>; rel="last"`)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`[{"name":"main"}]`))
+			}),
+		),
+	))
+
+	branches, resp, err := client.Repositories.ListBranches(context.Background(), "owner", "repo", nil)
+	require.NoError(t, err)
+
+	result := MarshalledListResult(branches, resp, nil)
+	text := getTextResult(t, result).Text
+
+	var returnedBranches []*github.Branch
+	pagination := getListResult(t, text, &returnedBranches)
+	require.Len(t, returnedBranches, 1)
+	assert.Equal(t, "main", returnedBranches[0].GetName())
+	assert.Equal(t, PageInfo{NextPage: 3, PrevPage: 1, LastPage: 5}, pagination)
+
+	// Pages that do not exist and unknown totals are left out
+	total := 1
+	assert.JSONEq(t, `{"items":[],"pagination":{"total_count":1}}`,
+		getTextResult(t, MarshalledListResult([]string{}, &github.Response{}, &total)).Text)
+}
+
+func Test_FetchAllPages(t *testing.T) {
+	// pageFetcher serves lastPage pages of two items each, linking to the last page only when withLast is set
+	pageFetcher := func(lastPage int, withLast bool, failPage int, inFlight, maxInFlight *int32) PageFetcher[int] {
+		var mu sync.Mutex
+		return func(_ context.Context, page int) ([]int, *github.Response, error) {
+			mu.Lock()
+			*inFlight++
+			if *inFlight > *maxInFlight {
+				*maxInFlight = *inFlight
+			}
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				*inFlight--
+				mu.Unlock()
+			}()
+			time.Sleep(5 * time.Millisecond)
+
+			if page == failPage {
+				return nil, nil, fmt.Errorf("page %d failed", page)
+			}
+			resp := &github.Response{}
+			if page < lastPage {
+				resp.NextPage = page + 1
+				if withLast {
+					resp.LastPage = lastPage
+				}
+			}
+			return []int{2*page - 1, 2 * page}, resp, nil
+		}
+	}
+
+	tests := []struct {
+		name                string
+		concurrency         int
+		withLast            bool
+		failPage            int
+		expectedItems       []int
+		expectedErrMsg      string
+		expectedMaxInFlight int32
+	}{
+		{
+			name:                "sequential without concurrency",
+			concurrency:         1,
+			withLast:            true,
+			expectedItems:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expectedMaxInFlight: 1,
+		},
+		{
+			name:                "concurrent when the last page is known",
+			concurrency:         3,
+			withLast:            true,
+			expectedItems:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expectedMaxInFlight: 3,
+		},
+		{
+			name:                "sequential when the last page is unknown",
+			concurrency:         3,
+			expectedItems:       []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			expectedMaxInFlight: 1,
+		},
+		{
+			name:           "page error",
+			concurrency:    3,
+			withLast:       true,
+			failPage:       3,
+			expectedErrMsg: "page 3 failed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var inFlight, maxInFlight int32
+			ctx := ContextWithPaginationConcurrency(context.Background(), tc.concurrency)
+
+			items, err := FetchAllPages(ctx, pageFetcher(5, tc.withLast, tc.failPage, &inFlight, &maxInFlight))
+
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedItems, items)
+			assert.Equal(t, tc.expectedMaxInFlight, maxInFlight)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// audienceListCap is the number of stargazers or watchers GitHub lists at most, pages past it fail
+const audienceListCap = 40000
+
+// checkAudienceListCap rejects pages past the stargazers or watchers GitHub lists
+func checkAudienceListCap(pagination PaginationParams, kind string) error {
+	if pagination.page*pagination.perPage > audienceListCap {
+		return fmt.Errorf("GitHub only lists the first %d %s of a repository, page %d with perPage %d is past them", audienceListCap, kind, pagination.page, pagination.perPage)
+	}
+	return nil
+}
+
+// MinimalStargazer is a user who starred a repository, and when they did.
+type MinimalStargazer struct {
+	Login      string     `json:"login"`
+	ID         int64      `json:"id,omitempty"`
+	ProfileURL string     `json:"profile_url,omitempty"`
+	StarredAt  *time.Time `json:"starred_at,omitempty"`
+}
+
+func newMinimalStargazer(stargazer *github.Stargazer) MinimalStargazer {
+	minimal := MinimalStargazer{
+		Login:      stargazer.GetUser().GetLogin(),
+		ID:         stargazer.GetUser().GetID(),
+		ProfileURL: stargazer.GetUser().GetHTMLURL(),
+	}
+	if stargazer.StarredAt != nil {
+		minimal.StarredAt = &stargazer.StarredAt.Time
+	}
+	return minimal
+}
+
+// ListStargazers creates a tool to list the users who starred a repository.
+func ListStargazers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_stargazers",
+			mcp.WithDescription(t("TOOL_LIST_STARGAZERS_DESCRIPTION", fmt.Sprintf("List the users who starred a GitHub repository and when they did, oldest first. GitHub only lists the first %d stargazers", audienceListCap))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_STARGAZERS_USER_TITLE", "List stargazers"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := checkAudienceListCap(pagination, "stargazers"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			stargazers, resp, err := client.Activity.ListStargazers(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list stargazers: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list stargazers: %s", string(body))), nil
+			}
+
+			minimal := make([]MinimalStargazer, 0, len(stargazers))
+			for _, stargazer := range stargazers {
+				minimal = append(minimal, newMinimalStargazer(stargazer))
+			}
+			return MarshalledListResult(minimal, resp, nil), nil
+		}
+}
+
+// ListWatchers creates a tool to list the users watching a repository.
+func ListWatchers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_watchers",
+			mcp.WithDescription(t("TOOL_LIST_WATCHERS_DESCRIPTION", fmt.Sprintf("List the users watching a GitHub repository, who are notified of all its activity. GitHub only lists the first %d watchers", audienceListCap))),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WATCHERS_USER_TITLE", "List watchers"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := checkAudienceListCap(pagination, "watchers"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			watchers, resp, err := client.Activity.ListWatchers(ctx, owner, repo, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list watchers: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list watchers: %s", string(body))), nil
+			}
+
+			minimal := make([]MinimalUser, 0, len(watchers))
+			for _, watcher := range watchers {
+				minimal = append(minimal, MinimalUser{
+					Login:      watcher.GetLogin(),
+					ID:         watcher.GetID(),
+					ProfileURL: watcher.GetHTMLURL(),
+				})
+			}
+			return MarshalledListResult(minimal, resp, nil), nil
+		}
+}
@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListStargazers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListStargazers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_stargazers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	starredAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "list stargazers",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposStargazersByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "1",
+					}).andThen(
+						http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							assert.Equal(t, "application/vnd.github.v3.star+json", r.Header.Get("Accept"))
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/stargazers?page=3>; rel="next"`)
+							mockResponse(t, http.StatusOK, []*github.Stargazer{
+								{
+									StarredAt: &github.Timestamp{Time: starredAt},
+									User: &github.User{
+										Login:   github.Ptr("octocat"),
+										ID:      github.Ptr(int64(1)),
+										HTMLURL: github.Ptr("https://github.com/octocat"),
+									},
+								},
+							})(w, r)
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(2),
+				"perPage": float64(1),
+			},
+		},
+		{
+			name:         "page past the list cap",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"page":    float64(401),
+				"perPage": float64(100),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "GitHub only lists the first 40000 stargazers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListStargazers(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var stargazers []MinimalStargazer
+			pagination := getListResult(t, getTextResult(t, result).Text, &stargazers)
+			assert.Equal(t, []MinimalStargazer{{
+				Login:      "octocat",
+				ID:         1,
+				ProfileURL: "https://github.com/octocat",
+				StarredAt:  &starredAt,
+			}}, stargazers)
+			assert.Equal(t, 3, pagination.NextPage)
+		})
+	}
+}
+
+func Test_ListWatchers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWatchers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_watchers", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposSubscribersByOwnerByRepo,
+			expectQueryParams(t, map[string]string{
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{
+						Login:   github.Ptr("hubot"),
+						ID:      github.Ptr(int64(2)),
+						HTMLURL: github.Ptr("https://github.com/hubot"),
+					},
+				}),
+			),
+		),
+	))
+	_, handler := ListWatchers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+	}))
+	require.NoError(t, err)
+
+	var watchers []MinimalUser
+	pagination := getListResult(t, getTextResult(t, result).Text, &watchers)
+	assert.Equal(t, []MinimalUser{{
+		Login:      "hubot",
+		ID:         2,
+		ProfileURL: "https://github.com/hubot",
+	}}, watchers)
+	assert.Equal(t, PageInfo{}, pagination)
+}
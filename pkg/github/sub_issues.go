@@ -0,0 +1,346 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalSubIssue is an issue of a sub-issue hierarchy.
+type MinimalSubIssue struct {
+	ID      int64  `json:"id"`
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+func newMinimalSubIssue(issue *github.Issue) MinimalSubIssue {
+	return MinimalSubIssue{
+		ID:      issue.GetID(),
+		Number:  issue.GetNumber(),
+		Title:   issue.GetTitle(),
+		State:   issue.GetState(),
+		HTMLURL: issue.GetHTMLURL(),
+	}
+}
+
+// SubIssuesResult is an issue along with its sub-issues, in their order on the issue.
+type SubIssuesResult struct {
+	Parent MinimalSubIssue `json:"parent"`
+	Total  int             `json:"total"`
+	// Completed counts the closed sub-issues
+	Completed int               `json:"completed"`
+	SubIssues []MinimalSubIssue `json:"sub_issues"`
+}
+
+// subIssueRequest is the body identifying the sub-issue to add or remove, by ID rather than number
+type subIssueRequest struct {
+	SubIssueID    int64 `json:"sub_issue_id"`
+	ReplaceParent bool  `json:"replace_parent,omitempty"`
+}
+
+// doSubIssuesRequest calls a sub-issues endpoint of an issue. go-github does not cover the sub-issues API yet.
+func doSubIssuesRequest(ctx context.Context, client *github.Client, method, url string, body any, v any) (*github.Response, error) {
+	req, err := client.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(ctx, req, v)
+}
+
+// subIssuesUnavailableResult explains the 404 the sub-issues endpoints of an existing issue return when
+// sub-issues are not enabled for its repository. It returns nil for any other error.
+func subIssuesUnavailableResult(err error, owner, repo string) *mcp.CallToolResult {
+	var ghErr *github.ErrorResponse
+	if !errors.As(err, &ghErr) || ghErr.Response == nil || ghErr.Response.StatusCode != http.StatusNotFound {
+		return nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("sub-issues are not enabled for %s/%s, or the token in use cannot read them: %s", owner, repo, ghErr.Message))
+}
+
+// getIssueForSubIssues gets an issue of a sub-issue hierarchy, so that a missing issue is told apart from
+// sub-issues not being enabled
+func getIssueForSubIssues(ctx context.Context, client *github.Client, owner, repo string, number int) (*github.Issue, error) {
+	issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	_ = resp.Body.Close()
+	return issue, nil
+}
+
+// fetchSubIssues gets an issue along with all its sub-issues, returning a tool error result when
+// sub-issues are not enabled
+func fetchSubIssues(ctx context.Context, client *github.Client, owner, repo string, parent *github.Issue) (*SubIssuesResult, *mcp.CallToolResult, error) {
+	subIssues, err := FetchAllPages(ctx, func(ctx context.Context, page int) ([]MinimalSubIssue, *github.Response, error) {
+		url := fmt.Sprintf("repos/%v/%v/issues/%d/sub_issues?per_page=100&page=%d", owner, repo, parent.GetNumber(), page)
+		var issues []*github.Issue
+		resp, err := doSubIssuesRequest(ctx, client, http.MethodGet, url, nil, &issues)
+		if err != nil {
+			return nil, nil, err
+		}
+		_ = resp.Body.Close()
+
+		minimal := make([]MinimalSubIssue, 0, len(issues))
+		for _, issue := range issues {
+			minimal = append(minimal, newMinimalSubIssue(issue))
+		}
+		return minimal, resp, nil
+	})
+	if result := subIssuesUnavailableResult(err, owner, repo); result != nil {
+		return nil, result, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list sub-issues: %w", err)
+	}
+	if subIssues == nil {
+		subIssues = []MinimalSubIssue{}
+	}
+
+	result := &SubIssuesResult{
+		Parent:    newMinimalSubIssue(parent),
+		Total:     len(subIssues),
+		SubIssues: subIssues,
+	}
+	for _, subIssue := range subIssues {
+		if subIssue.State == "closed" {
+			result.Completed++
+		}
+	}
+	return result, nil, nil
+}
+
+// withSubIssueParams adds the parameters shared by the tools changing the sub-issues of an issue
+func withSubIssueParams(description string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		for _, opt := range []mcp.ToolOption{
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+			mcp.WithNumber("sub_issue_number",
+				mcp.Required(),
+				mcp.Description(description),
+			),
+		} {
+			opt(tool)
+		}
+	}
+}
+
+// subIssueParams reads the parameters added by withSubIssueParams
+func subIssueParams(request mcp.CallToolRequest) (owner, repo string, issueNumber, subIssueNumber int, err error) {
+	if owner, err = requiredParam[string](request, "owner"); err != nil {
+		return
+	}
+	if repo, err = requiredParam[string](request, "repo"); err != nil {
+		return
+	}
+	if issueNumber, err = RequiredInt(request, "issue_number"); err != nil {
+		return
+	}
+	subIssueNumber, err = RequiredInt(request, "sub_issue_number")
+	return
+}
+
+// ListSubIssues creates a tool to list the sub-issues of an issue.
+func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_sub_issues",
+			mcp.WithDescription(t("TOOL_LIST_SUB_ISSUES_DESCRIPTION", "List the sub-issues of an issue in their order on the issue, with how many of them are completed")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SUB_ISSUES_USER_TITLE", "List sub-issues"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Number of the parent issue"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			parent, err := getIssueForSubIssues(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return nil, err
+			}
+			subIssues, result, err := fetchSubIssues(ctx, client, owner, repo, parent)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			return MarshalledTextResult(subIssues), nil
+		}
+}
+
+// AddSubIssue creates a tool to add an issue as a sub-issue of another.
+func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_sub_issue",
+			mcp.WithDescription(t("TOOL_ADD_SUB_ISSUE_DESCRIPTION", "Add an issue of the repository as a sub-issue of another issue. Returns the resulting sub-issues of the parent issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SUB_ISSUE_USER_TITLE", "Add sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withSubIssueParams("Number of the issue to add as a sub-issue"),
+			mcp.WithBoolean("replace_parent",
+				mcp.Description("Move the issue from its current parent issue, if it already has one, instead of failing"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, issueNumber, subIssueNumber, err := subIssueParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			replaceParent, err := OptionalParam[bool](request, "replace_parent")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if issueNumber == subIssueNumber {
+				return mcp.NewToolResultError("an issue cannot be a sub-issue of itself"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			parent, err := getIssueForSubIssues(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return nil, err
+			}
+			subIssue, err := getIssueForSubIssues(ctx, client, owner, repo, subIssueNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := doSubIssuesRequest(ctx, client, http.MethodPost,
+				fmt.Sprintf("repos/%v/%v/issues/%d/sub_issues", owner, repo, issueNumber),
+				&subIssueRequest{SubIssueID: subIssue.GetID(), ReplaceParent: replaceParent}, nil)
+			if result := subIssuesUnavailableResult(err, owner, repo); result != nil {
+				return result, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to add sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add sub-issue: %s", string(body))), nil
+			}
+
+			subIssues, result, err := fetchSubIssues(ctx, client, owner, repo, parent)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			return MarshalledTextResult(subIssues), nil
+		}
+}
+
+// RemoveSubIssue creates a tool to remove a sub-issue from its parent issue.
+func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_sub_issue",
+			mcp.WithDescription(t("TOOL_REMOVE_SUB_ISSUE_DESCRIPTION", "Remove a sub-issue from its parent issue, the issue itself is kept. Returns the resulting sub-issues of the parent issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_SUB_ISSUE_USER_TITLE", "Remove sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withSubIssueParams("Number of the sub-issue to remove"),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, repo, issueNumber, subIssueNumber, err := subIssueParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			parent, err := getIssueForSubIssues(ctx, client, owner, repo, issueNumber)
+			if err != nil {
+				return nil, err
+			}
+			// Check the issue is a sub-issue first, GitHub answers 404 either way
+			subIssues, result, err := fetchSubIssues(ctx, client, owner, repo, parent)
+			if result != nil || err != nil {
+				return result, err
+			}
+			index := slices.IndexFunc(subIssues.SubIssues, func(subIssue MinimalSubIssue) bool {
+				return subIssue.Number == subIssueNumber
+			})
+			if index < 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("issue #%d is not a sub-issue of #%d", subIssueNumber, issueNumber)), nil
+			}
+			subIssue := subIssues.SubIssues[index]
+
+			resp, err := doSubIssuesRequest(ctx, client, http.MethodDelete,
+				fmt.Sprintf("repos/%v/%v/issues/%d/sub_issue", owner, repo, issueNumber),
+				&subIssueRequest{SubIssueID: subIssue.ID}, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove sub-issue: %s", string(body))), nil
+			}
+
+			subIssues.SubIssues = slices.Delete(subIssues.SubIssues, index, index+1)
+			subIssues.Total--
+			if subIssue.State == "closed" {
+				subIssues.Completed--
+			}
+
+			return MarshalledTextResult(subIssues), nil
+		}
+}
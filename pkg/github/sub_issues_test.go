@@ -0,0 +1,289 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	mockParentIssue = &github.Issue{ID: github.Ptr(int64(1001)), Number: github.Ptr(1), Title: github.Ptr("Epic"), State: github.Ptr("open")}
+	mockSubIssue2   = &github.Issue{ID: github.Ptr(int64(1002)), Number: github.Ptr(2), Title: github.Ptr("Design"), State: github.Ptr("closed")}
+	mockSubIssue3   = &github.Issue{ID: github.Ptr(int64(1003)), Number: github.Ptr(3), Title: github.Ptr("Build"), State: github.Ptr("open")}
+)
+
+// mockGetIssues serves the issues of the repository by number
+func mockGetIssues(t *testing.T, issues ...*github.Issue) mock.MockBackendOption {
+	return mock.WithRequestMatchHandler(
+		mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			number, _ := strconv.Atoi(path.Base(r.URL.Path))
+			for _, issue := range issues {
+				if issue.GetNumber() == number {
+					mockResponse(t, http.StatusOK, issue)(w, r)
+					return
+				}
+			}
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+		}),
+	)
+}
+
+func Test_ListSubIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSubIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_sub_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expected        SubIssuesResult
+	}{
+		{
+			name: "sub-issues listed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockGetIssues(t, mockParentIssue),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/1/sub_issues").andThen(
+						mockResponse(t, http.StatusOK, []*github.Issue{mockSubIssue2, mockSubIssue3}),
+					),
+				),
+			),
+			expected: SubIssuesResult{
+				Parent:    MinimalSubIssue{ID: 1001, Number: 1, Title: "Epic", State: "open"},
+				Total:     2,
+				Completed: 1,
+				SubIssues: []MinimalSubIssue{
+					{ID: 1002, Number: 2, Title: "Design", State: "closed"},
+					{ID: 1003, Number: 3, Title: "Build", State: "open"},
+				},
+			},
+		},
+		{
+			name: "sub-issues not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockGetIssues(t, mockParentIssue),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			expectToolError: true,
+			expectedErrMsg:  "sub-issues are not enabled for owner/repo",
+		},
+		{
+			name:           "parent issue not found",
+			mockedClient:   mock.NewMockedHTTPClient(mockGetIssues(t)),
+			expectError:    true,
+			expectedErrMsg: "failed to get issue #1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListSubIssues(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(1),
+			}))
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var subIssues SubIssuesResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &subIssues))
+			assert.Equal(t, tc.expected, subIssues)
+		})
+	}
+}
+
+func Test_AddSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "replace_parent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "sub-issue added by ID",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockGetIssues(t, mockParentIssue, mockSubIssue3),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id":   float64(1003),
+						"replace_parent": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockParentIssue),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusOK, []*github.Issue{mockSubIssue3}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(1),
+				"sub_issue_number": float64(3),
+				"replace_parent":   true,
+			},
+		},
+		{
+			name: "sub-issues not enabled",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockGetIssues(t, mockParentIssue, mockSubIssue3),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(1),
+				"sub_issue_number": float64(3),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "sub-issues are not enabled for owner/repo",
+		},
+		{
+			name:         "issue as its own sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(1),
+				"sub_issue_number": float64(1),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "an issue cannot be a sub-issue of itself",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := AddSubIssue(stubGetClientFn(github.NewClient(tc.mockedClient)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var subIssues SubIssuesResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &subIssues))
+			assert.Equal(t, 1, subIssues.Total)
+			assert.Equal(t, 3, subIssues.SubIssues[0].Number)
+		})
+	}
+}
+
+func Test_RemoveSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	newClient := func(t *testing.T) *http.Client {
+		return mock.NewMockedHTTPClient(
+			mockGetIssues(t, mockParentIssue),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+				mockResponse(t, http.StatusOK, []*github.Issue{mockSubIssue2, mockSubIssue3}),
+			),
+			mock.WithRequestMatchHandler(
+				mock.DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber,
+				expectPath(t, "/repos/owner/repo/issues/1/sub_issue").andThen(
+					expectRequestBody(t, map[string]any{"sub_issue_id": float64(1002)}).andThen(
+						mockResponse(t, http.StatusOK, mockParentIssue),
+					),
+				),
+			),
+		)
+	}
+
+	t.Run("sub-issue removed", func(t *testing.T) {
+		_, handler := RemoveSubIssue(stubGetClientFn(github.NewClient(newClient(t))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":            "owner",
+			"repo":             "repo",
+			"issue_number":     float64(1),
+			"sub_issue_number": float64(2),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var subIssues SubIssuesResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &subIssues))
+		assert.Equal(t, SubIssuesResult{
+			Parent:    MinimalSubIssue{ID: 1001, Number: 1, Title: "Epic", State: "open"},
+			Total:     1,
+			SubIssues: []MinimalSubIssue{{ID: 1003, Number: 3, Title: "Build", State: "open"}},
+		}, subIssues)
+	})
+
+	t.Run("issue that is not a sub-issue", func(t *testing.T) {
+		_, handler := RemoveSubIssue(stubGetClientFn(github.NewClient(newClient(t))), translations.NullTranslationHelper)
+
+		result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+			"owner":            "owner",
+			"repo":             "repo",
+			"issue_number":     float64(1),
+			"sub_issue_number": float64(4),
+		}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "issue #4 is not a sub-issue of #1")
+	})
+}
@@ -0,0 +1,246 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// teamRoles are the roles a user can have in a team
+var teamRoles = []string{"member", "maintainer"}
+
+// TeamMembership is the membership of a user in a team, as left by a membership change.
+type TeamMembership struct {
+	Org      string `json:"org"`
+	TeamSlug string `json:"team_slug"`
+	Username string `json:"username"`
+	Role     string `json:"role,omitempty"`
+	// State is active, pending while an invitation to the organization is open, or removed
+	State string `json:"state"`
+}
+
+// teamMembershipParams reads the org, team_slug and username parameters shared by the membership tools
+func teamMembershipParams(request mcp.CallToolRequest) (org, teamSlug, username string, err error) {
+	if org, err = requiredParam[string](request, "org"); err != nil {
+		return "", "", "", err
+	}
+	if teamSlug, err = requiredParam[string](request, "team_slug"); err != nil {
+		return "", "", "", err
+	}
+	if username, err = requiredParam[string](request, "username"); err != nil {
+		return "", "", "", err
+	}
+	return org, teamSlug, username, nil
+}
+
+// ListTeamMembers creates a tool to list the members of a team.
+func ListTeamMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_members",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_MEMBERS_DESCRIPTION", "List the members of a team in a GitHub organization, including members of its child teams")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_MEMBERS_USER_TITLE", "List team members"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug, the URL friendly team name"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Only list members with this role"),
+				mcp.Enum("member", "maintainer", "all"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.TeamListTeamMembersOptions{
+				Role: role,
+				ListOptions: github.ListOptions{
+					Page:    pagination.page,
+					PerPage: pagination.perPage,
+				},
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			members, resp, err := client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list team members: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to list team members: %s", string(body))), nil
+			}
+
+			minimalMembers := make([]MinimalUser, 0, len(members))
+			for _, member := range members {
+				minimalMembers = append(minimalMembers, MinimalUser{
+					Login:      member.GetLogin(),
+					ID:         member.GetID(),
+					ProfileURL: member.GetHTMLURL(),
+					AvatarURL:  member.GetAvatarURL(),
+				})
+			}
+
+			return MarshalledListResult(minimalMembers, resp, nil), nil
+		}
+}
+
+// AddTeamMember creates a tool to add a user to a team, or change their role in it.
+func AddTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_member",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_MEMBER_DESCRIPTION", "Add a user to a team in a GitHub organization, or change the role of an existing member. Users who are not members of the organization are invited, and their membership stays pending until they accept")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_TEAM_MEMBER_USER_TITLE", "Add team member"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug, the URL friendly team name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username of the user to add"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Role of the user in the team, defaults to member"),
+				mcp.Enum(teamRoles...),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, username, err := teamMembershipParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if role == "" {
+				role = "member"
+			}
+			if role != "member" && role != "maintainer" {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid role %q, must be member or maintainer", role)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			membership, resp, err := client.Teams.AddTeamMembershipBySlug(ctx, org, teamSlug, username, &github.TeamAddTeamMembershipOptions{
+				Role: role,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add team member: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to add team member: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(TeamMembership{
+				Org:      org,
+				TeamSlug: teamSlug,
+				Username: username,
+				Role:     membership.GetRole(),
+				State:    membership.GetState(),
+			}), nil
+		}
+}
+
+// RemoveTeamMember creates a tool to remove a user from a team.
+func RemoveTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_team_member",
+			mcp.WithDescription(t("TOOL_REMOVE_TEAM_MEMBER_DESCRIPTION", "Remove a user from a team in a GitHub organization. The user stays a member of the organization")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_TEAM_MEMBER_USER_TITLE", "Remove team member"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("Team slug, the URL friendly team name"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username of the user to remove"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, teamSlug, username, err := teamMembershipParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Teams.RemoveTeamMembershipBySlug(ctx, org, teamSlug, username)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove team member: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to remove team member: %s", string(body))), nil
+			}
+
+			return MarshalledTextResult(TeamMembership{
+				Org:      org,
+				TeamSlug: teamSlug,
+				Username: username,
+				State:    "removed",
+			}), nil
+		}
+}
@@ -0,0 +1,317 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListTeamMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_team_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockMembers := []*github.User{
+		{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), HTMLURL: github.Ptr("https://github.com/octocat")},
+		{Login: github.Ptr("hubot"), ID: github.Ptr(int64(2)), HTMLURL: github.Ptr("https://github.com/hubot")},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedErrMsg  string
+		expectedMembers []MinimalUser
+	}{
+		{
+			name: "members with role filter",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					expectPath(t, "/orgs/org/teams/platform/members").andThen(
+						expectQueryParams(t, map[string]string{"role": "maintainer", "page": "1", "per_page": "30"}).andThen(
+							mockResponse(t, http.StatusOK, mockMembers),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "platform",
+				"role":      "maintainer",
+			},
+			expectedMembers: []MinimalUser{
+				{Login: "octocat", ID: 1, ProfileURL: "https://github.com/octocat"},
+				{Login: "hubot", ID: 2, ProfileURL: "https://github.com/hubot"},
+			},
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list team members",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeamMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedMembers []MinimalUser
+			getListResult(t, textContent.Text, &returnedMembers)
+			assert.Equal(t, tc.expectedMembers, returnedMembers)
+		})
+	}
+}
+
+func Test_AddTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_team_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectToolError    bool
+		expectedErrMsg     string
+		expectedMembership TeamMembership
+	}{
+		{
+			name: "add maintainer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectPath(t, "/orgs/org/teams/platform/memberships/octocat").andThen(
+						expectRequestBody(t, map[string]any{"role": "maintainer"}).andThen(
+							mockResponse(t, http.StatusOK, &github.Membership{
+								Role:  github.Ptr("maintainer"),
+								State: github.Ptr("active"),
+							}),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "platform",
+				"username":  "octocat",
+				"role":      "maintainer",
+			},
+			expectedMembership: TeamMembership{Org: "org", TeamSlug: "platform", Username: "octocat", Role: "maintainer", State: "active"},
+		},
+		{
+			name: "role defaults to member",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectRequestBody(t, map[string]any{"role": "member"}).andThen(
+						mockResponse(t, http.StatusOK, &github.Membership{
+							Role:  github.Ptr("member"),
+							State: github.Ptr("pending"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "platform",
+				"username":  "newcomer",
+			},
+			expectedMembership: TeamMembership{Org: "org", TeamSlug: "platform", Username: "newcomer", Role: "member", State: "pending"},
+		},
+		{
+			name:         "invalid role",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "platform",
+				"username":  "octocat",
+				"role":      "owner",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "must be member or maintainer",
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "org",
+				"team_slug": "missing",
+				"username":  "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add team member",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedMembership TeamMembership
+			err = json.Unmarshal([]byte(textContent.Text), &returnedMembership)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMembership, returnedMembership)
+		})
+	}
+}
+
+func Test_RemoveTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_team_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, *tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "successful removal",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectPath(t, "/orgs/org/teams/platform/memberships/octocat").andThen(
+						mockResponse(t, http.StatusNoContent, nil),
+					),
+				),
+			),
+		},
+		{
+			name: "forbidden",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to remove team member",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"org":       "org",
+				"team_slug": "platform",
+				"username":  "octocat",
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedMembership TeamMembership
+			err = json.Unmarshal([]byte(textContent.Text), &returnedMembership)
+			require.NoError(t, err)
+			assert.Equal(t, TeamMembership{Org: "org", TeamSlug: "platform", Username: "octocat", State: "removed"}, returnedMembership)
+		})
+	}
+}
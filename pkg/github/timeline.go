@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MinimalTimelineEvent is an issue or pull request timeline event with only the fields that describe
+// what happened, who did it and when. Which of the optional fields are set depends on the event.
+type MinimalTimelineEvent struct {
+	ID                int64                  `json:"id,omitempty"`
+	Event             string                 `json:"event"`
+	Actor             string                 `json:"actor,omitempty"`
+	CreatedAt         *time.Time             `json:"created_at,omitempty"`
+	Label             string                 `json:"label,omitempty"`
+	Assignee          string                 `json:"assignee,omitempty"`
+	Milestone         string                 `json:"milestone,omitempty"`
+	Rename            *MinimalRename         `json:"rename,omitempty"`
+	RequestedReviewer string                 `json:"requested_reviewer,omitempty"`
+	RequestedTeam     string                 `json:"requested_team,omitempty"`
+	CommitID          string                 `json:"commit_id,omitempty"`
+	Source            *MinimalTimelineSource `json:"source,omitempty"`
+	State             string                 `json:"state,omitempty"`
+	Body              string                 `json:"body,omitempty"`
+	SHA               string                 `json:"sha,omitempty"`
+	Message           string                 `json:"message,omitempty"`
+}
+
+// MinimalRename is the title change of a renamed event.
+type MinimalRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MinimalTimelineSource is the issue or pull request that cross-referenced the timeline's issue.
+type MinimalTimelineSource struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title,omitempty"`
+	HTMLURL string `json:"html_url,omitempty"`
+}
+
+func newMinimalTimelineEvent(event *github.Timeline) MinimalTimelineEvent {
+	minimal := MinimalTimelineEvent{
+		ID:                event.GetID(),
+		Event:             event.GetEvent(),
+		Actor:             event.GetActor().GetLogin(),
+		Label:             event.GetLabel().GetName(),
+		Assignee:          event.GetAssignee().GetLogin(),
+		Milestone:         event.GetMilestone().GetTitle(),
+		RequestedReviewer: event.GetReviewer().GetLogin(),
+		RequestedTeam:     event.GetRequestedTeam().GetSlug(),
+		CommitID:          event.GetCommitID(),
+		State:             event.GetState(),
+		Body:              event.GetBody(),
+		SHA:               event.GetSHA(),
+		Message:           event.GetMessage(),
+	}
+
+	// Comments and reviews name their author as user, commits as a git author without an account
+	if minimal.Actor == "" {
+		minimal.Actor = event.GetUser().GetLogin()
+	}
+	if minimal.Actor == "" {
+		minimal.Actor = event.GetAuthor().GetName()
+	}
+
+	switch {
+	case event.CreatedAt != nil:
+		minimal.CreatedAt = &event.CreatedAt.Time
+	case event.SubmittedAt != nil:
+		minimal.CreatedAt = &event.SubmittedAt.Time
+	case event.GetAuthor().Date != nil:
+		minimal.CreatedAt = &event.GetAuthor().Date.Time
+	}
+
+	if event.Rename != nil {
+		minimal.Rename = &MinimalRename{
+			From: event.Rename.GetFrom(),
+			To:   event.Rename.GetTo(),
+		}
+	}
+	if issue := event.GetSource().GetIssue(); issue != nil {
+		minimal.Source = &MinimalTimelineSource{
+			Number:  issue.GetNumber(),
+			Title:   issue.GetTitle(),
+			HTMLURL: issue.GetHTMLURL(),
+		}
+	}
+	return minimal
+}
+
+// GetIssueTimeline creates a tool to list the timeline events of an issue or pull request.
+func GetIssueTimeline(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_timeline",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TIMELINE_DESCRIPTION", "Get the timeline of an issue or pull request, oldest first: comments, reviews, commits and events such as labeled, assigned, referenced, cross-referenced, renamed, closed and merged, with their actors and timestamps")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TIMELINE_USER_TITLE", "Get issue timeline"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			WithPagination(),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github sends the timeline preview media types the endpoint needs
+			events, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue timeline: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue timeline: %s", string(body))), nil
+			}
+
+			minimalEvents := make([]MinimalTimelineEvent, 0, len(events))
+			for _, event := range events {
+				minimalEvents = append(minimalEvents, newMinimalTimelineEvent(event))
+			}
+
+			return MarshalledListResult(minimalEvents, resp, nil), nil
+		}
+}
@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIssueTimeline(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTimeline(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_timeline", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	labeledAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	reviewedAt := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	committedAt := time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)
+	mockEvents := []*github.Timeline{
+		{
+			ID:        github.Ptr(int64(1)),
+			Event:     github.Ptr("labeled"),
+			Actor:     &github.User{Login: github.Ptr("octocat")},
+			CreatedAt: &github.Timestamp{Time: labeledAt},
+			Label:     &github.Label{Name: github.Ptr("bug")},
+		},
+		{
+			Event:     github.Ptr("cross-referenced"),
+			Actor:     &github.User{Login: github.Ptr("hubot")},
+			CreatedAt: &github.Timestamp{Time: labeledAt},
+			Source: &github.Source{
+				Type: github.Ptr("issue"),
+				Issue: &github.Issue{
+					Number:  github.Ptr(7),
+					Title:   github.Ptr("Fix the bug"),
+					HTMLURL: github.Ptr("https://github.com/owner/repo/pull/7"),
+				},
+			},
+		},
+		{
+			ID:          github.Ptr(int64(2)),
+			Event:       github.Ptr("reviewed"),
+			User:        &github.User{Login: github.Ptr("reviewer")},
+			SubmittedAt: &github.Timestamp{Time: reviewedAt},
+			State:       github.Ptr("approved"),
+		},
+		{
+			Event:   github.Ptr("committed"),
+			SHA:     github.Ptr("abc123"),
+			Message: github.Ptr("Fix the bug"),
+			Author:  &github.CommitAuthor{Name: github.Ptr("Mona"), Date: &github.Timestamp{Time: committedAt}},
+		},
+		{
+			Event:     github.Ptr("renamed"),
+			Actor:     &github.User{Login: github.Ptr("octocat")},
+			CreatedAt: &github.Timestamp{Time: committedAt},
+			Rename:    &github.Rename{From: github.Ptr("Bug"), To: github.Ptr("Crash on start")},
+		},
+	}
+	expectedEvents := []MinimalTimelineEvent{
+		{ID: 1, Event: "labeled", Actor: "octocat", CreatedAt: &labeledAt, Label: "bug"},
+		{Event: "cross-referenced", Actor: "hubot", CreatedAt: &labeledAt, Source: &MinimalTimelineSource{Number: 7, Title: "Fix the bug", HTMLURL: "https://github.com/owner/repo/pull/7"}},
+		{ID: 2, Event: "reviewed", Actor: "reviewer", CreatedAt: &reviewedAt, State: "approved"},
+		{Event: "committed", Actor: "Mona", CreatedAt: &committedAt, SHA: "abc123", Message: "Fix the bug"},
+		{Event: "renamed", Actor: "octocat", CreatedAt: &committedAt, Rename: &MinimalRename{From: "Bug", To: "Crash on start"}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedEvents []MinimalTimelineEvent
+	}{
+		{
+			name: "timeline page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/timeline").andThen(
+						expectQueryParams(t, map[string]string{"page": "2", "per_page": "50"}).andThen(
+							mockResponse(t, http.StatusOK, mockEvents),
+						),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"page":         float64(2),
+				"perPage":      float64(50),
+			},
+			expectedEvents: expectedEvents,
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue timeline",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetIssueTimeline(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedEvents []MinimalTimelineEvent
+			getListResult(t, textContent.Text, &returnedEvents)
+			assert.Equal(t, tc.expectedEvents, returnedEvents)
+		})
+	}
+}
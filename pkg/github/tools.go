@@ -24,18 +24,50 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, t)),
+			toolsets.NewServerTool(GetReadme(getClient, t)),
+			toolsets.NewServerTool(GetTree(getClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
+			toolsets.NewServerTool(SearchCommits(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(ListCommitComments(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(GetRepoLanguages(getClient, t)),
+			toolsets.NewServerTool(GetContributorStats(getClient, t)),
+			toolsets.NewServerTool(GetRepoTraffic(getClient, t)),
+			toolsets.NewServerTool(ListStargazers(getClient, t)),
+			toolsets.NewServerTool(ListWatchers(getClient, t)),
+			toolsets.NewServerTool(ListDeployKeys(getClient, t)),
+			toolsets.NewServerTool(ListRulesets(getClient, t)),
+			toolsets.NewServerTool(GetRuleset(getClient, t)),
+			toolsets.NewServerTool(GetRepoCustomProperties(getClient, t)),
+			toolsets.NewServerTool(GetMergeSettings(getClient, t)),
+			toolsets.NewServerTool(ListWikiPages(getClient, t)),
+			toolsets.NewServerTool(GetWikiPage(getClient, t)),
+			toolsets.NewServerTool(GetReleaseDownloadStats(getClient, t)),
+			toolsets.NewServerTool(ListIssueTemplates(getClient, t)),
+			toolsets.NewServerTool(GetIssueTemplate(getClient, t)),
+			toolsets.NewServerTool(GetCloneURLs(getClient, t)),
+			toolsets.NewServerTool(DownloadRepoArchive(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(CreateCommitComment(getClient, t)),
+			toolsets.NewServerTool(SetDefaultBranch(getClient, t)),
+			toolsets.NewServerTool(UpdateRepoSettings(getClient, t)),
+			toolsets.NewServerTool(SetMergeSettings(getClient, t)),
+			toolsets.NewServerTool(CreateTag(getClient, t)),
+			toolsets.NewServerTool(CreateRepositoryDispatch(getClient, t)),
+			toolsets.NewServerTool(CreateDeployKey(getClient, t)),
+			toolsets.NewServerTool(DeleteDeployKey(getClient, t)),
+			toolsets.NewServerTool(CreateRuleset(getClient, t)),
+			toolsets.NewServerTool(UpdateRuleset(getClient, t)),
+			toolsets.NewServerTool(SetRepoCustomProperties(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
 		)
@@ -45,16 +77,38 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(GetIssueTimeline(getClient, t)),
+			toolsets.NewServerTool(ListReactions(getClient, t)),
+			toolsets.NewServerTool(ListLabels(getClient, t)),
+			toolsets.NewServerTool(ListSubIssues(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
+			toolsets.NewServerTool(AddAssignees(getClient, t)),
+			toolsets.NewServerTool(RemoveAssignees(getClient, t)),
+			toolsets.NewServerTool(CreateReaction(getClient, t)),
+			toolsets.NewServerTool(CreateLabel(getClient, t)),
+			toolsets.NewServerTool(UpdateLabel(getClient, t)),
+			toolsets.NewServerTool(DeleteLabel(getClient, t)),
+			toolsets.NewServerTool(AddSubIssue(getClient, t)),
+			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
 		)
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
 			toolsets.NewServerTool(SearchUsers(getClient, t)),
+			toolsets.NewServerTool(GetUser(getClient, t)),
+			toolsets.NewServerTool(GetOrg(getClient, t)),
+			toolsets.NewServerTool(ListTeamMembers(getClient, t)),
+			toolsets.NewServerTool(ListOrgMembers(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddTeamMember(getClient, t)),
+			toolsets.NewServerTool(RemoveTeamMember(getClient, t)),
+			toolsets.NewServerTool(InviteOrgMember(getClient, t)),
+			toolsets.NewServerTool(RemoveOrgMember(getClient, t)),
 		)
 	pullRequests := toolsets.NewToolset("pull_requests", "GitHub Pull Request related tools").
 		AddReadTools(
@@ -72,6 +126,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(CreatePullRequest(getClient, t)),
 			toolsets.NewServerTool(UpdatePullRequest(getClient, t)),
 			toolsets.NewServerTool(RequestCopilotReview(getClient, t)),
+			toolsets.NewServerTool(RequestReviewers(getClient, t)),
+			toolsets.NewServerTool(RemoveRequestedReviewers(getClient, t)),
+			toolsets.NewServerTool(ReplyToReviewComment(getClient, t)),
 
 			// Reviews
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
@@ -80,10 +137,20 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
 		)
-	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
+	security := toolsets.NewToolset("security", "Code security and supply chain tools, such as GitHub Code Scanning, Dependabot alerts and the dependency graph").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListDependabotAlerts(getClient, t)),
+			toolsets.NewServerTool(GetSBOM(getClient, t)),
+			toolsets.NewServerTool(ListDependencies(getClient, t)),
+			toolsets.NewServerTool(GetAutomatedSecurityFixes(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(UpdateCodeScanningAlert(getClient, t)),
+			toolsets.NewServerTool(DismissDependabotAlert(getClient, t)),
+			toolsets.NewServerTool(EnableAutomatedSecurityFixes(getClient, t)),
+			toolsets.NewServerTool(DisableAutomatedSecurityFixes(getClient, t)),
 		)
 	secretProtection := toolsets.NewToolset("secret_protection", "Secret protection related tools, such as GitHub Secret Scanning").
 		AddReadTools(
@@ -103,6 +170,65 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(ManageRepositoryNotificationSubscription(getClient, t)),
 		)
 
+	gists := toolsets.NewToolset("gists", "GitHub Gist related tools").
+		AddReadTools(
+			toolsets.NewServerTool(GetGist(getClient, t)),
+			toolsets.NewServerTool(ListGists(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateGist(getClient, t)),
+		)
+
+	projects := toolsets.NewToolset("projects", "GitHub Projects (v2) related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListProjectItems(getGQLClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddItemToProject(getGQLClient, t)),
+		)
+
+	discussions := toolsets.NewToolset("discussions", "GitHub Discussions related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListDiscussionComments(getGQLClient, t)),
+		)
+
+	actions := toolsets.NewToolset("actions", "GitHub Actions workflow runs and jobs, variables and organization secrets, artifacts, deployment environments and check suites").
+		AddReadTools(
+			toolsets.NewServerTool(ListWorkflowRuns(getClient, t)),
+			toolsets.NewServerTool(ListWorkflowJobs(getClient, t)),
+			toolsets.NewServerTool(ListRepoVariables(getClient, t)),
+			toolsets.NewServerTool(GetRepoVariable(getClient, t)),
+			toolsets.NewServerTool(ListArtifacts(getClient, t)),
+			toolsets.NewServerTool(DownloadArtifact(getClient, t)),
+			toolsets.NewServerTool(ListEnvironments(getClient, t)),
+			toolsets.NewServerTool(GetEnvironment(getClient, t)),
+			toolsets.NewServerTool(ListCheckSuites(getClient, t)),
+			toolsets.NewServerTool(GetOrgActionsPermissions(getClient, t)),
+			toolsets.NewServerTool(ListOrgSecrets(getClient, t)),
+			toolsets.NewServerTool(ListOrgVariables(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CancelWorkflowRun(getClient, t)),
+			toolsets.NewServerTool(RerunFailedJobs(getClient, t)),
+			toolsets.NewServerTool(SetRepoVariable(getClient, t)),
+			toolsets.NewServerTool(DeleteRepoVariable(getClient, t)),
+			toolsets.NewServerTool(CreateOrUpdateEnvironment(getClient, t)),
+			toolsets.NewServerTool(RerequestCheckSuite(getClient, t)),
+			toolsets.NewServerTool(SetOrgActionsPermissions(getClient, t)),
+			toolsets.NewServerTool(SetOrgSecret(getClient, t)),
+			toolsets.NewServerTool(SetOrgVariable(getClient, t)),
+		)
+
+	codespaces := toolsets.NewToolset("codespaces", "GitHub Codespaces related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListCodespaces(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateCodespace(getClient, t)),
+			toolsets.NewServerTool(StopCodespace(getClient, t)),
+			toolsets.NewServerTool(DeleteCodespace(getClient, t)),
+		)
+
 	// Keep experiments alive so the system doesn't error out when it's always enabled
 	experiments := toolsets.NewToolset("experiments", "Experimental features that are not considered stable yet")
 
@@ -111,11 +237,22 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(issues)
 	tsg.AddToolset(users)
 	tsg.AddToolset(pullRequests)
-	tsg.AddToolset(codeSecurity)
 	tsg.AddToolset(secretProtection)
+	tsg.AddToolset(security)
+	// The code scanning tools moved from code_security to security. The former name only enables the tools
+	// code_security held, not the supply chain tools or update_code_scanning_alert.
+	tsg.AddAlias("code_security", "security", "get_code_scanning_alert", "list_code_scanning_alerts")
 	tsg.AddToolset(notifications)
+	tsg.AddToolset(gists)
+	tsg.AddToolset(projects)
+	tsg.AddToolset(discussions)
+	tsg.AddToolset(actions)
+	tsg.AddToolset(codespaces)
 	tsg.AddToolset(experiments)
 
+	// Every tool can trim its result down to the fields the caller asks for, see FieldsMiddleware
+	tsg.ApplyToolOptions(WithFields())
+
 	return tsg
 }
 
@@ -125,6 +262,7 @@ func InitContextToolset(getClient GetClientFn, t translations.TranslationHelperF
 		AddReadTools(
 			toolsets.NewServerTool(GetMe(getClient, t)),
 		)
+	contextTools.ApplyToolOptions(WithFields())
 	contextTools.Enabled = true
 	return contextTools
 }
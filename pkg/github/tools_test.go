@@ -0,0 +1,25 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DefaultToolsetGroup_CodeSecurityAlias(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+	require.NoError(t, tsg.EnableToolsets([]string{"code_security"}))
+
+	var names []string
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetActiveTools() {
+			names = append(names, tool.Tool.Name)
+		}
+	}
+	// The former name must not grant the tools security gained when code scanning moved there
+	assert.ElementsMatch(t, []string{"get_code_scanning_alert", "list_code_scanning_alerts"}, names)
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// truncatedBodyFields are the fields of GitHub objects holding free text written by users, such as the body of
+// an issue, pull request or comment, which can be large enough to flood the context of the caller
+var truncatedBodyFields = []string{"body", "body_text", "body_html"}
+
+// BodyTruncationMiddleware returns a tool handler middleware cutting the large text fields of JSON results, such
+// as issue and comment bodies, down to maxChars characters followed by an ellipsis. Each object with a truncated
+// field gets a <field>_truncated field with the original length, so the caller knows the text is incomplete.
+// Tool errors and text content that is not JSON are left untouched.
+func BodyTruncationMiddleware(maxChars int) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError {
+				return result, err
+			}
+
+			for i, content := range result.Content {
+				textContent, ok := content.(mcp.TextContent)
+				if !ok {
+					continue
+				}
+				textContent.Text = truncateJSONBodies(textContent.Text, maxChars)
+				result.Content[i] = textContent
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// truncateJSONBodies truncates the body fields of every object in s if it holds a JSON document, otherwise s is
+// returned unchanged. s is only re-encoded when a field was truncated.
+func truncateJSONBodies(s string, maxChars int) string {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(s)))
+	// Keep numbers as they are, such as IDs too large for a float64
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return s
+	}
+
+	if !truncateBodies(value, maxChars) {
+		return s
+	}
+
+	r, err := json.Marshal(value)
+	if err != nil {
+		return s
+	}
+	return string(r)
+}
+
+// truncateBodies truncates the body fields of every object within value, reporting whether any was truncated
+func truncateBodies(value any, maxChars int) bool {
+	truncated := false
+	switch v := value.(type) {
+	case map[string]any:
+		for _, field := range truncatedBodyFields {
+			text, ok := v[field].(string)
+			if !ok {
+				continue
+			}
+			length := utf8.RuneCountInString(text)
+			if length <= maxChars {
+				continue
+			}
+			v[field] = string([]rune(text)[:maxChars]) + "…"
+			v[field+"_truncated"] = map[string]any{"original_length": length}
+			truncated = true
+		}
+		for _, child := range v {
+			if truncateBodies(child, maxChars) {
+				truncated = true
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if truncateBodies(child, maxChars) {
+				truncated = true
+			}
+		}
+	}
+	return truncated
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BodyTruncationMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		result       *mcp.CallToolResult
+		expectedText string
+	}{
+		{
+			name:         "long body is truncated",
+			result:       mcp.NewToolResultText(`{"number":1,"body":"0123456789abc"}`),
+			expectedText: `{"body":"0123456789…","body_truncated":{"original_length":13},"number":1}`,
+		},
+		{
+			name:         "short result is left untouched",
+			result:       mcp.NewToolResultText(`{"number": 1, "body": "short"}`),
+			expectedText: `{"number": 1, "body": "short"}`,
+		},
+		{
+			name:         "bodies of list items and nested objects",
+			result:       mcp.NewToolResultText(`{"items":[{"id":12345678901234567890,"body_text":"ééééééééééééé"},{"pull_request":{"body":"0123456789abc"}}]}`),
+			expectedText: `{"items":[{"body_text":"éééééééééé…","body_text_truncated":{"original_length":13},"id":12345678901234567890},{"pull_request":{"body":"0123456789…","body_truncated":{"original_length":13}}}]}`,
+		},
+		{
+			name:         "other long fields are left untouched",
+			result:       mcp.NewToolResultText(`{"title":"0123456789abc"}`),
+			expectedText: `{"title":"0123456789abc"}`,
+		},
+		{
+			name:         "non JSON text is left untouched",
+			result:       mcp.NewToolResultText("0123456789abcdef"),
+			expectedText: "0123456789abcdef",
+		},
+		{
+			name:         "tool errors are left untouched",
+			result:       mcp.NewToolResultError(`{"body":"0123456789abc"}`),
+			expectedText: `{"body":"0123456789abc"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return tc.result, nil
+			}
+
+			result, err := BodyTruncationMiddleware(10)(handler)(context.Background(), mcp.CallToolRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
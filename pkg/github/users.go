@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// UserProfile is the public profile of a GitHub user. Fields the token cannot see are left out.
+type UserProfile struct {
+	Login       string     `json:"login"`
+	ID          int64      `json:"id"`
+	Type        string     `json:"type,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Company     string     `json:"company,omitempty"`
+	Blog        string     `json:"blog,omitempty"`
+	Location    string     `json:"location,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	Bio         string     `json:"bio,omitempty"`
+	TwitterName string     `json:"twitter_username,omitempty"`
+	ProfileURL  string     `json:"profile_url,omitempty"`
+	AvatarURL   string     `json:"avatar_url,omitempty"`
+	Followers   int        `json:"followers"`
+	Following   int        `json:"following"`
+	PublicRepos int        `json:"public_repos"`
+	PublicGists int        `json:"public_gists"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// OrgPlan is the plan of an organization, only visible to its owners.
+type OrgPlan struct {
+	Name         string `json:"name"`
+	Seats        int    `json:"seats,omitempty"`
+	FilledSeats  int    `json:"filled_seats,omitempty"`
+	PrivateRepos int64  `json:"private_repos,omitempty"`
+}
+
+// OrgProfile is the profile of a GitHub organization. Fields the token cannot see are left out.
+type OrgProfile struct {
+	Login             string     `json:"login"`
+	ID                int64      `json:"id"`
+	Name              string     `json:"name,omitempty"`
+	Description       string     `json:"description,omitempty"`
+	Company           string     `json:"company,omitempty"`
+	Blog              string     `json:"blog,omitempty"`
+	Location          string     `json:"location,omitempty"`
+	Email             string     `json:"email,omitempty"`
+	TwitterName       string     `json:"twitter_username,omitempty"`
+	ProfileURL        string     `json:"profile_url,omitempty"`
+	AvatarURL         string     `json:"avatar_url,omitempty"`
+	IsVerified        bool       `json:"is_verified"`
+	Followers         int        `json:"followers"`
+	PublicRepos       int        `json:"public_repos"`
+	PublicGists       int        `json:"public_gists"`
+	TotalPrivateRepos *int64     `json:"total_private_repos,omitempty"`
+	OwnedPrivateRepos *int64     `json:"owned_private_repos,omitempty"`
+	Plan              *OrgPlan   `json:"plan,omitempty"`
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+}
+
+func newUserProfile(user *github.User) UserProfile {
+	profile := UserProfile{
+		Login:       user.GetLogin(),
+		ID:          user.GetID(),
+		Type:        user.GetType(),
+		Name:        user.GetName(),
+		Company:     user.GetCompany(),
+		Blog:        user.GetBlog(),
+		Location:    user.GetLocation(),
+		Email:       user.GetEmail(),
+		Bio:         user.GetBio(),
+		TwitterName: user.GetTwitterUsername(),
+		ProfileURL:  user.GetHTMLURL(),
+		AvatarURL:   user.GetAvatarURL(),
+		Followers:   user.GetFollowers(),
+		Following:   user.GetFollowing(),
+		PublicRepos: user.GetPublicRepos(),
+		PublicGists: user.GetPublicGists(),
+	}
+	if user.CreatedAt != nil {
+		profile.CreatedAt = &user.CreatedAt.Time
+	}
+	return profile
+}
+
+func newOrgProfile(org *github.Organization) OrgProfile {
+	profile := OrgProfile{
+		Login:             org.GetLogin(),
+		ID:                org.GetID(),
+		Name:              org.GetName(),
+		Description:       org.GetDescription(),
+		Company:           org.GetCompany(),
+		Blog:              org.GetBlog(),
+		Location:          org.GetLocation(),
+		Email:             org.GetEmail(),
+		TwitterName:       org.GetTwitterUsername(),
+		ProfileURL:        org.GetHTMLURL(),
+		AvatarURL:         org.GetAvatarURL(),
+		IsVerified:        org.GetIsVerified(),
+		Followers:         org.GetFollowers(),
+		PublicRepos:       org.GetPublicRepos(),
+		PublicGists:       org.GetPublicGists(),
+		TotalPrivateRepos: org.TotalPrivateRepos,
+		OwnedPrivateRepos: org.OwnedPrivateRepos,
+	}
+	if org.Plan != nil {
+		profile.Plan = &OrgPlan{
+			Name:         org.Plan.GetName(),
+			Seats:        org.Plan.GetSeats(),
+			FilledSeats:  org.Plan.GetFilledSeats(),
+			PrivateRepos: org.Plan.GetPrivateRepos(),
+		}
+	}
+	if org.CreatedAt != nil {
+		profile.CreatedAt = &org.CreatedAt.Time
+	}
+	return profile
+}
+
+// GetUser creates a tool to get the public profile of a GitHub user.
+func GetUser(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_user",
+			mcp.WithDescription(t("TOOL_GET_USER_DESCRIPTION", "Get the public profile of a GitHub user, including follower and repository counts")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_USER_USER_TITLE", "Get user profile"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("GitHub username"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			user, resp, err := client.Users.Get(ctx, username)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get user: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get user: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(newUserProfile(user))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
+
+// GetOrg creates a tool to get the profile of a GitHub organization.
+func GetOrg(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_org",
+			mcp.WithDescription(t("TOOL_GET_ORG_DESCRIPTION", "Get the profile of a GitHub organization, including follower and repository counts. The plan and private repository counts are only included when the token can see them.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ORG_USER_TITLE", "Get organization profile"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("Organization login"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgName, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			org, resp, err := client.Organizations.Get(ctx, orgName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get organization: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to read response body: %w", err)
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("failed to get organization: %s", string(body))), nil
+			}
+
+			r, err := json.Marshal(newOrgProfile(org))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return mcp.NewToolResultText(string(r)), nil
+		}
+}
@@ -0,0 +1,257 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetUser(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetUser(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_user", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"username"})
+
+	mockUser := &github.User{
+		Login:       github.Ptr("octocat"),
+		ID:          github.Ptr(int64(583231)),
+		Name:        github.Ptr("The Octocat"),
+		Company:     github.Ptr("@github"),
+		HTMLURL:     github.Ptr("https://github.com/octocat"),
+		Followers:   github.Ptr(4000),
+		Following:   github.Ptr(9),
+		PublicRepos: github.Ptr(8),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedProfile UserProfile
+		expectedErrMsg  string
+	}{
+		{
+			name: "successful user fetch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					expectPath(t, "/users/octocat").andThen(
+						mockResponse(t, http.StatusOK, mockUser),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+			expectError: false,
+			expectedProfile: UserProfile{
+				Login:       "octocat",
+				ID:          583231,
+				Name:        "The Octocat",
+				Company:     "@github",
+				ProfileURL:  "https://github.com/octocat",
+				Followers:   4000,
+				Following:   9,
+				PublicRepos: 8,
+			},
+		},
+		{
+			name: "user fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get user",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetUser(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedProfile UserProfile
+			err = json.Unmarshal([]byte(textContent.Text), &returnedProfile)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProfile, returnedProfile)
+		})
+	}
+}
+
+func Test_GetOrg(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetOrg(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_org", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	publicOrg := &github.Organization{
+		Login:       github.Ptr("github"),
+		ID:          github.Ptr(int64(9919)),
+		Name:        github.Ptr("GitHub"),
+		Followers:   github.Ptr(30000),
+		PublicRepos: github.Ptr(500),
+	}
+	memberOrg := &github.Organization{
+		Login:             github.Ptr("github"),
+		ID:                github.Ptr(int64(9919)),
+		Name:              github.Ptr("GitHub"),
+		Followers:         github.Ptr(30000),
+		PublicRepos:       github.Ptr(500),
+		TotalPrivateRepos: github.Ptr(int64(1200)),
+		OwnedPrivateRepos: github.Ptr(int64(1100)),
+		Plan: &github.Plan{
+			Name:        github.Ptr("enterprise"),
+			Seats:       github.Ptr(5000),
+			FilledSeats: github.Ptr(4500),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedProfile OrgProfile
+		expectedErrMsg  string
+	}{
+		{
+			name: "public organization fields only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsByOrg,
+					expectPath(t, "/orgs/github").andThen(
+						mockResponse(t, http.StatusOK, publicOrg),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "github",
+			},
+			expectError: false,
+			expectedProfile: OrgProfile{
+				Login:       "github",
+				ID:          9919,
+				Name:        "GitHub",
+				Followers:   30000,
+				PublicRepos: 500,
+			},
+		},
+		{
+			name: "plan and private counts visible to members",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetOrgsByOrg,
+					memberOrg,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "github",
+			},
+			expectError: false,
+			expectedProfile: OrgProfile{
+				Login:             "github",
+				ID:                9919,
+				Name:              "GitHub",
+				Followers:         30000,
+				PublicRepos:       500,
+				TotalPrivateRepos: github.Ptr(int64(1200)),
+				OwnedPrivateRepos: github.Ptr(int64(1100)),
+				Plan: &OrgPlan{
+					Name:        "enterprise",
+					Seats:       5000,
+					FilledSeats: 4500,
+				},
+			},
+		},
+		{
+			name: "organization fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get organization",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetOrg(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedProfile OrgProfile
+			err = json.Unmarshal([]byte(textContent.Text), &returnedProfile)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedProfile, returnedProfile)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// wikiPageFormats maps the file extensions GitHub renders as wiki pages to their markup language
+var wikiPageFormats = map[string]string{
+	".md":        "markdown",
+	".markdown":  "markdown",
+	".mdown":     "markdown",
+	".mkdn":      "markdown",
+	".mkd":       "markdown",
+	".textile":   "textile",
+	".rdoc":      "rdoc",
+	".org":       "org",
+	".creole":    "creole",
+	".mediawiki": "mediawiki",
+	".wiki":      "mediawiki",
+	".rst":       "rst",
+	".asciidoc":  "asciidoc",
+	".adoc":      "asciidoc",
+	".asc":       "asciidoc",
+	".pod":       "pod",
+}
+
+// MinimalWikiPage is a page of a repository wiki. Name is the title GitHub shows, the file name with dashes
+// as spaces.
+type MinimalWikiPage struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Format string `json:"format"`
+}
+
+// WikiPage is a page of a repository wiki with its source.
+type WikiPage struct {
+	MinimalWikiPage
+	Content string `json:"content"`
+}
+
+// wikiPages returns the pages among the files of a wiki sorted by path, other files such as images are skipped
+func wikiPages(files map[string][]byte) []WikiPage {
+	pages := make([]WikiPage, 0, len(files))
+	for filePath, content := range files {
+		ext := path.Ext(filePath)
+		format, ok := wikiPageFormats[strings.ToLower(ext)]
+		if !ok {
+			continue
+		}
+		pages = append(pages, WikiPage{
+			MinimalWikiPage: MinimalWikiPage{
+				Name:   strings.ReplaceAll(strings.TrimSuffix(path.Base(filePath), ext), "-", " "),
+				Path:   filePath,
+				Format: format,
+			},
+			Content: string(content),
+		})
+	}
+	sort.Slice(pages, func(i, j int) bool { return pages[i].Path < pages[j].Path })
+	return pages
+}
+
+// normalizeWikiPageName makes the title and the file name of a page compare equal
+func normalizeWikiPageName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "-", " "))
+}
+
+// getWikiPages reads the pages of the wiki of a repository, returning a tool error result when the wiki is
+// disabled. A wiki without pages yet has none.
+func getWikiPages(ctx context.Context, getClient GetClientFn, owner, repo string) ([]WikiPage, *mcp.CallToolResult, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+	if !repository.GetHasWiki() {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("the wiki of %s/%s is disabled, an admin can enable it with update_repo_settings and has_wiki", owner, repo)), nil
+	}
+
+	files, err := fetchWikiFiles(ctx, client.Client(), repository.GetHTMLURL()+".wiki.git")
+	if errors.Is(err, errWikiNotCreated) {
+		return []WikiPage{}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return wikiPages(files), nil, nil
+}
+
+// ListWikiPages creates a tool to list, and optionally search, the pages of a repository wiki.
+func ListWikiPages(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_wiki_pages",
+			mcp.WithDescription(t("TOOL_LIST_WIKI_PAGES_DESCRIPTION", "List the pages of the wiki of a repository, with the name, path and markup format of each. Pass query to only list the pages whose name or content contains it. Read a page with get_wiki_page")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_WIKI_PAGES_USER_TITLE", "List wiki pages"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("query",
+				mcp.Description("Case-insensitive text the name or content of the listed pages must contain"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := OptionalParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			pages, result, err := getWikiPages(ctx, getClient, owner, repo)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			query = strings.ToLower(query)
+			minimalPages := make([]MinimalWikiPage, 0, len(pages))
+			for _, page := range pages {
+				if query != "" && !strings.Contains(strings.ToLower(page.Name), query) && !strings.Contains(strings.ToLower(page.Content), query) {
+					continue
+				}
+				minimalPages = append(minimalPages, page.MinimalWikiPage)
+			}
+
+			return MarshalledTextResult(minimalPages), nil
+		}
+}
+
+// GetWikiPage creates a tool to get a page of a repository wiki.
+func GetWikiPage(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_wiki_page",
+			mcp.WithDescription(t("TOOL_GET_WIKI_PAGE_DESCRIPTION", "Get the source of a page of the wiki of a repository, as markdown or whichever markup format the page is written in")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_WIKI_PAGE_USER_TITLE", "Get wiki page"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("page",
+				mcp.Required(),
+				mcp.Description("Page name as shown in the wiki, such as 'Getting Started', or its path from list_wiki_pages. The wiki home page is 'Home'"),
+			),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "page")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			pages, result, err := getWikiPages(ctx, getClient, owner, repo)
+			if result != nil || err != nil {
+				return result, err
+			}
+
+			for _, page := range pages {
+				if page.Path == name || normalizeWikiPageName(page.Name) == normalizeWikiPageName(name) {
+					return MarshalledTextResult(page), nil
+				}
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("wiki page %q not found in %s/%s, list_wiki_pages lists its pages", name, owner, repo)), nil
+		}
+}
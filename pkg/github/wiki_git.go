@@ -0,0 +1,381 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"crypto/sha1" //nolint:gosec // git object IDs are SHA-1
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GitHub has no API for wikis, they are read from their git repository with the smart HTTP protocol. Only the
+// latest commit is fetched, which is enough to read the current pages.
+
+// maxWikiPackSize bounds the pack downloaded from a wiki repository, wikis are mostly text
+const maxWikiPackSize = 64 << 20
+
+// errWikiNotCreated is returned for a wiki that is enabled but has no pages yet, GitHub creates its repository
+// with the first page
+var errWikiNotCreated = errors.New("the wiki has no pages yet")
+
+// git object types as numbered in pack files
+const (
+	gitObjectCommit   = 1
+	gitObjectTree     = 2
+	gitObjectBlob     = 3
+	gitObjectTag      = 4
+	gitObjectOfsDelta = 6
+	gitObjectRefDelta = 7
+)
+
+var gitObjectTypeNames = map[int]string{
+	gitObjectCommit: "commit",
+	gitObjectTree:   "tree",
+	gitObjectBlob:   "blob",
+	gitObjectTag:    "tag",
+}
+
+type gitObject struct {
+	kind int
+	data []byte
+}
+
+// fetchWikiFiles downloads the files at the head of the wiki repository at gitURL, keyed by path
+func fetchWikiFiles(ctx context.Context, httpClient *http.Client, gitURL string) (map[string][]byte, error) {
+	head, capabilities, err := fetchGitHead(ctx, httpClient, gitURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	writePktLine(&body, "want "+head+" no-progress\n")
+	if capabilities["shallow"] {
+		writePktLine(&body, "deepen 1\n")
+	}
+	body.WriteString("0000")
+	writePktLine(&body, "done\n")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gitURL+"/git-upload-pack", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-git-upload-pack-request")
+	req.Header.Set("Accept", "application/x-git-upload-pack-result")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch wiki: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch wiki: unexpected status %s", resp.Status)
+	}
+
+	r := bufio.NewReader(io.LimitReader(resp.Body, maxWikiPackSize))
+	// The pack follows the shallow lines and the NAK ending the negotiation
+	for {
+		line, flush, err := readPktLine(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read wiki negotiation: %w", err)
+		}
+		if !flush && strings.TrimSpace(line) == "NAK" {
+			break
+		}
+		if message, ok := strings.CutPrefix(line, "ERR "); ok {
+			return nil, fmt.Errorf("failed to fetch wiki: %s", strings.TrimSpace(message))
+		}
+	}
+
+	objects, err := readGitPack(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wiki pack: %w", err)
+	}
+
+	commit, ok := objects[head]
+	if !ok || commit.kind != gitObjectCommit {
+		return nil, fmt.Errorf("failed to read wiki pack: commit %s is missing", head)
+	}
+	treeLine, _, _ := strings.Cut(string(commit.data), "\n")
+	tree, ok := strings.CutPrefix(treeLine, "tree ")
+	if !ok {
+		return nil, fmt.Errorf("failed to read wiki pack: commit %s has no tree", head)
+	}
+
+	files := make(map[string][]byte)
+	if err := collectGitTree(objects, tree, "", files); err != nil {
+		return nil, fmt.Errorf("failed to read wiki pack: %w", err)
+	}
+	return files, nil
+}
+
+// fetchGitHead reads the commit HEAD points to and the capabilities of the server from the ref advertisement
+func fetchGitHead(ctx context.Context, httpClient *http.Client, gitURL string) (string, map[string]bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gitURL+"/info/refs?service=git-upload-pack", nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch wiki refs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", nil, errWikiNotCreated
+	default:
+		return "", nil, fmt.Errorf("failed to fetch wiki refs: unexpected status %s", resp.Status)
+	}
+
+	r := bufio.NewReader(resp.Body)
+	var head, master string
+	capabilities := make(map[string]bool)
+	for {
+		line, flush, err := readPktLine(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read wiki refs: %w", err)
+		}
+		if flush || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ref, caps, hasCaps := strings.Cut(strings.TrimSuffix(line, "\n"), "\x00")
+		if hasCaps {
+			for _, capability := range strings.Fields(caps) {
+				capabilities[capability] = true
+			}
+		}
+		sha, name, _ := strings.Cut(ref, " ")
+		switch name {
+		case "HEAD":
+			head = sha
+		case "refs/heads/master":
+			master = sha
+		}
+	}
+
+	if head == "" {
+		head = master
+	}
+	// An empty repository advertises only its capabilities, on a zero ID
+	if head == "" || strings.Trim(head, "0") == "" {
+		return "", nil, errWikiNotCreated
+	}
+	return head, capabilities, nil
+}
+
+func writePktLine(w *bytes.Buffer, line string) {
+	fmt.Fprintf(w, "%04x%s", len(line)+4, line)
+}
+
+// readPktLine reads a pkt-line, flush is set for the 0000 flush packet
+func readPktLine(r io.Reader) (line string, flush bool, err error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return "", false, err
+	}
+	n, err := strconv.ParseUint(string(size[:]), 16, 16)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid pkt-line length %q", size)
+	}
+	if n == 0 {
+		return "", true, nil
+	}
+	if n < 4 {
+		return "", false, fmt.Errorf("invalid pkt-line length %q", size)
+	}
+	data := make([]byte, n-4)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return "", false, err
+	}
+	return string(data), false, nil
+}
+
+// readGitPack reads the objects of a pack, keyed by ID, resolving the deltas against objects of the same pack
+func readGitPack(r *bufio.Reader) (map[string]*gitObject, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	if string(header[:4]) != "PACK" {
+		return nil, errors.New("missing pack signature")
+	}
+	count := binary.BigEndian.Uint32(header[8:])
+
+	objects := make(map[string]*gitObject, count)
+	deltas := make(map[string][][]byte)
+	for i := uint32(0); i < count; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		kind := int(b>>4) & 7
+		for b&0x80 != 0 {
+			// The rest of the size is known from the inflated data
+			if b, err = r.ReadByte(); err != nil {
+				return nil, err
+			}
+		}
+
+		var base [20]byte
+		switch kind {
+		case gitObjectRefDelta:
+			if _, err := io.ReadFull(r, base[:]); err != nil {
+				return nil, err
+			}
+		case gitObjectOfsDelta:
+			return nil, errors.New("unexpected offset delta")
+		}
+
+		zr, err := zlib.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == gitObjectRefDelta {
+			baseID := hex.EncodeToString(base[:])
+			deltas[baseID] = append(deltas[baseID], data)
+			continue
+		}
+		addGitObject(objects, kind, data)
+	}
+
+	// Deltas may come before their base, and be the base of other deltas
+	for len(deltas) > 0 {
+		resolved := false
+		for baseID, pending := range deltas {
+			base, ok := objects[baseID]
+			if !ok {
+				continue
+			}
+			for _, delta := range pending {
+				data, err := applyGitDelta(base.data, delta)
+				if err != nil {
+					return nil, err
+				}
+				addGitObject(objects, base.kind, data)
+			}
+			delete(deltas, baseID)
+			resolved = true
+		}
+		if !resolved {
+			return nil, errors.New("delta against an object missing from the pack")
+		}
+	}
+	return objects, nil
+}
+
+func addGitObject(objects map[string]*gitObject, kind int, data []byte) {
+	h := sha1.New() //nolint:gosec // git object IDs are SHA-1
+	fmt.Fprintf(h, "%s %d\x00", gitObjectTypeNames[kind], len(data))
+	h.Write(data)
+	objects[hex.EncodeToString(h.Sum(nil))] = &gitObject{kind: kind, data: data}
+}
+
+// applyGitDelta rebuilds an object from its base and a delta of copy and insert instructions
+func applyGitDelta(base, delta []byte) ([]byte, error) {
+	r := bytes.NewReader(delta)
+	if _, err := binary.ReadUvarint(r); err != nil {
+		return nil, fmt.Errorf("invalid delta: %w", err)
+	}
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delta: %w", err)
+	}
+
+	out := make([]byte, 0, size)
+	for {
+		cmd, err := r.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if cmd&0x80 == 0 {
+			if cmd == 0 {
+				return nil, errors.New("invalid delta: reserved instruction")
+			}
+			insert := make([]byte, cmd)
+			if _, err := io.ReadFull(r, insert); err != nil {
+				return nil, fmt.Errorf("invalid delta: %w", err)
+			}
+			out = append(out, insert...)
+			continue
+		}
+
+		// The low bits say which bytes of the offset and the size follow
+		var offset, length uint32
+		for i := 0; i < 7; i++ {
+			if cmd&(1<<i) == 0 {
+				continue
+			}
+			b, err := r.ReadByte()
+			if err != nil {
+				return nil, fmt.Errorf("invalid delta: %w", err)
+			}
+			if i < 4 {
+				offset |= uint32(b) << (8 * i)
+			} else {
+				length |= uint32(b) << (8 * (i - 4))
+			}
+		}
+		if length == 0 {
+			length = 0x10000
+		}
+		if uint64(offset)+uint64(length) > uint64(len(base)) {
+			return nil, errors.New("invalid delta: copy out of the base")
+		}
+		out = append(out, base[offset:offset+length]...)
+	}
+
+	if uint64(len(out)) != size {
+		return nil, errors.New("invalid delta: size mismatch")
+	}
+	return out, nil
+}
+
+// collectGitTree adds the files of a tree and its subtrees to files, keyed by path
+func collectGitTree(objects map[string]*gitObject, id, prefix string, files map[string][]byte) error {
+	tree, ok := objects[id]
+	if !ok || tree.kind != gitObjectTree {
+		return fmt.Errorf("tree %s is missing", id)
+	}
+
+	data := tree.data
+	for len(data) > 0 {
+		entry, rest, ok := bytes.Cut(data, []byte{0})
+		if !ok || len(rest) < 20 {
+			return fmt.Errorf("tree %s is malformed", id)
+		}
+		mode, name, _ := strings.Cut(string(entry), " ")
+		childID := hex.EncodeToString(rest[:20])
+		data = rest[20:]
+
+		switch {
+		case mode == "40000":
+			if err := collectGitTree(objects, childID, prefix+name+"/", files); err != nil {
+				return err
+			}
+		case strings.HasPrefix(mode, "100"):
+			blob, ok := objects[childID]
+			if !ok || blob.kind != gitObjectBlob {
+				return fmt.Errorf("blob %s is missing", childID)
+			}
+			files[prefix+name] = blob.data
+		}
+	}
+	return nil
+}
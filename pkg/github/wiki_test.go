@@ -0,0 +1,298 @@
+package github
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"crypto/sha1" //nolint:gosec // git object IDs are SHA-1
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testGitPack builds a git pack out of entries written with add and addDelta
+type testGitPack struct {
+	entries [][]byte
+}
+
+func (p *testGitPack) entry(kind, size int, prefix, data []byte) {
+	var buf bytes.Buffer
+	b := byte(kind<<4) | byte(size&0x0f)
+	size >>= 4
+	for size > 0 {
+		buf.WriteByte(b | 0x80)
+		b = byte(size & 0x7f)
+		size >>= 7
+	}
+	buf.WriteByte(b)
+	buf.Write(prefix)
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	p.entries = append(p.entries, buf.Bytes())
+}
+
+// add adds an object and returns its ID
+func (p *testGitPack) add(kind int, data []byte) []byte {
+	p.entry(kind, len(data), nil, data)
+	h := sha1.New() //nolint:gosec // git object IDs are SHA-1
+	fmt.Fprintf(h, "%s %d\x00", gitObjectTypeNames[kind], len(data))
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// addDelta adds a blob as a delta copying the base blob and appending suffix, and returns the blob ID
+func (p *testGitPack) addDelta(baseID, base []byte, suffix string) []byte {
+	delta := binary.AppendUvarint(nil, uint64(len(base)))
+	delta = binary.AppendUvarint(delta, uint64(len(base)+len(suffix)))
+	delta = append(delta, 0x80|0x10, byte(len(base)), byte(len(suffix)))
+	delta = append(delta, suffix...)
+	p.entry(gitObjectRefDelta, len(delta), baseID, delta)
+
+	data := append(append([]byte{}, base...), suffix...)
+	h := sha1.New() //nolint:gosec // git object IDs are SHA-1
+	fmt.Fprintf(h, "blob %d\x00", len(data))
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func (p *testGitPack) bytes() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("PACK")
+	_ = binary.Write(&buf, binary.BigEndian, uint32(2))
+	_ = binary.Write(&buf, binary.BigEndian, uint32(len(p.entries)))
+	for _, entry := range p.entries {
+		buf.Write(entry)
+	}
+	sum := sha1.Sum(buf.Bytes()) //nolint:gosec // git pack checksums are SHA-1
+	buf.Write(sum[:])
+	return buf.Bytes()
+}
+
+func testGitTree(entries ...string) []byte {
+	var tree []byte
+	for i := 0; i < len(entries); i += 2 {
+		id, _ := hex.DecodeString(entries[i+1])
+		tree = append(append(append(tree, entries[i]...), 0), id...)
+	}
+	return tree
+}
+
+func testPktLine(line string) string {
+	return fmt.Sprintf("%04x%s", len(line)+4, line)
+}
+
+// newWikiServer serves a repository with hasWiki, whose wiki has a home page, a page stored as a delta, a
+// page in a directory and an image. The wiki repository does not exist when created is false.
+func newWikiServer(t *testing.T, hasWiki, created bool) *github.Client {
+	pack := &testGitPack{}
+	home := []byte("# Home\n\nWelcome to the wiki.\n")
+	// The delta comes before its base
+	homeID := func() []byte {
+		h := sha1.New() //nolint:gosec // git object IDs are SHA-1
+		fmt.Fprintf(h, "blob %d\x00", len(home))
+		h.Write(home)
+		return h.Sum(nil)
+	}()
+	gettingStartedID := pack.addDelta(homeID, home, "Run make to get started.\n")
+	pack.add(gitObjectBlob, home)
+	deployID := pack.add(gitObjectBlob, []byte("Deploy with cf push.\n"))
+	logoID := pack.add(gitObjectBlob, []byte{0x89, 'P', 'N', 'G'})
+	docsID := pack.add(gitObjectTree, testGitTree("100644 Deploy.md", hex.EncodeToString(deployID)))
+	rootID := pack.add(gitObjectTree, testGitTree(
+		"100644 Getting-Started.md", hex.EncodeToString(gettingStartedID),
+		"100644 Home.md", hex.EncodeToString(homeID),
+		"40000 docs", hex.EncodeToString(docsID),
+		"100644 logo.png", hex.EncodeToString(logoID),
+	))
+	commitID := hex.EncodeToString(pack.add(gitObjectCommit, []byte("tree "+hex.EncodeToString(rootID)+"\nauthor a <a@example.com> 0 +0000\n\nUpdate wiki\n")))
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("GET /repos/owner/repo", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(&github.Repository{
+			HasWiki: github.Ptr(hasWiki),
+			HTMLURL: github.Ptr(srv.URL + "/owner/repo"),
+		})
+	})
+	mux.HandleFunc("GET /owner/repo.wiki.git/info/refs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "service=git-upload-pack", r.URL.RawQuery)
+		if !created {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, testPktLine("# service=git-upload-pack\n")+"0000"+
+			testPktLine(commitID+" HEAD\x00multi_ack shallow no-progress\n")+
+			testPktLine(commitID+" refs/heads/master\n")+"0000")
+	})
+	mux.HandleFunc("POST /owner/repo.wiki.git/git-upload-pack", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, testPktLine("want "+commitID+" no-progress\n")+testPktLine("deepen 1\n")+"0000"+testPktLine("done\n"), string(body))
+
+		_, _ = io.WriteString(w, testPktLine("shallow "+commitID+"\n")+"0000"+testPktLine("NAK\n"))
+		_, _ = w.Write(pack.bytes())
+	})
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return client
+}
+
+func Test_ListWikiPages(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListWikiPages(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_wiki_pages", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	tests := []struct {
+		name            string
+		hasWiki         bool
+		created         bool
+		query           string
+		expectToolError bool
+		expectedErrMsg  string
+		expected        []MinimalWikiPage
+	}{
+		{
+			name:    "all pages",
+			hasWiki: true,
+			created: true,
+			expected: []MinimalWikiPage{
+				{Name: "Getting Started", Path: "Getting-Started.md", Format: "markdown"},
+				{Name: "Home", Path: "Home.md", Format: "markdown"},
+				{Name: "Deploy", Path: "docs/Deploy.md", Format: "markdown"},
+			},
+		},
+		{
+			name:    "pages matching a query",
+			hasWiki: true,
+			created: true,
+			query:   "CF PUSH",
+			expected: []MinimalWikiPage{
+				{Name: "Deploy", Path: "docs/Deploy.md", Format: "markdown"},
+			},
+		},
+		{
+			name:     "wiki without pages",
+			hasWiki:  true,
+			expected: []MinimalWikiPage{},
+		},
+		{
+			name:            "wiki disabled",
+			expectToolError: true,
+			expectedErrMsg:  "the wiki of owner/repo is disabled",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := ListWikiPages(stubGetClientFn(newWikiServer(t, tc.hasWiki, tc.created)), translations.NullTranslationHelper)
+
+			args := map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			}
+			if tc.query != "" {
+				args["query"] = tc.query
+			}
+			result, err := handler(context.Background(), createMCPRequest(args))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var pages []MinimalWikiPage
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pages))
+			assert.Equal(t, tc.expected, pages)
+		})
+	}
+}
+
+func Test_GetWikiPage(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetWikiPage(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_wiki_page", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "page"})
+
+	tests := []struct {
+		name            string
+		page            string
+		expectToolError bool
+		expectedErrMsg  string
+		expected        WikiPage
+	}{
+		{
+			name: "page by name",
+			page: "getting started",
+			expected: WikiPage{
+				MinimalWikiPage: MinimalWikiPage{Name: "Getting Started", Path: "Getting-Started.md", Format: "markdown"},
+				Content:         "# Home\n\nWelcome to the wiki.\nRun make to get started.\n",
+			},
+		},
+		{
+			name: "page by path",
+			page: "docs/Deploy.md",
+			expected: WikiPage{
+				MinimalWikiPage: MinimalWikiPage{Name: "Deploy", Path: "docs/Deploy.md", Format: "markdown"},
+				Content:         "Deploy with cf push.\n",
+			},
+		},
+		{
+			name:            "unknown page",
+			page:            "Roadmap",
+			expectToolError: true,
+			expectedErrMsg:  `wiki page "Roadmap" not found in owner/repo`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, handler := GetWikiPage(stubGetClientFn(newWikiServer(t, true, true)), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"page":  tc.page,
+			}))
+			require.NoError(t, err)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var page WikiPage
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &page))
+			assert.Equal(t, tc.expected, page)
+		})
+	}
+}
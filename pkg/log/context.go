@@ -0,0 +1,24 @@
+package log
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type loggerContextKey struct{}
+
+// WithLogger returns a context carrying a request scoped log entry, so that every log line written
+// while handling the request includes the same fields.
+func WithLogger(ctx context.Context, entry *log.Entry) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, entry)
+}
+
+// FromContext returns the request scoped log entry stored in the context, or an entry of the
+// standard logger when there is none.
+func FromContext(ctx context.Context) *log.Entry {
+	if entry, ok := ctx.Value(loggerContextKey{}).(*log.Entry); ok {
+		return entry
+	}
+	return log.NewEntry(log.StandardLogger())
+}
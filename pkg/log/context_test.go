@@ -0,0 +1,33 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("returns the standard logger when none is set", func(t *testing.T) {
+		entry := FromContext(context.Background())
+		assert.Equal(t, log.StandardLogger(), entry.Logger)
+		assert.Empty(t, entry.Data)
+	})
+
+	t.Run("returns the request scoped entry", func(t *testing.T) {
+		var logBuffer bytes.Buffer
+		logger := log.New()
+		logger.SetOutput(&logBuffer)
+		logger.SetFormatter(&log.TextFormatter{
+			DisableTimestamp: true,
+		})
+
+		ctx := WithLogger(context.Background(), logger.WithField("x_tenant_id", "tenant-1"))
+		FromContext(ctx).Info("handled request")
+
+		assert.Contains(t, logBuffer.String(), "x_tenant_id=tenant-1")
+		assert.Contains(t, logBuffer.String(), "handled request")
+	})
+}
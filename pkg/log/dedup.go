@@ -0,0 +1,149 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RepeatedField is the field of the log entry summing up a run of identical entries, holding how many were
+// left out
+const RepeatedField = "repeated"
+
+type dedupSummaryContextKey struct{}
+
+// Deduplicate collapses runs of identical consecutive log entries of logger, such as the same warning logged
+// over and over during a retry storm. The first entry of a run is written as usual, and the identical entries
+// following it within window are left out. Once the window ends, or a different entry is logged, a single
+// copy of the entry is written with a repeated field counting the entries left out. Entries are identical
+// when their level, message and fields are.
+func Deduplicate(logger *log.Logger, window time.Duration) {
+	logger.SetFormatter(&dedupFormatter{
+		formatter: logger.Formatter,
+		logger:    logger,
+		window:    window,
+	})
+}
+
+type dedupFormatter struct {
+	formatter log.Formatter
+	logger    *log.Logger
+	window    time.Duration
+
+	mu  sync.Mutex
+	run *dedupRun
+}
+
+// dedupRun is an entry that was written, and the identical entries that followed it
+type dedupRun struct {
+	key     string
+	start   time.Time
+	level   log.Level
+	message string
+	data    log.Fields
+	// repeated is how many identical entries were left out
+	repeated int
+	// last is when the last of them was logged
+	last time.Time
+}
+
+func (f *dedupFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if entry.Context != nil && entry.Context.Value(dedupSummaryContextKey{}) != nil {
+		return f.formatter.Format(entry)
+	}
+
+	key := dedupKey(entry)
+
+	f.mu.Lock()
+	run := f.run
+	if run != nil && run.key == key && entry.Time.Sub(run.start) < f.window {
+		run.repeated++
+		run.last = entry.Time
+		if run.repeated == 1 {
+			// Sum up the run once its window ends, should nothing else be logged before then
+			time.AfterFunc(f.window-entry.Time.Sub(run.start), func() { f.flush(run) })
+		}
+		f.mu.Unlock()
+		return nil, nil
+	}
+	f.run = &dedupRun{
+		key:     key,
+		start:   entry.Time,
+		level:   entry.Level,
+		message: entry.Message,
+		data:    copyFields(entry.Data),
+	}
+	f.mu.Unlock()
+
+	var serialized []byte
+	if run != nil && run.repeated > 0 {
+		summary, err := f.formatter.Format(run.summary(entry.Logger))
+		if err != nil {
+			return nil, err
+		}
+		serialized = summary
+	}
+	formatted, err := f.formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+	return append(serialized, formatted...), nil
+}
+
+// flush writes the summary of run when its window ends, unless a different entry already did
+func (f *dedupFormatter) flush(run *dedupRun) {
+	f.mu.Lock()
+	if f.run != run {
+		f.mu.Unlock()
+		return
+	}
+	f.run = nil
+	f.mu.Unlock()
+
+	// Marked so that Format writes it as is, it is logged rather than written to the output directly to hold
+	// the lock of the logger
+	ctx := context.WithValue(context.Background(), dedupSummaryContextKey{}, true)
+	f.logger.WithContext(ctx).WithTime(run.last).WithFields(run.data).WithField(RepeatedField, run.repeated).Log(run.level, run.message)
+}
+
+// summary returns the entry summing up the run
+func (r *dedupRun) summary(logger *log.Logger) *log.Entry {
+	data := copyFields(r.data)
+	data[RepeatedField] = r.repeated
+	return &log.Entry{
+		Logger:  logger,
+		Data:    data,
+		Time:    r.last,
+		Level:   r.level,
+		Message: r.message,
+	}
+}
+
+// dedupKey identifies the entries that are identical to entry
+func dedupKey(entry *log.Entry) string {
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\x00%s", entry.Level, entry.Message)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\x00%s=%v", key, entry.Data[key])
+	}
+	return b.String()
+}
+
+func copyFields(fields log.Fields) log.Fields {
+	copied := make(log.Fields, len(fields)+1)
+	for key, value := range fields {
+		copied[key] = value
+	}
+	return copied
+}
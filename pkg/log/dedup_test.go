@@ -0,0 +1,77 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer that the timer summing up a run may write to while the test reads it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Split(strings.TrimSpace(b.buf.String()), "\n")
+}
+
+func newDedupLogger(window time.Duration) (*log.Logger, *syncBuffer) {
+	var out syncBuffer
+	logger := log.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: true,
+	})
+	Deduplicate(logger, window)
+	return logger, &out
+}
+
+func TestDeduplicate(t *testing.T) {
+	t.Run("identical entries are summed up when a different one is logged", func(t *testing.T) {
+		logger, out := newDedupLogger(time.Hour)
+
+		for range 3 {
+			logger.WithField("host", "api.github.com").Warn("retrying request")
+		}
+		logger.WithField("host", "uploads.github.com").Warn("retrying request")
+		logger.Info("request succeeded")
+
+		assert.Equal(t, []string{
+			`level=warning msg="retrying request" host=api.github.com`,
+			`level=warning msg="retrying request" host=api.github.com repeated=2`,
+			`level=warning msg="retrying request" host=uploads.github.com`,
+			`level=info msg="request succeeded"`,
+		}, out.lines())
+	})
+
+	t.Run("a run is summed up when its window ends", func(t *testing.T) {
+		logger, out := newDedupLogger(20 * time.Millisecond)
+
+		for range 4 {
+			logger.Warn("retrying request")
+		}
+
+		assert.Eventually(t, func() bool {
+			return len(out.lines()) == 2
+		}, time.Second, 5*time.Millisecond)
+		assert.Equal(t, `level=warning msg="retrying request" repeated=3`, out.lines()[1])
+
+		// The next identical entry starts a new run
+		logger.Warn("retrying request")
+		assert.Len(t, out.lines(), 3)
+	})
+}
@@ -0,0 +1,333 @@
+package toolsets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrorCodeArgumentNotAllowed is returned for tool calls whose arguments do not match the
+// argument patterns of the tool policy.
+const ErrorCodeArgumentNotAllowed = "argument_not_allowed"
+
+// ToolPolicy overrides the toolset defaults for individual tools, so that the tools on offer can be
+// governed from one file per environment.
+type ToolPolicy struct {
+	// EnableTools are registered even when their toolset is not enabled
+	EnableTools []string `yaml:"enable_tools"`
+	// DisableTools are never registered, whatever toolsets are enabled
+	DisableTools []string `yaml:"disable_tools"`
+	// ReadOnlyToolsets only offer their read-only tools
+	ReadOnlyToolsets []string `yaml:"read_only_toolsets"`
+	// ArgumentPatterns maps tool names to the regular expressions their arguments must match in full
+	ArgumentPatterns map[string]map[string]string `yaml:"argument_patterns"`
+	// ArgumentDefaults maps tool names to the values of the arguments a call leaves out
+	ArgumentDefaults map[string]map[string]any `yaml:"argument_defaults"`
+	// MaxArgumentChars maps tool names to the length in characters their string arguments may not exceed,
+	// overriding the server-wide limit. 0 lifts the limit for the tool.
+	MaxArgumentChars map[string]int `yaml:"max_argument_chars"`
+}
+
+// ParseToolPolicy reads a YAML tool policy. Unknown keys are rejected so that typos do not go unnoticed.
+func ParseToolPolicy(r io.Reader) (*ToolPolicy, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var policy ToolPolicy
+	if err := decoder.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for _, toolName := range slices.Sorted(maps.Keys(policy.ArgumentPatterns)) {
+		if _, err := compileArgumentPatterns(policy.ArgumentPatterns[toolName]); err != nil {
+			return nil, fmt.Errorf("argument_patterns: %s: %w", toolName, err)
+		}
+	}
+	for _, toolName := range slices.Sorted(maps.Keys(policy.ArgumentDefaults)) {
+		defaults, err := normalizeArgumentDefaults(policy.ArgumentDefaults[toolName])
+		if err != nil {
+			return nil, fmt.Errorf("argument_defaults: %s: %w", toolName, err)
+		}
+		policy.ArgumentDefaults[toolName] = defaults
+	}
+	for _, toolName := range slices.Sorted(maps.Keys(policy.MaxArgumentChars)) {
+		if policy.MaxArgumentChars[toolName] < 0 {
+			return nil, fmt.Errorf("max_argument_chars: %s: must not be negative", toolName)
+		}
+	}
+	return &policy, nil
+}
+
+// ApplyToolPolicy applies policy to the toolsets of the group. It must be called before the tools are
+// registered. Entries naming tools or toolsets that do not exist are skipped and reported as warnings.
+func (tg *ToolsetGroup) ApplyToolPolicy(policy *ToolPolicy) []string {
+	if policy == nil {
+		return nil
+	}
+
+	var warnings []string
+	for _, name := range policy.ReadOnlyToolsets {
+		toolset, exists := tg.Toolsets[tg.resolve(name)]
+		if !exists {
+			warnings = append(warnings, fmt.Sprintf("read_only_toolsets: toolset %s does not exist", name))
+			continue
+		}
+		toolset.SetReadOnly()
+	}
+
+	for _, name := range policy.DisableTools {
+		found := false
+		for _, toolset := range tg.Toolsets {
+			if toolset.removeTool(name) {
+				found = true
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("disable_tools: %s", tg.unknownToolMessage(name)))
+		}
+	}
+
+	// Before enable_tools, which copies the tools it forces on. Tools forced on earlier, by a toolset alias
+	// limited to some tools, are guarded too.
+	for _, name := range slices.Sorted(maps.Keys(policy.ArgumentPatterns)) {
+		patterns, err := compileArgumentPatterns(policy.ArgumentPatterns[name])
+		if err != nil {
+			// Fail closed, a guardrail that cannot be enforced must not leave the tool unguarded
+			for _, toolset := range tg.Toolsets {
+				toolset.removeTool(name)
+			}
+			warnings = append(warnings, fmt.Sprintf("argument_patterns: %s: %v, tool disabled", name, err))
+			continue
+		}
+		found := false
+		for _, toolset := range tg.Toolsets {
+			restricted, unknownArguments := toolset.restrictArguments(name, patterns)
+			if restricted {
+				found = true
+			}
+			for _, argument := range unknownArguments {
+				warnings = append(warnings, fmt.Sprintf("argument_patterns: tool %s has no argument %s", name, argument))
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("argument_patterns: %s", tg.unknownToolMessage(name)))
+		}
+	}
+
+	// After argument_patterns, so that the patterns check the defaults too
+	for _, name := range slices.Sorted(maps.Keys(policy.ArgumentDefaults)) {
+		found := false
+		for _, toolset := range tg.Toolsets {
+			defaulted, unknownArguments := toolset.defaultArguments(name, policy.ArgumentDefaults[name])
+			if defaulted {
+				found = true
+			}
+			for _, argument := range unknownArguments {
+				warnings = append(warnings, fmt.Sprintf("argument_defaults: tool %s has no argument %s", name, argument))
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("argument_defaults: %s", tg.unknownToolMessage(name)))
+		}
+	}
+
+	// The limits themselves are enforced by the server, which knows the server-wide one
+	for _, name := range slices.Sorted(maps.Keys(policy.MaxArgumentChars)) {
+		found := false
+		for _, toolset := range tg.Toolsets {
+			if toolset.hasTool(name) {
+				found = true
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("max_argument_chars: %s", tg.unknownToolMessage(name)))
+		}
+	}
+
+	for _, name := range policy.EnableTools {
+		if slices.Contains(policy.DisableTools, name) {
+			warnings = append(warnings, fmt.Sprintf("enable_tools: tool %s is also disabled", name))
+			continue
+		}
+		found := false
+		for _, toolset := range tg.Toolsets {
+			if toolset.forceTool(name) {
+				found = true
+			}
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("enable_tools: %s", tg.unknownToolMessage(name)))
+		}
+	}
+	return warnings
+}
+
+func (tg *ToolsetGroup) unknownToolMessage(name string) string {
+	if tg.readOnly {
+		return fmt.Sprintf("tool %s does not exist or is not available in read-only mode", name)
+	}
+	return fmt.Sprintf("tool %s does not exist", name)
+}
+
+// removeTool drops the tool called name from the toolset, reporting whether it was there
+func (t *Toolset) removeTool(name string) bool {
+	isNamed := func(tool server.ServerTool) bool { return tool.Tool.Name == name }
+	before := len(t.readTools) + len(t.writeTools) + len(t.forcedTools)
+	t.readTools = slices.DeleteFunc(t.readTools, isNamed)
+	t.writeTools = slices.DeleteFunc(t.writeTools, isNamed)
+	t.forcedTools = slices.DeleteFunc(t.forcedTools, isNamed)
+	return len(t.readTools)+len(t.writeTools)+len(t.forcedTools) != before
+}
+
+// hasTool reports whether the toolset offers the tool called name
+func (t *Toolset) hasTool(name string) bool {
+	return slices.ContainsFunc(t.GetAvailableTools(), func(tool server.ServerTool) bool { return tool.Tool.Name == name })
+}
+
+// forceTool registers the tool called name even when the toolset is not enabled, reporting whether
+// the toolset offers it. Write tools of read-only toolsets are not offered.
+func (t *Toolset) forceTool(name string) bool {
+	for _, tool := range t.GetAvailableTools() {
+		if tool.Tool.Name == name {
+			if !slices.ContainsFunc(t.forcedTools, func(forced server.ServerTool) bool { return forced.Tool.Name == name }) {
+				t.forcedTools = append(t.forcedTools, tool)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// compileArgumentPatterns compiles the patterns of one tool, anchored so that they match whole values
+func compileArgumentPatterns(patterns map[string]string) (map[string]*regexp.Regexp, error) {
+	compiled := make(map[string]*regexp.Regexp, len(patterns))
+	for _, argument := range slices.Sorted(maps.Keys(patterns)) {
+		re, err := regexp.Compile("^(?:" + patterns[argument] + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for argument %s: %w", argument, err)
+		}
+		compiled[argument] = re
+	}
+	return compiled, nil
+}
+
+// restrictArguments makes the tool called name reject calls whose arguments do not match patterns,
+// reporting whether the toolset has the tool and the patterns naming arguments the tool does not take.
+func (t *Toolset) restrictArguments(name string, patterns map[string]*regexp.Regexp) (bool, []string) {
+	found := false
+	var unknownArguments []string
+	// Forced tools are copies, each copy is wrapped but its arguments are only reported once
+	for _, tools := range [][]server.ServerTool{t.readTools, t.writeTools, t.forcedTools} {
+		for i, tool := range tools {
+			if tool.Tool.Name != name {
+				continue
+			}
+			if !found {
+				for _, argument := range slices.Sorted(maps.Keys(patterns)) {
+					if _, ok := tool.Tool.InputSchema.Properties[argument]; !ok {
+						unknownArguments = append(unknownArguments, argument)
+					}
+				}
+			}
+			found = true
+			tools[i].Handler = argumentPatternsHandler(patterns, tool.Handler)
+		}
+	}
+	return found, unknownArguments
+}
+
+// argumentPatternsHandler rejects calls whose arguments do not match patterns with an argument_not_allowed
+// tool error. A missing argument is checked as an empty value, so leaving it out cannot get around a pattern.
+func argumentPatternsHandler(patterns map[string]*regexp.Regexp, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	arguments := slices.Sorted(maps.Keys(patterns))
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := request.GetArguments()
+		for _, argument := range arguments {
+			value := ""
+			if v, ok := args[argument]; ok && v != nil {
+				value = fmt.Sprint(v)
+			}
+			if patterns[argument].MatchString(value) {
+				continue
+			}
+
+			r, err := json.Marshal(map[string]any{
+				"error":    ErrorCodeArgumentNotAllowed,
+				"message":  fmt.Sprintf("The value of %s is not allowed by the tool policy", argument),
+				"argument": argument,
+				"value":    value,
+				"pattern":  patterns[argument].String(),
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s: %s", ErrorCodeArgumentNotAllowed, argument)), nil
+			}
+			return mcp.NewToolResultError(string(r)), nil
+		}
+		return next(ctx, request)
+	}
+}
+
+// normalizeArgumentDefaults converts the YAML values of defaults to the types they have when decoded from the
+// JSON of a tool call, such as float64 for numbers, so that the tools read them like the arguments of the agent
+func normalizeArgumentDefaults(defaults map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(defaults)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// defaultArguments makes the tool called name fill in the arguments of defaults a call leaves out, reporting
+// whether the toolset has the tool and the defaults naming arguments the tool does not take.
+func (t *Toolset) defaultArguments(name string, defaults map[string]any) (bool, []string) {
+	found := false
+	var unknownArguments []string
+	// Forced tools are copies, each copy is wrapped but its arguments are only reported once
+	for _, tools := range [][]server.ServerTool{t.readTools, t.writeTools, t.forcedTools} {
+		for i, tool := range tools {
+			if tool.Tool.Name != name {
+				continue
+			}
+			if !found {
+				for _, argument := range slices.Sorted(maps.Keys(defaults)) {
+					if _, ok := tool.Tool.InputSchema.Properties[argument]; !ok {
+						unknownArguments = append(unknownArguments, argument)
+					}
+				}
+			}
+			found = true
+			tools[i].Handler = argumentDefaultsHandler(defaults, tool.Handler)
+		}
+	}
+	return found, unknownArguments
+}
+
+// argumentDefaultsHandler sets the arguments of defaults that a call leaves out or passes as null, the
+// arguments the call passes are kept.
+func argumentDefaultsHandler(defaults map[string]any, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := maps.Clone(request.GetArguments())
+		if args == nil {
+			args = make(map[string]any, len(defaults))
+		}
+		for argument, value := range defaults {
+			if v, ok := args[argument]; !ok || v == nil {
+				args[argument] = value
+			}
+		}
+		request.Params.Arguments = args
+		return next(ctx, request)
+	}
+}
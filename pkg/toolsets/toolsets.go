@@ -40,6 +40,8 @@ type Toolset struct {
 	readOnly    bool
 	writeTools  []server.ServerTool
 	readTools   []server.ServerTool
+	// forcedTools are registered even when the toolset is not enabled, see ApplyToolPolicy
+	forcedTools []server.ServerTool
 }
 
 func (t *Toolset) GetActiveTools() []server.ServerTool {
@@ -49,7 +51,7 @@ func (t *Toolset) GetActiveTools() []server.ServerTool {
 		}
 		return append(t.readTools, t.writeTools...)
 	}
-	return nil
+	return t.forcedTools
 }
 
 func (t *Toolset) GetAvailableTools() []server.ServerTool {
@@ -61,6 +63,9 @@ func (t *Toolset) GetAvailableTools() []server.ServerTool {
 
 func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	if !t.Enabled {
+		for _, tool := range t.forcedTools {
+			s.AddTool(tool.Tool, tool.Handler)
+		}
 		return
 	}
 	for _, tool := range t.readTools {
@@ -73,6 +78,25 @@ func (t *Toolset) RegisterTools(s *server.MCPServer) {
 	}
 }
 
+// ApplyToolOptions applies opts to the definition of every tool in the toolset
+func (t *Toolset) ApplyToolOptions(opts ...mcp.ToolOption) {
+	for i := range t.readTools {
+		for _, opt := range opts {
+			opt(&t.readTools[i].Tool)
+		}
+	}
+	for i := range t.writeTools {
+		for _, opt := range opts {
+			opt(&t.writeTools[i].Tool)
+		}
+	}
+	for i := range t.forcedTools {
+		for _, opt := range opts {
+			opt(&t.forcedTools[i].Tool)
+		}
+	}
+}
+
 func (t *Toolset) SetReadOnly() {
 	// Set the toolset to read-only
 	t.readOnly = true
@@ -102,7 +126,9 @@ func (t *Toolset) AddReadTools(tools ...server.ServerTool) *Toolset {
 }
 
 type ToolsetGroup struct {
-	Toolsets     map[string]*Toolset
+	Toolsets map[string]*Toolset
+	// aliases maps former toolset names to the toolsets now holding their tools, see AddAlias
+	aliases      map[string]toolsetAlias
 	everythingOn bool
 	readOnly     bool
 }
@@ -110,11 +136,41 @@ type ToolsetGroup struct {
 func NewToolsetGroup(readOnly bool) *ToolsetGroup {
 	return &ToolsetGroup{
 		Toolsets:     make(map[string]*Toolset),
+		aliases:      make(map[string]toolsetAlias),
 		everythingOn: false,
 		readOnly:     readOnly,
 	}
 }
 
+// toolsetAlias is a former toolset name, see AddAlias
+type toolsetAlias struct {
+	name string
+	// tools are the tools enabling the alias registers, or all the tools of the toolset when empty
+	tools []string
+}
+
+// AddAlias lets alias name the toolset called name, so that configurations naming a toolset whose tools
+// moved to another one keep working. When tools are given, enabling alias only registers those tools of
+// the toolset, so that a former name does not grant the tools the toolset gained with the move.
+func (tg *ToolsetGroup) AddAlias(alias, name string, tools ...string) {
+	tg.aliases[alias] = toolsetAlias{name: name, tools: tools}
+}
+
+// resolve returns the name of the toolset name is an alias of, or name itself
+func (tg *ToolsetGroup) resolve(name string) string {
+	if alias, ok := tg.aliases[name]; ok {
+		return alias.name
+	}
+	return name
+}
+
+// ResolveName returns the name of the toolset name refers to, following aliases, and whether it exists
+func (tg *ToolsetGroup) ResolveName(name string) (string, bool) {
+	name = tg.resolve(name)
+	_, exists := tg.Toolsets[name]
+	return name, exists
+}
+
 func (tg *ToolsetGroup) AddToolset(ts *Toolset) {
 	if tg.readOnly {
 		ts.SetReadOnly()
@@ -137,7 +193,7 @@ func (tg *ToolsetGroup) IsEnabled(name string) bool {
 		return true
 	}
 
-	feature, exists := tg.Toolsets[name]
+	feature, exists := tg.Toolsets[tg.resolve(name)]
 	if !exists {
 		return false
 	}
@@ -170,12 +226,17 @@ func (tg *ToolsetGroup) EnableToolsets(names []string) error {
 }
 
 func (tg *ToolsetGroup) EnableToolset(name string) error {
-	toolset, exists := tg.Toolsets[name]
+	toolset, exists := tg.Toolsets[tg.resolve(name)]
 	if !exists {
 		return NewToolsetDoesNotExistError(name)
 	}
+	if alias, ok := tg.aliases[name]; ok && len(alias.tools) > 0 {
+		for _, tool := range alias.tools {
+			toolset.forceTool(tool)
+		}
+		return nil
+	}
 	toolset.Enabled = true
-	tg.Toolsets[name] = toolset
 	return nil
 }
 
@@ -185,8 +246,15 @@ func (tg *ToolsetGroup) RegisterTools(s *server.MCPServer) {
 	}
 }
 
+// ApplyToolOptions applies opts to the definition of every tool in the group
+func (tg *ToolsetGroup) ApplyToolOptions(opts ...mcp.ToolOption) {
+	for _, toolset := range tg.Toolsets {
+		toolset.ApplyToolOptions(opts...)
+	}
+}
+
 func (tg *ToolsetGroup) GetToolset(name string) (*Toolset, error) {
-	toolset, exists := tg.Toolsets[name]
+	toolset, exists := tg.Toolsets[tg.resolve(name)]
 	if !exists {
 		return nil, NewToolsetDoesNotExistError(name)
 	}
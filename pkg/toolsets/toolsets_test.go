@@ -1,8 +1,13 @@
 package toolsets
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -250,3 +255,422 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestToolsetGroup_AddAlias(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	toolset := NewToolset("new-name", "desc")
+	tsg.AddToolset(toolset)
+	tsg.AddAlias("old-name", "new-name")
+
+	if err := tsg.EnableToolsets([]string{"old-name"}); err != nil {
+		t.Fatalf("expected no error enabling an alias, got %v", err)
+	}
+	if !toolset.Enabled || !tsg.IsEnabled("old-name") {
+		t.Error("expected the aliased toolset to be enabled")
+	}
+	if _, exists := tsg.Toolsets["old-name"]; exists {
+		t.Error("expected the alias not to be added as a toolset")
+	}
+
+	got, err := tsg.GetToolset("old-name")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got != toolset {
+		t.Errorf("expected to get the aliased toolset")
+	}
+}
+
+func TestToolsetGroup_AddAliasLimitedToTools(t *testing.T) {
+	readOnly := mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)})
+	tsg := NewToolsetGroup(false)
+	toolset := NewToolset("new-name", "desc").
+		AddReadTools(
+			NewServerTool(mcp.NewTool("moved", readOnly), nil),
+			NewServerTool(mcp.NewTool("already-there", readOnly), nil),
+		).
+		AddWriteTools(NewServerTool(mcp.NewTool("added", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(false)})), nil))
+	tsg.AddToolset(toolset)
+	tsg.AddAlias("old-name", "new-name", "moved")
+
+	// Enabling the alias twice must not register its tools twice
+	if err := tsg.EnableToolsets([]string{"old-name", "old-name"}); err != nil {
+		t.Fatalf("expected no error enabling an alias, got %v", err)
+	}
+	if toolset.Enabled {
+		t.Error("expected the aliased toolset not to be enabled as a whole")
+	}
+	var names []string
+	for _, tool := range toolset.GetActiveTools() {
+		names = append(names, tool.Tool.Name)
+	}
+	if len(names) != 1 || names[0] != "moved" {
+		t.Errorf("expected only the tool of the alias to be active, got %v", names)
+	}
+
+	// The new name still enables the whole toolset
+	if err := tsg.EnableToolsets([]string{"new-name"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(toolset.GetActiveTools()) != 3 {
+		t.Errorf("expected all tools to be active, got %d", len(toolset.GetActiveTools()))
+	}
+}
+
+func TestToolsetGroup_ApplyToolPolicyToAliasTools(t *testing.T) {
+	var received map[string]any
+	handler := func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		received = request.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	}
+	tsg := NewToolsetGroup(false)
+	toolset := NewToolset("new-name", "desc").
+		AddReadTools(
+			NewServerTool(mcp.NewTool("list_alerts", mcp.WithString("state"), mcp.WithString("ref"), mcp.WithReadOnlyHintAnnotation(true)), handler),
+			NewServerTool(mcp.NewTool("get_alert", mcp.WithReadOnlyHintAnnotation(true)), handler),
+		)
+	tsg.AddToolset(toolset)
+	tsg.AddAlias("old-name", "new-name", "list_alerts", "get_alert")
+	// The server enables the toolsets before it applies the policy
+	if err := tsg.EnableToolsets([]string{"old-name"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	warnings := tsg.ApplyToolPolicy(&ToolPolicy{
+		DisableTools:     []string{"get_alert"},
+		ArgumentPatterns: map[string]map[string]string{"list_alerts": {"ref": "refs/heads/main", "missing": ".*"}},
+		ArgumentDefaults: map[string]map[string]any{"list_alerts": {"state": "open"}},
+	})
+	// The arguments the tool does not take are reported once, not once per copy of the tool
+	if strings.Join(warnings, "\n") != "argument_patterns: tool list_alerts has no argument missing" {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+
+	active := toolset.GetActiveTools()
+	if len(active) != 1 || active[0].Tool.Name != "list_alerts" {
+		t.Fatalf("expected only list_alerts to stay forced on, got %v", active)
+	}
+	call := func(args map[string]any) *mcp.CallToolResult {
+		received = nil
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "list_alerts"
+		request.Params.Arguments = args
+		result, err := active[0].Handler(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	if result := call(map[string]any{"ref": "refs/heads/feature"}); !result.IsError || received != nil {
+		t.Error("expected the argument patterns to reject the call of the forced tool")
+	}
+	if result := call(map[string]any{"ref": "refs/heads/main", "missing": ""}); result.IsError || received["state"] != "open" {
+		t.Errorf("expected the argument defaults to be applied to the forced tool, got %v", received)
+	}
+}
+
+func TestToolsetGroup_ApplyToolPolicyDisablesForcedOnlyTool(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	toolset := NewToolset("repos", "desc")
+	toolset.forcedTools = []server.ServerTool{NewServerTool(mcp.NewTool("get_file", mcp.WithReadOnlyHintAnnotation(true)), nil)}
+	tsg.AddToolset(toolset)
+
+	if warnings := tsg.ApplyToolPolicy(&ToolPolicy{DisableTools: []string{"get_file"}}); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %q", warnings)
+	}
+	if len(toolset.GetActiveTools()) != 0 {
+		t.Error("expected get_file to be disabled")
+	}
+}
+
+func TestToolsetGroup_ApplyToolOptions(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	toolset := NewToolset("my-toolset", "desc").
+		AddReadTools(NewServerTool(mcp.NewTool("read", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)})), nil)).
+		AddWriteTools(NewServerTool(mcp.NewTool("write", mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(false)})), nil))
+	tsg.AddToolset(toolset)
+
+	tsg.ApplyToolOptions(mcp.WithString("extra"))
+
+	for _, tool := range toolset.GetAvailableTools() {
+		if _, ok := tool.Tool.InputSchema.Properties["extra"]; !ok {
+			t.Errorf("expected tool %s to have the extra property", tool.Tool.Name)
+		}
+	}
+}
+
+func TestParseToolPolicy(t *testing.T) {
+	policy, err := ParseToolPolicy(strings.NewReader(`
+enable_tools: [get_me]
+disable_tools:
+  - delete_file
+read_only_toolsets: [repos]
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(policy.EnableTools) != 1 || policy.EnableTools[0] != "get_me" {
+		t.Errorf("unexpected enable_tools %v", policy.EnableTools)
+	}
+	if len(policy.DisableTools) != 1 || policy.DisableTools[0] != "delete_file" {
+		t.Errorf("unexpected disable_tools %v", policy.DisableTools)
+	}
+	if len(policy.ReadOnlyToolsets) != 1 || policy.ReadOnlyToolsets[0] != "repos" {
+		t.Errorf("unexpected read_only_toolsets %v", policy.ReadOnlyToolsets)
+	}
+
+	if _, err := ParseToolPolicy(strings.NewReader("disabled_tools: [delete_file]")); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+	if _, err := ParseToolPolicy(strings.NewReader("")); err != nil {
+		t.Errorf("expected an empty policy to be valid, got %v", err)
+	}
+}
+
+func TestToolsetGroup_ApplyToolPolicy(t *testing.T) {
+	readTool := func(name string) server.ServerTool {
+		return NewServerTool(mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(true)})), nil)
+	}
+	writeTool := func(name string) server.ServerTool {
+		return NewServerTool(mcp.NewTool(name, mcp.WithToolAnnotation(mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(false)})), nil)
+	}
+	toolNames := func(tools []server.ServerTool) []string {
+		names := make([]string, 0, len(tools))
+		for _, tool := range tools {
+			names = append(names, tool.Tool.Name)
+		}
+		return names
+	}
+
+	tsg := NewToolsetGroup(false)
+	repos := NewToolset("repos", "desc").
+		AddReadTools(readTool("get_file")).
+		AddWriteTools(writeTool("create_file"), writeTool("delete_file"))
+	issues := NewToolset("issues", "desc").
+		AddReadTools(readTool("get_issue"), readTool("list_issues")).
+		AddWriteTools(writeTool("create_issue"))
+	gists := NewToolset("gists", "desc").
+		AddReadTools(readTool("get_gist")).
+		AddWriteTools(writeTool("create_gist"))
+	tsg.AddToolset(repos)
+	tsg.AddToolset(issues)
+	tsg.AddToolset(gists)
+	if err := tsg.EnableToolsets([]string{"repos", "issues"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	warnings := tsg.ApplyToolPolicy(&ToolPolicy{
+		EnableTools:      []string{"get_gist", "create_gist", "missing_tool"},
+		DisableTools:     []string{"delete_file", "create_gist"},
+		ReadOnlyToolsets: []string{"issues", "missing_toolset"},
+	})
+
+	if got := strings.Join(toolNames(repos.GetActiveTools()), ","); got != "get_file,create_file" {
+		t.Errorf("expected delete_file to be disabled, got %s", got)
+	}
+	if got := strings.Join(toolNames(issues.GetActiveTools()), ","); got != "get_issue,list_issues" {
+		t.Errorf("expected issues to be read-only, got %s", got)
+	}
+	if got := strings.Join(toolNames(gists.GetActiveTools()), ","); got != "get_gist" {
+		t.Errorf("expected only get_gist to be forced on, got %s", got)
+	}
+
+	expectedWarnings := []string{
+		"read_only_toolsets: toolset missing_toolset does not exist",
+		"enable_tools: tool create_gist is also disabled",
+		"enable_tools: tool missing_tool does not exist",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+}
+
+func TestToolsetGroup_ApplyToolPolicyArgumentPatterns(t *testing.T) {
+	called := 0
+	handler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called++
+		return mcp.NewToolResultText("ok"), nil
+	}
+	tsg := NewToolsetGroup(false)
+	repos := NewToolset("repos", "desc").
+		AddReadTools(NewServerTool(mcp.NewTool("get_file", mcp.WithString("ref"), mcp.WithReadOnlyHintAnnotation(true)), handler)).
+		AddWriteTools(
+			NewServerTool(mcp.NewTool("push_files", mcp.WithString("branch"), mcp.WithReadOnlyHintAnnotation(false)), handler),
+			NewServerTool(mcp.NewTool("delete_file", mcp.WithString("branch"), mcp.WithReadOnlyHintAnnotation(false)), handler),
+		)
+	tsg.AddToolset(repos)
+	if err := tsg.EnableToolsets([]string{"repos"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	warnings := tsg.ApplyToolPolicy(&ToolPolicy{
+		ArgumentPatterns: map[string]map[string]string{
+			"push_files":   {"branch": "feature/.*"},
+			"get_file":     {"path": ".*"},
+			"delete_file":  {"branch": "("},
+			"missing_tool": {"branch": ".*"},
+		},
+	})
+
+	expectedWarnings := []string{
+		"argument_patterns: delete_file: invalid pattern for argument branch: error parsing regexp: missing closing ): `^(?:()$`, tool disabled",
+		"argument_patterns: tool get_file has no argument path",
+		"argument_patterns: tool missing_tool does not exist",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+
+	var pushFiles server.ServerTool
+	for _, tool := range repos.GetActiveTools() {
+		if tool.Tool.Name == "delete_file" {
+			t.Error("expected delete_file to be disabled")
+		}
+		if tool.Tool.Name == "push_files" {
+			pushFiles = tool
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]any
+		allowed bool
+	}{
+		{name: "matching value", args: map[string]any{"branch": "feature/login"}, allowed: true},
+		{name: "partial match", args: map[string]any{"branch": "main-feature/login"}, allowed: false},
+		{name: "other value", args: map[string]any{"branch": "main"}, allowed: false},
+		{name: "missing argument", args: map[string]any{}, allowed: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called = 0
+			request := mcp.CallToolRequest{}
+			request.Params.Name = "push_files"
+			request.Params.Arguments = tc.args
+
+			result, err := pushFiles.Handler(context.Background(), request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.allowed {
+				if result.IsError || called != 1 {
+					t.Errorf("expected the call to be allowed")
+				}
+				return
+			}
+			if !result.IsError || called != 0 {
+				t.Fatalf("expected the call to be rejected")
+			}
+			text := result.Content[0].(mcp.TextContent).Text
+			if !strings.Contains(text, ErrorCodeArgumentNotAllowed) {
+				t.Errorf("expected an %s error, got %s", ErrorCodeArgumentNotAllowed, text)
+			}
+		})
+	}
+
+	if _, err := ParseToolPolicy(strings.NewReader("argument_patterns:\n  push_files:\n    branch: \"(\"\n")); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestToolsetGroup_ApplyToolPolicyArgumentDefaults(t *testing.T) {
+	var received map[string]any
+	handler := func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		received = request.GetArguments()
+		return mcp.NewToolResultText("ok"), nil
+	}
+	tsg := NewToolsetGroup(false)
+	repos := NewToolset("repos", "desc").
+		AddReadTools(NewServerTool(mcp.NewTool("list_commits", mcp.WithString("sha"), mcp.WithNumber("perPage"), mcp.WithReadOnlyHintAnnotation(true)), handler)).
+		AddWriteTools(NewServerTool(mcp.NewTool("push_files", mcp.WithString("branch"), mcp.WithReadOnlyHintAnnotation(false)), handler))
+	tsg.AddToolset(repos)
+	if err := tsg.EnableToolsets([]string{"repos"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	policy, err := ParseToolPolicy(strings.NewReader(`
+argument_defaults:
+  list_commits:
+    perPage: 100
+    sha: main
+    author: octocat
+  push_files:
+    branch: feature/defaults
+  missing_tool:
+    perPage: 100
+argument_patterns:
+  push_files:
+    branch: "feature/.*"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	warnings := tsg.ApplyToolPolicy(policy)
+
+	expectedWarnings := []string{
+		"argument_defaults: tool list_commits has no argument author",
+		"argument_defaults: tool missing_tool does not exist",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+
+	tools := make(map[string]server.ServerTool)
+	for _, tool := range repos.GetActiveTools() {
+		tools[tool.Tool.Name] = tool
+	}
+	call := func(name string, args map[string]any) *mcp.CallToolResult {
+		received = nil
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = args
+		result, err := tools[name].Handler(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	// Numbers are passed as float64, like the numbers of a JSON tool call
+	call("list_commits", map[string]any{"sha": "release", "perPage": nil})
+	if received["sha"] != "release" || received["perPage"] != float64(100) || received["author"] != "octocat" {
+		t.Errorf("expected the defaults to fill in the missing arguments only, got %v", received)
+	}
+
+	// Argument patterns check the defaults as well
+	if result := call("push_files", nil); result.IsError || received["branch"] != "feature/defaults" {
+		t.Errorf("expected the default branch to be allowed, got %v", received)
+	}
+	if result := call("push_files", map[string]any{"branch": "main"}); !result.IsError || received != nil {
+		t.Error("expected an explicit branch to override the default and be checked")
+	}
+}
+
+func TestToolsetGroup_ApplyToolPolicyMaxArgumentChars(t *testing.T) {
+	tsg := NewToolsetGroup(false)
+	tsg.AddToolset(NewToolset("repos", "desc").
+		AddWriteTools(NewServerTool(mcp.NewTool("push_files", mcp.WithReadOnlyHintAnnotation(false)), nil)))
+
+	policy, err := ParseToolPolicy(strings.NewReader(`
+max_argument_chars:
+  push_files: 5000000
+  missing_tool: 10
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.MaxArgumentChars["push_files"] != 5000000 {
+		t.Errorf("unexpected max_argument_chars %v", policy.MaxArgumentChars)
+	}
+
+	warnings := tsg.ApplyToolPolicy(policy)
+	expectedWarnings := []string{"max_argument_chars: tool missing_tool does not exist"}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("unexpected warnings %q", warnings)
+	}
+
+	if _, err := ParseToolPolicy(strings.NewReader("max_argument_chars: {push_files: -1}")); err == nil {
+		t.Error("expected an error for a negative limit")
+	}
+}
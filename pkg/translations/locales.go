@@ -0,0 +1,106 @@
+package translations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// localeFilePattern matches the per-locale translation files, such as github-mcp-server-config.fr.json.
+// They use the same keys as github-mcp-server-config.json.
+const localeFilePattern = "github-mcp-server-config.*.json"
+
+// LocaleTranslations maps a lower case locale, such as "fr" or "pt-br", to its translated values
+// keyed by upper case translation key.
+type LocaleTranslations map[string]map[string]string
+
+// LoadLocaleTranslations reads the per-locale translation files found in dir. A directory without
+// any yields an empty set.
+func LoadLocaleTranslations(dir string) (LocaleTranslations, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, localeFilePattern))
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(LocaleTranslations, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		locale := strings.TrimSuffix(strings.TrimPrefix(name, "github-mcp-server-config."), ".json")
+		if locale == "" || strings.Contains(locale, ".") {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+		var values map[string]string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+
+		translated := make(map[string]string, len(values))
+		for key, value := range values {
+			translated[strings.ToUpper(key)] = value
+		}
+		locales[strings.ToLower(locale)] = translated
+	}
+	return locales, nil
+}
+
+// Match picks the best locale for preference, which is either a single tag such as "fr-CA" or an
+// Accept-Language header value. A tag that has no translations of its own falls back to its base
+// language, so "fr-CA" matches "fr". It reports false when no locale matches.
+func (l LocaleTranslations) Match(preference string) (string, bool) {
+	type weightedTag struct {
+		tag    string
+		weight float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(preference, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, "_", "-")))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+		tags = append(tags, weightedTag{tag: tag, weight: weight})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].weight > tags[j].weight })
+
+	for _, t := range tags {
+		if _, ok := l[t.tag]; ok {
+			return t.tag, true
+		}
+		if base, _, found := strings.Cut(t.tag, "-"); found {
+			if _, ok := l[base]; ok {
+				return base, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Translate returns the value of key in locale, or defaultValue when the locale does not translate it
+func (l LocaleTranslations) Translate(locale, key, defaultValue string) string {
+	if value, ok := l[locale][strings.ToUpper(key)]; ok {
+		return value
+	}
+	return defaultValue
+}
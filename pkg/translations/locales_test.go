@@ -0,0 +1,58 @@
+package translations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadLocaleTranslations(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "github-mcp-server-config.json"), []byte(`{"TOOL_GET_ME_DESCRIPTION": "default"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "github-mcp-server-config.fr.json"), []byte(`{"tool_get_me_description": "Mon profil"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "github-mcp-server-config.pt-BR.json"), []byte(`{"TOOL_GET_ME_DESCRIPTION": "Meu perfil"}`), 0600))
+
+	locales, err := LoadLocaleTranslations(dir)
+	require.NoError(t, err)
+	assert.Len(t, locales, 2)
+	assert.Equal(t, "Mon profil", locales.Translate("fr", "TOOL_GET_ME_DESCRIPTION", "default"))
+	assert.Equal(t, "Meu perfil", locales.Translate("pt-br", "TOOL_GET_ME_DESCRIPTION", "default"))
+	assert.Equal(t, "default", locales.Translate("fr", "TOOL_GET_ISSUE_DESCRIPTION", "default"))
+	assert.Equal(t, "default", locales.Translate("de", "TOOL_GET_ME_DESCRIPTION", "default"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "github-mcp-server-config.de.json"), []byte(`not json`), 0600))
+	_, err = LoadLocaleTranslations(dir)
+	assert.ErrorContains(t, err, "github-mcp-server-config.de.json")
+}
+
+func Test_LocaleTranslationsMatch(t *testing.T) {
+	locales := LocaleTranslations{"fr": {}, "pt-br": {}, "de": {}}
+
+	tests := []struct {
+		preference string
+		expected   string
+		matched    bool
+	}{
+		{preference: "fr", expected: "fr", matched: true},
+		{preference: "FR", expected: "fr", matched: true},
+		{preference: "fr-CA", expected: "fr", matched: true},
+		{preference: "pt_BR", expected: "pt-br", matched: true},
+		{preference: "pt-PT", matched: false},
+		{preference: "en-US,en;q=0.9,de;q=0.8", expected: "de", matched: true},
+		{preference: "de;q=0.5, fr;q=0.8", expected: "fr", matched: true},
+		{preference: "fr;q=0, de;q=0.1", expected: "de", matched: true},
+		{preference: "*", matched: false},
+		{preference: "", matched: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.preference, func(t *testing.T) {
+			locale, ok := locales.Match(tc.preference)
+			assert.Equal(t, tc.matched, ok)
+			assert.Equal(t, tc.expected, locale)
+		})
+	}
+}